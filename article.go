@@ -0,0 +1,252 @@
+package intercom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ArticleService handles interactions with the API through an ArticleRepository.
+type ArticleService struct {
+	Repository ArticleRepository
+}
+
+// ArticleList holds a page of Articles
+type ArticleList struct {
+	Type       string     `json:"type,omitempty"`
+	Pages      PageParams `json:"pages"`
+	TotalCount int64      `json:"total_count"`
+	Articles   []Article  `json:"data"`
+}
+
+// An Article is a Help Center article. Its Body is HTML.
+// Not all of the fields are writeable to the API, non-writeable fields are
+// stripped out from the request. Please see the API documentation for details.
+type Article struct {
+	Type              string              `json:"type,omitempty"`
+	ID                string              `json:"id,omitempty"`
+	WorkspaceID       string              `json:"workspace_id,omitempty"`
+	Title             string              `json:"title"`
+	Description       string              `json:"description,omitempty"`
+	Body              string              `json:"body,omitempty"`
+	AuthorID          int64               `json:"author_id"`                    // The ID of the Admin authoring the Article.
+	State             string              `json:"state,omitempty"`              // "published" or "draft"
+	ParentID          string              `json:"parent_id,omitempty"`          // The ID of the Collection or Section holding the Article.
+	ParentIDs         []string            `json:"parent_ids,omitempty"`         // The IDs of every Collection and Section above the Article.
+	ParentType        string              `json:"parent_type,omitempty"`        // "collection" or "section"
+	DefaultLocale     string              `json:"default_locale,omitempty"`     // The locale of the Title, Description and Body.
+	TranslatedContent ArticleTranslations `json:"translated_content,omitempty"` // Content by locale, such as "fr".
+	URL               string              `json:"url,omitempty"`
+	CreatedAt         int64               `json:"created_at,omitempty"`
+	UpdatedAt         int64               `json:"updated_at,omitempty"`
+}
+
+// UnmarshalJSON decodes an Article, whose parent IDs the API sends as numbers.
+func (a *Article) UnmarshalJSON(b []byte) error {
+	type article Article
+	decoded := struct {
+		*article
+		ParentID  json.Number   `json:"parent_id"`
+		ParentIDs []json.Number `json:"parent_ids"`
+	}{article: (*article)(a)}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+	a.ParentID, a.ParentIDs = string(decoded.ParentID), nil
+	for _, id := range decoded.ParentIDs {
+		a.ParentIDs = append(a.ParentIDs, string(id))
+	}
+	return nil
+}
+
+// ArticleContent is the content of an Article in one locale.
+type ArticleContent struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Body        string `json:"body,omitempty"`
+	AuthorID    int64  `json:"author_id"`
+	State       string `json:"state,omitempty"`
+	URL         string `json:"url,omitempty"`
+	CreatedAt   int64  `json:"created_at,omitempty"`
+	UpdatedAt   int64  `json:"updated_at,omitempty"`
+}
+
+// ArticleTranslations hold the content of an Article by locale.
+type ArticleTranslations map[string]ArticleContent
+
+// UnmarshalJSON skips the type of the translations, and the locales without translation.
+func (t *ArticleTranslations) UnmarshalJSON(b []byte) error {
+	translations, err := unmarshalTranslations[ArticleContent](b)
+	*t = translations
+	return err
+}
+
+// ArticleSearchParams filter a search of Articles.
+type ArticleSearchParams struct {
+	Phrase       string `url:"phrase,omitempty"`
+	State        string `url:"state,omitempty"` // "published" or "draft"
+	HelpCenterID string `url:"help_center_id,omitempty"`
+	Highlight    bool   `url:"highlight,omitempty"` // Return the matches of Phrase in ArticleSearchResult.Highlights.
+}
+
+// ArticleSearchResult holds the Articles matching a search.
+type ArticleSearchResult struct {
+	TotalCount int64
+	Pages      PageParams
+	Articles   []Article
+	Highlights []ArticleHighlight
+}
+
+// An ArticleHighlight holds the matches of a search in an Article.
+type ArticleHighlight struct {
+	ArticleID          string              `json:"article_id"`
+	HighlightedTitle   []HighlightedText   `json:"highlighted_title"`
+	HighlightedSummary [][]HighlightedText `json:"highlighted_summary"`
+}
+
+// HighlightedText is a piece of text, of type "highlight" where it matches the search, "plain" otherwise.
+type HighlightedText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+var articleStates = [...]string{
+	"published",
+	"draft",
+}
+
+// List Articles
+func (a *ArticleService) List(params PageParams) (ArticleList, error) {
+	return a.Repository.list(params)
+}
+
+// IterateAll returns an ArticleIterator over all Articles, fetching pages of perPage as needed.
+// A perPage of 0 uses the API default.
+func (a *ArticleService) IterateAll(perPage int64) *ArticleIterator {
+	return &ArticleIterator{pageIterator[Article]{fetch: func(page int64) ([]Article, PageParams, error) {
+		list, err := a.List(PageParams{Page: page, PerPage: perPage})
+		return list.Articles, list.Pages, err
+	}}}
+}
+
+// Find an Article by its ID
+func (a *ArticleService) Find(id string) (Article, error) {
+	if id == "" {
+		return Article{}, errors.New("Missing Article ID")
+	}
+	return a.Repository.find(id)
+}
+
+// Search Articles
+func (a *ArticleService) Search(params ArticleSearchParams) (ArticleSearchResult, error) {
+	if params.State != "" && !containsString(articleStates[:], params.State) {
+		return ArticleSearchResult{}, fmt.Errorf("Invalid Article State %q", params.State)
+	}
+	return a.Repository.search(params)
+}
+
+// Create an Article. A Title and AuthorID are required; Articles are drafts unless State is "published".
+func (a *ArticleService) Create(article *Article) (Article, error) {
+	if article.Title == "" {
+		return Article{}, errors.New("Missing Article Title")
+	}
+	if article.AuthorID == 0 {
+		return Article{}, errors.New("Missing Article Author")
+	}
+	if err := validateArticle(article); err != nil {
+		return Article{}, err
+	}
+	return a.Repository.create(article)
+}
+
+// Update an Article. Only the fields set are changed.
+func (a *ArticleService) Update(article *Article) (Article, error) {
+	if article.ID == "" {
+		return Article{}, errors.New("Missing Article ID")
+	}
+	if err := validateArticle(article); err != nil {
+		return Article{}, err
+	}
+	return a.Repository.update(article)
+}
+
+// UpdateTranslation updates the content of an Article in a locale, creating the translation if needed.
+func (a *ArticleService) UpdateTranslation(id, locale string, content ArticleContent) (Article, error) {
+	if locale == "" {
+		return Article{}, errors.New("Missing Article Locale")
+	}
+	return a.Update(&Article{ID: id, TranslatedContent: ArticleTranslations{locale: content}})
+}
+
+// Publish an Article, in its default locale.
+func (a *ArticleService) Publish(id string) (Article, error) {
+	return a.Update(&Article{ID: id, State: "published"})
+}
+
+// Unpublish an Article, making it a draft again.
+func (a *ArticleService) Unpublish(id string) (Article, error) {
+	return a.Update(&Article{ID: id, State: "draft"})
+}
+
+// Delete an Article by its ID
+func (a *ArticleService) Delete(id string) (DeletedObject, error) {
+	if id == "" {
+		return DeletedObject{}, errors.New("Missing Article ID")
+	}
+	return a.Repository.delete(id)
+}
+
+// Translation returns the content of an Article in a locale.
+func (a Article) Translation(locale string) (ArticleContent, bool) {
+	content, ok := a.TranslatedContent[locale]
+	return content, ok
+}
+
+func validateArticle(article *Article) error {
+	if !isNumericID(article.ParentID) {
+		return fmt.Errorf("Invalid Article Parent ID %q", article.ParentID)
+	}
+	if article.State != "" && !containsString(articleStates[:], article.State) {
+		return fmt.Errorf("Invalid Article State %q", article.State)
+	}
+	for locale, content := range article.TranslatedContent {
+		if content.State != "" && !containsString(articleStates[:], content.State) {
+			return fmt.Errorf("Invalid Article State %q for locale %s", content.State, locale)
+		}
+	}
+	return nil
+}
+
+// ArticleIterator iterates over Articles across pages.
+type ArticleIterator struct {
+	pageIterator[Article]
+}
+
+// Article returns the current Article.
+func (it *ArticleIterator) Article() Article {
+	return it.current()
+}
+
+// unmarshalTranslations decodes translated content by locale, skipping its type and the locales without translation.
+func unmarshalTranslations[T any](b []byte) (map[string]T, error) {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	translations := make(map[string]T, len(raw))
+	for locale, content := range raw {
+		if locale == "type" || string(content) == "null" {
+			continue
+		}
+		var translation T
+		if err := json.Unmarshal(content, &translation); err != nil {
+			return nil, err
+		}
+		translations[locale] = translation
+	}
+	return translations, nil
+}
+
+func (a Article) String() string {
+	return fmt.Sprintf("[intercom] article { id: %s, title: %s, state: %s }", a.ID, a.Title, a.State)
+}
@@ -0,0 +1,127 @@
+package intercom
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
+
+// ArticleRepository defines the interface for working with Articles through the API.
+type ArticleRepository interface {
+	list(PageParams) (ArticleList, error)
+	find(id string) (Article, error)
+	search(ArticleSearchParams) (ArticleSearchResult, error)
+	create(*Article) (Article, error)
+	update(*Article) (Article, error)
+	delete(id string) (DeletedObject, error)
+}
+
+// ArticleAPI implements ArticleRepository
+type ArticleAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+type requestArticle struct {
+	Title             string                           `json:"title,omitempty"`
+	Description       string                           `json:"description,omitempty"`
+	Body              string                           `json:"body,omitempty"`
+	AuthorID          int64                            `json:"author_id,omitempty"`
+	State             string                           `json:"state,omitempty"`
+	ParentID          json.Number                      `json:"parent_id,omitempty"`
+	ParentType        string                           `json:"parent_type,omitempty"`
+	TranslatedContent map[string]requestArticleContent `json:"translated_content,omitempty"`
+}
+
+type requestArticleContent struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Body        string `json:"body,omitempty"`
+	AuthorID    int64  `json:"author_id,omitempty"`
+	State       string `json:"state,omitempty"`
+}
+
+type articleSearchResponse struct {
+	TotalCount int64      `json:"total_count"`
+	Pages      PageParams `json:"pages"`
+	Data       struct {
+		Articles   []Article          `json:"articles"`
+		Highlights []ArticleHighlight `json:"highlights"`
+	} `json:"data"`
+}
+
+func (api ArticleAPI) list(params PageParams) (ArticleList, error) {
+	articleList := ArticleList{}
+	data, err := api.httpClient.Get("/articles", params)
+	if err != nil {
+		return articleList, err
+	}
+	err = json.Unmarshal(data, &articleList)
+	return articleList, err
+}
+
+func (api ArticleAPI) find(id string) (Article, error) {
+	return unmarshalToArticle(api.httpClient.Get(fmt.Sprintf("/articles/%s", id), nil))
+}
+
+func (api ArticleAPI) search(params ArticleSearchParams) (ArticleSearchResult, error) {
+	data, err := api.httpClient.Get("/articles/search", params)
+	if err != nil {
+		return ArticleSearchResult{}, err
+	}
+	response := articleSearchResponse{}
+	err = json.Unmarshal(data, &response)
+	return ArticleSearchResult{
+		TotalCount: response.TotalCount,
+		Pages:      response.Pages,
+		Articles:   response.Data.Articles,
+		Highlights: response.Data.Highlights,
+	}, err
+}
+
+func (api ArticleAPI) create(article *Article) (Article, error) {
+	return unmarshalToArticle(api.httpClient.Post("/articles", newRequestArticle(article)))
+}
+
+func (api ArticleAPI) update(article *Article) (Article, error) {
+	return unmarshalToArticle(api.httpClient.Put(fmt.Sprintf("/articles/%s", article.ID), newRequestArticle(article)))
+}
+
+func (api ArticleAPI) delete(id string) (DeletedObject, error) {
+	return unmarshalToDeletedObject(api.httpClient.Delete(fmt.Sprintf("/articles/%s", id), nil))
+}
+
+func newRequestArticle(article *Article) *requestArticle {
+	var translations map[string]requestArticleContent
+	if len(article.TranslatedContent) > 0 {
+		translations = make(map[string]requestArticleContent, len(article.TranslatedContent))
+	}
+	for locale, content := range article.TranslatedContent {
+		translations[locale] = requestArticleContent{
+			Title:       content.Title,
+			Description: content.Description,
+			Body:        content.Body,
+			AuthorID:    content.AuthorID,
+			State:       content.State,
+		}
+	}
+	return &requestArticle{
+		Title:             article.Title,
+		Description:       article.Description,
+		Body:              article.Body,
+		AuthorID:          article.AuthorID,
+		State:             article.State,
+		ParentID:          json.Number(article.ParentID),
+		ParentType:        article.ParentType,
+		TranslatedContent: translations,
+	}
+}
+
+func unmarshalToArticle(data []byte, err error) (Article, error) {
+	savedArticle := Article{}
+	if err != nil {
+		return savedArticle, err
+	}
+	err = json.Unmarshal(data, &savedArticle)
+	return savedArticle, err
+}
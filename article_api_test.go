@@ -0,0 +1,156 @@
+package intercom
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+func TestArticleAPIList(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/articles.json", expectedURI: "/articles"}
+	api := ArticleAPI{httpClient: &http}
+	list, err := api.list(PageParams{Page: 1, PerPage: 25})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if params := http.lastParams.(PageParams); params.PerPage != 25 {
+		t.Errorf("Params were %v", params)
+	}
+	if list.TotalCount != 27 || list.Pages.TotalPages != 2 || len(list.Articles) != 2 {
+		t.Errorf("List was %v", list)
+	}
+	if list.Articles[1].State != "draft" || list.Articles[1].ParentID != "" {
+		t.Errorf("Second article was %v", list.Articles[1])
+	}
+}
+
+func TestArticleAPIFind(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/article.json", expectedURI: "/articles/6871119"}
+	api := ArticleAPI{httpClient: &http}
+	article, err := api.find("6871119")
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if article.Title != "Getting started" || article.AuthorID != 991267834 || article.ParentID != "145" || len(article.ParentIDs) != 1 || article.ParentIDs[0] != "145" || article.ParentType != "collection" {
+		t.Errorf("Article was %v", article)
+	}
+	if len(article.TranslatedContent) != 2 {
+		t.Errorf("Expected the en and fr translations, got %v", article.TranslatedContent)
+	}
+	if fr, ok := article.Translation("fr"); !ok || fr.Title != "Premiers pas" || fr.State != "draft" {
+		t.Errorf("French translation was %v", fr)
+	}
+	if _, ok := article.Translation("de"); ok {
+		t.Errorf("Expected no German translation")
+	}
+}
+
+func TestArticleAPISearch(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/article_search.json", expectedURI: "/articles/search"}
+	api := ArticleAPI{httpClient: &http}
+	result, err := api.search(ArticleSearchParams{Phrase: "getting", Highlight: true})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if params := http.lastParams.(ArticleSearchParams); params.Phrase != "getting" || !params.Highlight {
+		t.Errorf("Params were %v", params)
+	}
+	if result.TotalCount != 1 || len(result.Articles) != 1 || result.Articles[0].ID != "6871119" {
+		t.Errorf("Result was %v", result)
+	}
+	if len(result.Highlights) != 1 || result.Highlights[0].HighlightedTitle[0].Type != "highlight" || result.Highlights[0].HighlightedSummary[0][1].Text != "getting" {
+		t.Errorf("Highlights were %v", result.Highlights)
+	}
+}
+
+func TestArticleAPICreate(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/article.json", expectedURI: "/articles"}
+	http.f = func(body interface{}) {
+		request := body.(*requestArticle)
+		if request.Title != "Getting started" || request.AuthorID != 991267834 || request.ParentID != "145" || request.TranslatedContent["fr"].Title != "Premiers pas" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := ArticleAPI{httpClient: &http}
+	article, err := api.create(&Article{ID: "ignored", Title: "Getting started", AuthorID: 991267834, ParentID: "145", ParentType: "collection",
+		TranslatedContent: ArticleTranslations{"fr": {Title: "Premiers pas", AuthorID: 991267834}}})
+	if err != nil || article.ID != "6871119" {
+		t.Errorf("Created %v, error %v", article, err)
+	}
+}
+
+func TestArticleAPIUpdate(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/article.json", expectedURI: "/articles/6871119"}
+	http.f = func(body interface{}) {
+		if request := body.(*requestArticle); request.State != "published" || request.Title != "" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := ArticleAPI{httpClient: &http}
+	api.update(&Article{ID: "6871119", State: "published"})
+}
+
+func TestArticleAPIUpdateTranslation(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/article.json", expectedURI: "/articles/6871119"}
+	http.f = func(body interface{}) {
+		b, _ := json.Marshal(body)
+		if string(b) != `{"translated_content":{"fr":{"body":"Bonjour"}}}` {
+			t.Errorf("Request was %s", b)
+		}
+	}
+	api := ArticleAPI{httpClient: &http}
+	api.update(&Article{ID: "6871119", TranslatedContent: ArticleTranslations{
+		"fr": {Body: "Bonjour", URL: "https://intercom.help/fr/articles/6871119", CreatedAt: 1663597223, UpdatedAt: 1663597260},
+	}})
+}
+
+func TestArticleAPIDelete(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/article_deleted.json", expectedURI: "/articles/6871119"}
+	api := ArticleAPI{httpClient: &http}
+	deleted, err := api.delete("6871119")
+	if err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	if deleted != (DeletedObject{ID: "6871119", Object: "article", Deleted: true}) {
+		t.Errorf("Deleted was %+v", deleted)
+	}
+}
+
+type TestArticleHTTPClient struct {
+	TestHTTPClient
+	t               *testing.T
+	f               func(body interface{})
+	fixtureFilename string
+	expectedURI     string
+	lastParams      interface{}
+}
+
+func (t *TestArticleHTTPClient) Get(uri string, params interface{}) ([]byte, error) {
+	if uri != t.expectedURI {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	t.lastParams = params
+	return ioutil.ReadFile(t.fixtureFilename)
+}
+
+func (t *TestArticleHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
+	return t.send(uri, body)
+}
+
+func (t *TestArticleHTTPClient) Put(uri string, body interface{}) ([]byte, error) {
+	return t.send(uri, body)
+}
+
+func (t *TestArticleHTTPClient) Delete(uri string, params interface{}) ([]byte, error) {
+	return t.send(uri, params)
+}
+
+func (t *TestArticleHTTPClient) send(uri string, body interface{}) ([]byte, error) {
+	if uri != t.expectedURI {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	if t.f != nil {
+		t.f(body)
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
+}
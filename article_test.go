@@ -0,0 +1,110 @@
+package intercom
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestArticleCreate(t *testing.T) {
+	articles := ArticleService{Repository: &TestArticleAPI{t: t}}
+	invalid := []struct {
+		article  Article
+		expected string
+	}{
+		{Article{AuthorID: 1}, "Missing Article Title"},
+		{Article{Title: "Billing"}, "Missing Article Author"},
+		{Article{Title: "Billing", AuthorID: 1, State: "live"}, `Invalid Article State "live"`},
+		{Article{Title: "Billing", AuthorID: 1, TranslatedContent: ArticleTranslations{"fr": {State: "live"}}}, `Invalid Article State "live" for locale fr`},
+		{Article{Title: "Billing", AuthorID: 1, ParentID: "onboarding"}, `Invalid Article Parent ID "onboarding"`},
+	}
+	for _, c := range invalid {
+		if _, err := articles.Create(&c.article); err == nil || err.Error() != c.expected {
+			t.Errorf("Expected %s, got %v", c.expected, err)
+		}
+	}
+	article, err := articles.Create(&Article{Title: "Billing", AuthorID: 1})
+	if err != nil || article.ID != "1" {
+		t.Errorf("Created %v, error %v", article, err)
+	}
+}
+
+func TestArticlePublishing(t *testing.T) {
+	repo := &TestArticleAPI{t: t}
+	articles := ArticleService{Repository: repo}
+	articles.Publish("1")
+	if repo.updated.ID != "1" || repo.updated.State != "published" {
+		t.Errorf("Published %v", repo.updated)
+	}
+	articles.Unpublish("1")
+	if repo.updated.State != "draft" {
+		t.Errorf("Unpublished %v", repo.updated)
+	}
+	if _, err := articles.Update(&Article{State: "draft"}); err == nil {
+		t.Errorf("Expected an update without ID to fail")
+	}
+}
+
+func TestArticleUpdateTranslation(t *testing.T) {
+	repo := &TestArticleAPI{t: t}
+	articles := ArticleService{Repository: repo}
+	articles.UpdateTranslation("1", "fr", ArticleContent{Title: "Facturation", AuthorID: 1, State: "published"})
+	if fr := repo.updated.TranslatedContent["fr"]; repo.updated.ID != "1" || fr.Title != "Facturation" || repo.updated.Title != "" {
+		t.Errorf("Updated %v", repo.updated)
+	}
+	if _, err := articles.UpdateTranslation("1", "", ArticleContent{}); err == nil {
+		t.Errorf("Expected a translation without locale to fail")
+	}
+}
+
+func TestArticleSearchState(t *testing.T) {
+	articles := ArticleService{Repository: &TestArticleAPI{t: t}}
+	if _, err := articles.Search(ArticleSearchParams{Phrase: "billing", State: "archived"}); err == nil {
+		t.Errorf("Expected an invalid state to fail")
+	}
+}
+
+func TestArticleIterateAll(t *testing.T) {
+	articles := ArticleService{Repository: &TestArticleAPI{t: t}}
+	it := articles.IterateAll(1)
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.Article().ID)
+	}
+	if it.Err() != nil || len(ids) != 2 || ids[1] != "2" {
+		t.Errorf("Iterated %v, error %v", ids, it.Err())
+	}
+}
+
+type TestArticleAPI struct {
+	t       *testing.T
+	updated *Article
+}
+
+func (t *TestArticleAPI) list(params PageParams) (ArticleList, error) {
+	list := ArticleList{Pages: PageParams{Page: params.Page, PerPage: params.PerPage, TotalPages: 2}}
+	if params.Page <= 2 {
+		list.Articles = []Article{{ID: strconv.FormatInt(params.Page, 10)}}
+	}
+	return list, nil
+}
+
+func (t *TestArticleAPI) find(id string) (Article, error) {
+	return Article{ID: id}, nil
+}
+
+func (t *TestArticleAPI) search(params ArticleSearchParams) (ArticleSearchResult, error) {
+	return ArticleSearchResult{}, nil
+}
+
+func (t *TestArticleAPI) create(article *Article) (Article, error) {
+	return Article{ID: "1", Title: article.Title}, nil
+}
+
+func (t *TestArticleAPI) update(article *Article) (Article, error) {
+	t.updated = article
+	return *article, nil
+}
+
+func (t *TestArticleAPI) delete(id string) (DeletedObject, error) {
+	return DeletedObject{ID: id, Object: "article", Deleted: true}, nil
+}
@@ -0,0 +1,111 @@
+package canvas
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+
+	intercom "github.com/stefanoschrs/go-intercom"
+)
+
+// SignatureHeader is the header holding the signature of Canvas Kit requests.
+const SignatureHeader = "X-Body-Signature"
+
+// maxRequestSize bounds the body of requests read.
+const maxRequestSize = 1 << 20
+
+// App routes Canvas Kit requests to its handlers by the last element of their path:
+// /initialize, /submit, /configure and /submit_sheet. Handlers left nil answer 404.
+//
+//	http.Handle("/intercom/", canvas.App{
+//		ClientSecret: os.Getenv("INTERCOM_CLIENT_SECRET"),
+//		Initialize: func(ctx context.Context, r canvas.InitializeRequest) (canvas.Response, error) {
+//			return canvas.NewResponse(canvas.Text{Text: "Hello " + r.Contact.Name}), nil
+//		},
+//	})
+type App struct {
+	ClientSecret string // Verifies the signature of requests; unsigned requests are rejected.
+	Initialize   func(ctx context.Context, request InitializeRequest) (Response, error)
+	Submit       func(ctx context.Context, request SubmitRequest) (Response, error)
+	Configure    func(ctx context.Context, request ConfigureRequest) (Response, error)
+	SubmitSheet  func(ctx context.Context, request SubmitSheetRequest) (Response, error)
+}
+
+// ServeHTTP verifies, decodes and routes a request, and encodes the Response of its handler.
+func (a App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	route := a.route(path.Base(r.URL.Path))
+	if route == nil {
+		http.NotFound(w, r)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if !intercom.VerifyNotificationSignature(a.ClientSecret, body, r.Header.Get(SignatureHeader)) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	response, err := route(r.Context(), body)
+	if _, invalid := err.(decodeError); invalid {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (a App) route(name string) func(ctx context.Context, body []byte) (Response, error) {
+	switch {
+	case name == "initialize" && a.Initialize != nil:
+		return func(ctx context.Context, body []byte) (Response, error) {
+			request := InitializeRequest{}
+			if err := json.Unmarshal(body, &request); err != nil {
+				return Response{}, decodeError{err}
+			}
+			return a.Initialize(ctx, request)
+		}
+	case name == "submit" && a.Submit != nil:
+		return func(ctx context.Context, body []byte) (Response, error) {
+			request := SubmitRequest{}
+			if err := json.Unmarshal(body, &request); err != nil {
+				return Response{}, decodeError{err}
+			}
+			return a.Submit(ctx, request)
+		}
+	case name == "configure" && a.Configure != nil:
+		return func(ctx context.Context, body []byte) (Response, error) {
+			request := ConfigureRequest{}
+			if err := json.Unmarshal(body, &request); err != nil {
+				return Response{}, decodeError{err}
+			}
+			return a.Configure(ctx, request)
+		}
+	case name == "submit_sheet" && a.SubmitSheet != nil:
+		return func(ctx context.Context, body []byte) (Response, error) {
+			request := SubmitSheetRequest{}
+			if err := json.Unmarshal(body, &request); err != nil {
+				return Response{}, decodeError{err}
+			}
+			return a.SubmitSheet(ctx, request)
+		}
+	}
+	return nil
+}
+
+// decodeError distinguishes malformed requests from errors of handlers.
+type decodeError struct {
+	error
+}
@@ -0,0 +1,111 @@
+package canvas
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAppSubmit(t *testing.T) {
+	app := App{ClientSecret: "secret", Submit: func(ctx context.Context, r SubmitRequest) (Response, error) {
+		if r.WorkspaceID != "ecahpwf5" || r.Admin.ID.String() != "25" || r.Contact.Name != "Jamie" || r.Conversation.Id != "147" {
+			t.Errorf("Request was %+v", r.Request)
+		}
+		if r.Context.Location != "conversation" || r.Context.ConversationID != 147 {
+			t.Errorf("Context was %+v", r.Context)
+		}
+		if r.ComponentID != "submit" || r.InputValues["email"] != "jamie@example.com" || r.CurrentCanvas.StoredData["order_id"] != "1234" {
+			t.Errorf("Submit was %+v", r)
+		}
+		return NewResponse(Text{Text: "Thanks"}).Completed(), nil
+	}}
+	body, _ := ioutil.ReadFile("../fixtures/canvas_submit.json")
+	recorder := serveCanvas(app, "/intercom/submit", string(body), sign("secret", string(body)))
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("Status was %d", recorder.Code)
+	}
+	expected := `{"canvas":{"content":{"components":[{"type":"text","text":"Thanks"}]}},"event":{"type":"completed"}}` + "\n"
+	if recorder.Body.String() != expected {
+		t.Errorf("Response was %s", recorder.Body)
+	}
+}
+
+func TestAppRoutes(t *testing.T) {
+	called := ""
+	app := App{
+		ClientSecret: "secret",
+		Initialize: func(ctx context.Context, r InitializeRequest) (Response, error) {
+			called = "initialize " + r.CardCreationOptions["plan"].(string)
+			return NewResponse(), nil
+		},
+		Configure: func(ctx context.Context, r ConfigureRequest) (Response, error) {
+			called = "configure"
+			return ResultsResponse(map[string]interface{}{"plan": "pro"}), nil
+		},
+		SubmitSheet: func(ctx context.Context, r SubmitSheetRequest) (Response, error) {
+			called = "submit_sheet " + r.SheetValues["rating"].(string)
+			return NewResponse(), nil
+		},
+	}
+	cases := []struct{ path, body, expected string }{
+		{"/initialize", `{"card_creation_options": {"plan": "pro"}}`, "initialize pro"},
+		{"/configure", `{"workspace_id": "ecahpwf5"}`, "configure"},
+		{"/apps/submit_sheet", `{"sheet_values": {"rating": "5"}}`, "submit_sheet 5"},
+	}
+	for _, c := range cases {
+		called = ""
+		if recorder := serveCanvas(app, c.path, c.body, sign("secret", c.body)); recorder.Code != http.StatusOK || called != c.expected {
+			t.Errorf("%s: status %d, called %q", c.path, recorder.Code, called)
+		}
+	}
+	if recorder := serveCanvas(app, "/submit", "{}", sign("secret", "{}")); recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected a route without handler not to be found, status was %d", recorder.Code)
+	}
+}
+
+func TestAppErrors(t *testing.T) {
+	app := App{ClientSecret: "secret", Initialize: func(ctx context.Context, r InitializeRequest) (Response, error) {
+		return Response{}, errors.New("failed")
+	}}
+	cases := []struct {
+		method, body, signature string
+		status                  int
+	}{
+		{http.MethodGet, "{}", sign("secret", "{}"), http.StatusMethodNotAllowed},
+		{http.MethodPost, "{}", "", http.StatusUnauthorized},
+		{http.MethodPost, "{}", sign("other", "{}"), http.StatusUnauthorized},
+		{http.MethodPost, "{", sign("secret", "{"), http.StatusBadRequest},
+		{http.MethodPost, `{"context": []}`, sign("secret", `{"context": []}`), http.StatusBadRequest},
+		{http.MethodPost, "{}", sign("secret", "{}"), http.StatusInternalServerError},
+	}
+	for _, c := range cases {
+		request := httptest.NewRequest(c.method, "/initialize", strings.NewReader(c.body))
+		request.Header.Set(SignatureHeader, c.signature)
+		recorder := httptest.NewRecorder()
+		app.ServeHTTP(recorder, request)
+		if recorder.Code != c.status {
+			t.Errorf("%s %s signed %q: status %d, expected %d", c.method, c.body, c.signature, recorder.Code, c.status)
+		}
+	}
+}
+
+func serveCanvas(app App, path, body, signature string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	request.Header.Set(SignatureHeader, signature)
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
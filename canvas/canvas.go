@@ -0,0 +1,119 @@
+package canvas
+
+import (
+	"encoding/json"
+
+	intercom "github.com/stefanoschrs/go-intercom"
+)
+
+// A Canvas is what an app shows: its components, or a URL serving them,
+// and data stored with it which Intercom sends back on submit.
+type Canvas struct {
+	Content    *Content               `json:"content,omitempty"`
+	ContentURL string                 `json:"content_url,omitempty"`
+	StoredData map[string]interface{} `json:"stored_data,omitempty"`
+}
+
+// Content holds the components of a Canvas.
+type Content struct {
+	Components []Component `json:"components"`
+}
+
+// A Response answers a request from Intercom with a Canvas, and for submit requests, an Event.
+// Configure requests are answered with Results once configuration is done, to be sent to initialize.
+type Response struct {
+	Canvas  *Canvas                `json:"canvas,omitempty"`
+	Event   *Event                 `json:"event,omitempty"`
+	Results map[string]interface{} `json:"results,omitempty"`
+}
+
+// An Event reports the outcome of a submit, such as "completed".
+type Event struct {
+	Type string `json:"type"`
+}
+
+// NewResponse creates a Response showing components.
+func NewResponse(components ...Component) Response {
+	return Response{Canvas: &Canvas{Content: &Content{Components: components}}}
+}
+
+// ResultsResponse creates the Response finishing the configuration of an app.
+func ResultsResponse(results map[string]interface{}) Response {
+	return Response{Results: results}
+}
+
+// WithStoredData stores data with the Canvas of the Response.
+func (r Response) WithStoredData(data map[string]interface{}) Response {
+	if r.Canvas == nil {
+		r.Canvas = &Canvas{}
+	}
+	canvas := *r.Canvas
+	canvas.StoredData = data
+	r.Canvas = &canvas
+	return r
+}
+
+// Completed marks the submit the Response answers as completed, for reporting.
+func (r Response) Completed() Response {
+	r.Event = &Event{Type: "completed"}
+	return r
+}
+
+// A Request holds what Intercom sends with every request: the workspace, where the app is shown and to whom.
+// Contact, Customer and User are the same person for apps in the Messenger; Admin is set in the Inbox.
+type Request struct {
+	WorkspaceID     string                 `json:"workspace_id"`
+	WorkspaceRegion string                 `json:"workspace_region,omitempty"`
+	Admin           *intercom.Admin        `json:"admin,omitempty"`
+	Context         Context                `json:"context"`
+	Contact         *intercom.Contact      `json:"contact,omitempty"`
+	Customer        *intercom.Contact      `json:"customer,omitempty"`
+	User            *intercom.Contact      `json:"user,omitempty"`
+	Conversation    *intercom.Conversation `json:"conversation,omitempty"`
+}
+
+// Context is where the app is shown.
+type Context struct {
+	Location               string `json:"location"` // "conversation", "home", "message" or "operator"
+	Locale                 string `json:"locale,omitempty"`
+	ConversationID         int64  `json:"conversation_id,omitempty"`
+	MessengerActionContext string `json:"messenger_action_context,omitempty"`
+}
+
+// CurrentCanvas is the Canvas shown when a component was submitted.
+type CurrentCanvas struct {
+	Content    json.RawMessage        `json:"content,omitempty"`
+	ContentURL string                 `json:"content_url,omitempty"`
+	StoredData map[string]interface{} `json:"stored_data,omitempty"`
+}
+
+// InitializeRequest asks for the first Canvas of an app.
+// CardCreationOptions are the Results of configuration, for apps added to messages or the Messenger home.
+type InitializeRequest struct {
+	Request
+	CardCreationOptions map[string]interface{} `json:"card_creation_options,omitempty"`
+}
+
+// SubmitRequest is sent when a component with a submit Action is clicked.
+type SubmitRequest struct {
+	Request
+	ComponentID   string                 `json:"component_id"`
+	InputValues   map[string]interface{} `json:"input_values"`
+	CurrentCanvas CurrentCanvas          `json:"current_canvas"`
+}
+
+// ConfigureRequest asks teammates to configure an app before it is added to a message or the Messenger home.
+// ComponentID is empty on the first request.
+type ConfigureRequest struct {
+	Request
+	ComponentID   string                 `json:"component_id,omitempty"`
+	InputValues   map[string]interface{} `json:"input_values,omitempty"`
+	CurrentCanvas *CurrentCanvas         `json:"current_canvas,omitempty"`
+}
+
+// SubmitSheetRequest is sent when a sheet is closed, with the values it submitted.
+type SubmitSheetRequest struct {
+	Request
+	SheetValues   map[string]interface{} `json:"sheet_values"`
+	CurrentCanvas CurrentCanvas          `json:"current_canvas"`
+}
@@ -0,0 +1,221 @@
+// Package canvas builds Intercom apps with Canvas Kit: typed components for the canvases apps show,
+// the requests Intercom sends apps, and an http.Handler routing them.
+package canvas
+
+import "encoding/json"
+
+// A Component is a Canvas Kit component.
+type Component interface {
+	json.Marshaler
+	component()
+}
+
+// An Action is what happens when a component is clicked.
+type Action struct {
+	Type string `json:"type"`
+	URL  string `json:"url,omitempty"`
+}
+
+// SubmitAction submits the canvas to the submit URL of the app.
+func SubmitAction() *Action {
+	return &Action{Type: "submit"}
+}
+
+// URLAction opens a URL in a new tab.
+func URLAction(url string) *Action {
+	return &Action{Type: "url", URL: url}
+}
+
+// SheetAction opens a sheet, posting to the URL given.
+func SheetAction(url string) *Action {
+	return &Action{Type: "sheet", URL: url}
+}
+
+// Text shows text, styled "header", "paragraph", "muted" or "error". Markdown links and emphasis are supported.
+type Text struct {
+	ID           string `json:"id,omitempty"`
+	Text         string `json:"text"`
+	Align        string `json:"align,omitempty"`
+	Style        string `json:"style,omitempty"`
+	BottomMargin string `json:"bottom_margin,omitempty"`
+}
+
+// Button triggers an Action, styled "primary", "secondary" or "link".
+type Button struct {
+	ID       string  `json:"id"`
+	Label    string  `json:"label"`
+	Action   *Action `json:"action"`
+	Style    string  `json:"style,omitempty"`
+	Disabled bool    `json:"disabled,omitempty"`
+}
+
+// Input is a single line text input, submitted under its ID.
+type Input struct {
+	ID          string  `json:"id"`
+	Label       string  `json:"label,omitempty"`
+	Placeholder string  `json:"placeholder,omitempty"`
+	Value       string  `json:"value,omitempty"`
+	SaveState   string  `json:"save_state,omitempty"`
+	Action      *Action `json:"action,omitempty"`
+	Disabled    bool    `json:"disabled,omitempty"`
+}
+
+// TextArea is a multiple line text input, submitted under its ID.
+type TextArea struct {
+	ID          string `json:"id"`
+	Label       string `json:"label,omitempty"`
+	Placeholder string `json:"placeholder,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Error       bool   `json:"error,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// List shows ListItems.
+type List struct {
+	Items    []ListItem `json:"items"`
+	Disabled bool       `json:"disabled,omitempty"`
+}
+
+// A ListItem is an item of a List.
+type ListItem struct {
+	ID           string  `json:"id"`
+	Title        string  `json:"title"`
+	Subtitle     string  `json:"subtitle,omitempty"`
+	TertiaryText string  `json:"tertiary_text,omitempty"`
+	Image        string  `json:"image,omitempty"`
+	ImageWidth   int     `json:"image_width,omitempty"`
+	ImageHeight  int     `json:"image_height,omitempty"`
+	RoundedImage bool    `json:"rounded_image,omitempty"`
+	Action       *Action `json:"action,omitempty"`
+	Disabled     bool    `json:"disabled,omitempty"`
+}
+
+// MarshalJSON encodes the ListItem with its type.
+func (i ListItem) MarshalJSON() ([]byte, error) {
+	type item ListItem
+	return marshalTyped("item", item(i))
+}
+
+// Dropdown selects one of its Options, submitted under its ID.
+type Dropdown struct {
+	ID        string   `json:"id"`
+	Label     string   `json:"label,omitempty"`
+	Options   []Option `json:"options"`
+	Value     string   `json:"value,omitempty"`
+	SaveState string   `json:"save_state,omitempty"`
+	Disabled  bool     `json:"disabled,omitempty"`
+}
+
+// An Option of a Dropdown.
+type Option struct {
+	ID       string `json:"id"`
+	Text     string `json:"text"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// MarshalJSON encodes the Option with its type.
+func (o Option) MarshalJSON() ([]byte, error) {
+	type option Option
+	return marshalTyped("option", option(o))
+}
+
+// Image shows an image.
+type Image struct {
+	ID           string  `json:"id,omitempty"`
+	URL          string  `json:"url"`
+	Width        int     `json:"width"`
+	Height       int     `json:"height"`
+	Align        string  `json:"align,omitempty"`
+	Rounded      bool    `json:"rounded,omitempty"`
+	BottomMargin string  `json:"bottom_margin,omitempty"`
+	Action       *Action `json:"action,omitempty"`
+}
+
+// Spacer adds space, sized "xs", "s", "m", "l" or "xl".
+type Spacer struct {
+	ID   string `json:"id,omitempty"`
+	Size string `json:"size,omitempty"`
+}
+
+// Divider draws a line between components.
+type Divider struct {
+	ID           string `json:"id,omitempty"`
+	BottomMargin string `json:"bottom_margin,omitempty"`
+}
+
+func (Text) component()     {}
+func (Button) component()   {}
+func (Input) component()    {}
+func (TextArea) component() {}
+func (List) component()     {}
+func (Dropdown) component() {}
+func (Image) component()    {}
+func (Spacer) component()   {}
+func (Divider) component()  {}
+
+// MarshalJSON encodes the Text with its type.
+func (c Text) MarshalJSON() ([]byte, error) {
+	type text Text
+	return marshalTyped("text", text(c))
+}
+
+// MarshalJSON encodes the Button with its type.
+func (c Button) MarshalJSON() ([]byte, error) {
+	type button Button
+	return marshalTyped("button", button(c))
+}
+
+// MarshalJSON encodes the Input with its type.
+func (c Input) MarshalJSON() ([]byte, error) {
+	type input Input
+	return marshalTyped("input", input(c))
+}
+
+// MarshalJSON encodes the TextArea with its type.
+func (c TextArea) MarshalJSON() ([]byte, error) {
+	type textArea TextArea
+	return marshalTyped("textarea", textArea(c))
+}
+
+// MarshalJSON encodes the List with its type.
+func (c List) MarshalJSON() ([]byte, error) {
+	type list List
+	return marshalTyped("list", list(c))
+}
+
+// MarshalJSON encodes the Dropdown with its type.
+func (c Dropdown) MarshalJSON() ([]byte, error) {
+	type dropdown Dropdown
+	return marshalTyped("dropdown", dropdown(c))
+}
+
+// MarshalJSON encodes the Image with its type.
+func (c Image) MarshalJSON() ([]byte, error) {
+	type image Image
+	return marshalTyped("image", image(c))
+}
+
+// MarshalJSON encodes the Spacer with its type.
+func (c Spacer) MarshalJSON() ([]byte, error) {
+	type spacer Spacer
+	return marshalTyped("spacer", spacer(c))
+}
+
+// MarshalJSON encodes the Divider with its type.
+func (c Divider) MarshalJSON() ([]byte, error) {
+	type divider Divider
+	return marshalTyped("divider", divider(c))
+}
+
+// marshalTyped encodes the fields of v after its type.
+func marshalTyped(typ string, v interface{}) ([]byte, error) {
+	fields, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	typed := []byte(`{"type":` + `"` + typ + `"`)
+	if len(fields) > 2 {
+		typed = append(typed, ',')
+	}
+	return append(typed, fields[1:]...), nil
+}
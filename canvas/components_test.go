@@ -0,0 +1,49 @@
+package canvas
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+func TestComponentsGolden(t *testing.T) {
+	response := NewResponse(
+		Text{Text: "*Your order*", Style: "header"},
+		Image{URL: "https://example.com/order.png", Width: 130, Height: 80, Align: "center"},
+		Divider{},
+		List{Items: []ListItem{
+			{ID: "item-1", Title: "Pro plan", Subtitle: "$49/month", Image: "https://example.com/pro.png", ImageWidth: 48, ImageHeight: 48, Action: SubmitAction()},
+			{ID: "item-2", Title: "Starter plan", Disabled: true},
+		}},
+		Spacer{Size: "m"},
+		Input{ID: "email", Label: "Email", Placeholder: "you@example.com", SaveState: "unsaved"},
+		TextArea{ID: "notes", Label: "Notes", Error: true},
+		Dropdown{ID: "size", Label: "Size", Value: "m", Options: []Option{{ID: "s", Text: "Small"}, {ID: "m", Text: "Medium"}}},
+		Button{ID: "submit", Label: "Submit", Style: "primary", Action: SubmitAction()},
+		Button{ID: "docs", Label: "Read the docs", Style: "link", Action: URLAction("https://example.com/docs")},
+	).WithStoredData(map[string]interface{}{"order_id": "1234"}).Completed()
+	assertGolden(t, response, "../fixtures/canvas_response.json")
+}
+
+func TestResultsResponseGolden(t *testing.T) {
+	assertGolden(t, ResultsResponse(map[string]interface{}{"plan": "pro"}), "../fixtures/canvas_results.json")
+}
+
+func TestWithStoredDataCopiesCanvas(t *testing.T) {
+	response := NewResponse(Text{Text: "Hi"})
+	stored := response.WithStoredData(map[string]interface{}{"step": 2})
+	if response.Canvas.StoredData != nil || stored.Canvas.StoredData["step"] != 2 {
+		t.Errorf("Stored data was %v, original %v", stored.Canvas.StoredData, response.Canvas.StoredData)
+	}
+}
+
+func assertGolden(t *testing.T, v interface{}, golden string) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := ioutil.ReadFile(golden)
+	if string(out)+"\n" != string(expected) {
+		t.Errorf("JSON did not match %s, was:\n%s", golden, out)
+	}
+}
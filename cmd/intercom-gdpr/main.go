@@ -0,0 +1,85 @@
+// Command intercom-gdpr handles data subject requests: it exports everything associated with the contacts
+// matching an email or external ID to a JSON archive, and with -delete then permanently deletes them.
+//
+//	INTERCOM_ACCESS_TOKEN=... intercom-gdpr -email jamie@example.io -out jamie.json -delete
+//
+// Every step is appended to the audit trail, intercom-gdpr-audit.log by default.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	intercom "github.com/stefanoschrs/go-intercom"
+)
+
+var errUsage = errors.New("usage")
+
+func main() {
+	if err := run(); err != nil {
+		if err == errUsage {
+			flag.Usage()
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run handles the request, returning rather than exiting so the audit trail is always closed.
+func run() error {
+	query := intercom.DataSubjectQuery{}
+	flag.StringVar(&query.Email, "email", "", "email of the contacts")
+	flag.StringVar(&query.ExternalID, "external-id", "", "external ID of the contacts")
+	out := flag.String("out", "", "file to write the archive to (required)")
+	auditPath := flag.String("audit", "intercom-gdpr-audit.log", "file to append the audit trail to")
+	remove := flag.Bool("delete", false, "permanently delete the contacts once archived")
+	baseURI := flag.String("base-uri", "", "base URI of the Intercom API")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s -email <email> | -external-id <id> -out <file> [flags]\n\nThe access token is read from INTERCOM_ACCESS_TOKEN.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	token := os.Getenv("INTERCOM_ACCESS_TOKEN")
+	if (query.Email == "" && query.ExternalID == "") || *out == "" || token == "" || flag.NArg() > 0 {
+		return errUsage
+	}
+
+	audit, err := os.OpenFile(*auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer audit.Close()
+	archive, err := os.OpenFile(*out, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	ic := intercom.NewClient("", token)
+	if *baseURI != "" {
+		ic.Option(intercom.BaseURI(*baseURI))
+	}
+	processor := intercom.DataSubjectProcessor{Client: ic, Audit: audit}
+	exported, err := processor.Export(query)
+	if err != nil {
+		return err
+	}
+	// Archive syncs the file and records the step, as Process does, so it is complete on disk before deleting anything.
+	if err := processor.Archive(exported, archive); err != nil {
+		return err
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("Archived %d contacts to %s\n", len(exported.Contacts), *out)
+	if !*remove {
+		return nil
+	}
+	requests, err := processor.Delete(exported)
+	fmt.Printf("Requested the permanent deletion of %d contacts\n", len(requests))
+	return err
+}
@@ -0,0 +1,68 @@
+// Command intercom-help-sync syncs a directory of Markdown documents to Intercom Help Center articles.
+//
+//	INTERCOM_ACCESS_TOKEN=... intercom-help-sync -author 991267834 -dry-run ./docs
+//
+// The articles synced are recorded in a manifest, by default .intercom-sync.json in the directory,
+// which should be committed with the documents.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	intercom "github.com/stefanoschrs/go-intercom"
+	"github.com/stefanoschrs/go-intercom/helpsync"
+)
+
+func main() {
+	author := flag.Int64("author", 0, "ID of the admin authoring the articles (required)")
+	locale := flag.String("locale", "en", "default locale of the documents")
+	state := flag.String("state", "draft", "state of documents without one: published or draft")
+	manifest := flag.String("manifest", "", "manifest of the articles synced (default <dir>/.intercom-sync.json)")
+	dryRun := flag.Bool("dry-run", false, "report the changes without making them")
+	remove := flag.Bool("delete", false, "delete the articles of removed documents, rather than unpublishing them")
+	baseURI := flag.String("base-uri", "", "base URI of the Intercom API")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <dir>\n\nThe access token is read from INTERCOM_ACCESS_TOKEN.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	token := os.Getenv("INTERCOM_ACCESS_TOKEN")
+	if flag.NArg() != 1 || *author == 0 || token == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := flag.Arg(0)
+	if *manifest == "" {
+		*manifest = filepath.Join(dir, ".intercom-sync.json")
+	}
+
+	docs, err := helpsync.LoadDirectory(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	ic := intercom.NewClient("", token)
+	if *baseURI != "" {
+		ic.Option(intercom.BaseURI(*baseURI))
+	}
+	syncer := helpsync.Syncer{
+		Articles:      &ic.Articles,
+		HelpCenter:    &ic.HelpCenter,
+		AuthorID:      *author,
+		DefaultLocale: *locale,
+		DefaultState:  *state,
+		ManifestPath:  *manifest,
+		DryRun:        *dryRun,
+		Delete:        *remove,
+	}
+	report, err := syncer.Sync(docs)
+	fmt.Print(report)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -1,6 +1,9 @@
 package intercom
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // CompanyService handles interactions with the API through a CompanyRepository.
 type CompanyService struct {
@@ -18,21 +21,32 @@ type CompanyList struct {
 // Not all of the fields are writeable to the API, non-writeable fields are
 // stripped out from the request. Please see the API documentation for details.
 type Company struct {
-	ID               string                 `json:"id,omitempty"`
-	CompanyID        string                 `json:"company_id,omitempty"`
-	Name             string                 `json:"name,omitempty"`
-	RemoteCreatedAt  int64                  `json:"remote_created_at,omitempty"`
-	LastRequestAt    int64                  `json:"last_request_at,omitempty"`
-	CreatedAt        int64                  `json:"created_at,omitempty"`
-	UpdatedAt        int64                  `json:"updated_at,omitempty"`
-	SessionCount     int64                  `json:"session_count,omitempty"`
-	MonthlySpend     int64                  `json:"monthly_spend,omitempty"`
-	UserCount        int64                  `json:"user_count,omitempty"`
-	Tags             *TagList               `json:"tags,omitempty"`
-	Segments         *SegmentList           `json:"segments,omitempty"`
-	Plan             *Plan                  `json:"plan,omitempty"`
-	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
-	Remove           *bool                  `json:"-"`
+	ID               string           `json:"id,omitempty"`
+	CompanyID        string           `json:"company_id,omitempty"`
+	Name             string           `json:"name,omitempty"`
+	RemoteCreatedAt  int64            `json:"remote_created_at,omitempty"`
+	LastRequestAt    int64            `json:"last_request_at,omitempty"`
+	CreatedAt        int64            `json:"created_at,omitempty"`
+	UpdatedAt        int64            `json:"updated_at,omitempty"`
+	SessionCount     int64            `json:"session_count,omitempty"`
+	MonthlySpend     int64            `json:"monthly_spend,omitempty"`
+	UserCount        int64            `json:"user_count,omitempty"`
+	Industry         string           `json:"industry,omitempty"`
+	Size             int64            `json:"size,omitempty"`
+	Website          string           `json:"website,omitempty"`
+	Tags             *TagList         `json:"tags,omitempty"`
+	Segments         *SegmentList     `json:"segments,omitempty"`
+	Plan             *Plan            `json:"plan,omitempty"`
+	CustomAttributes CustomAttributes `json:"custom_attributes,omitempty"`
+	Remove           *bool            `json:"-"`
+}
+
+// CompanyContactList holds a page of the Contacts of a Company
+type CompanyContactList struct {
+	Type       string     `json:"type,omitempty"`
+	Contacts   []Contact  `json:"data"`
+	Pages      PageParams `json:"pages"`
+	TotalCount int64      `json:"total_count,omitempty"`
 }
 
 // CompanyIdentifiers to identify a Company using the API
@@ -48,6 +62,16 @@ type Plan struct {
 	Name string `json:"name,omitempty"`
 }
 
+// CompanySearchParams filters the Companies found by Search.
+// Name and CompanyID each match at most one Company; SegmentID and TagID narrow the Companies listed or found.
+type CompanySearchParams struct {
+	Name      string
+	CompanyID string
+	SegmentID string
+	TagID     string
+	PageParams
+}
+
 type companyListParams struct {
 	PageParams
 	SegmentID string `url:"segment_id,omitempty"`
@@ -96,6 +120,57 @@ func (c *CompanyService) ListByTag(tagID string, params PageParams) (CompanyList
 	return c.Repository.list(companyListParams{PageParams: params, TagID: tagID})
 }
 
+// Search finds the Companies matching CompanySearchParams.
+// The API has no search endpoint for Companies, so this combines its lookups by Name or CompanyID
+// with its Segment and Tag filters. A Name or CompanyID not found gives an empty CompanyList.
+func (c *CompanyService) Search(params CompanySearchParams) (CompanyList, error) {
+	if params.Name == "" && params.CompanyID == "" {
+		if params.SegmentID == "" && params.TagID == "" {
+			return CompanyList{}, errors.New("Missing Company Search Criteria")
+		}
+		return c.Repository.list(companyListParams{PageParams: params.PageParams, SegmentID: params.SegmentID, TagID: params.TagID})
+	}
+	found := CompanyList{Pages: PageParams{Page: 1, TotalPages: 1}, Companies: []Company{}}
+	company, err := c.findWithIdentifiers(CompanyIdentifiers{Name: params.Name, CompanyID: params.CompanyID})
+	var herr IntercomError
+	if errors.As(err, &herr) && herr.GetStatusCode() == 404 {
+		return found, nil
+	}
+	if err != nil {
+		return found, err
+	}
+	if params.SegmentID != "" && !companyInSegment(company, params.SegmentID) {
+		return found, nil
+	}
+	if params.TagID != "" && !companyTagged(company, params.TagID) {
+		return found, nil
+	}
+	found.Companies = append(found.Companies, company)
+	return found, nil
+}
+
+func companyInSegment(company Company, segmentID string) bool {
+	if company.Segments != nil {
+		for _, segment := range company.Segments.Segments {
+			if segment.ID == segmentID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func companyTagged(company Company, tagID string) bool {
+	if company.Tags != nil {
+		for _, tag := range company.Tags.Tags {
+			if tag.ID == tagID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // List Company Users by ID
 func (c *CompanyService) ListUsersByID(id string, params PageParams) (UserList, error) {
 	return c.listUsersWithIdentifiers(id, companyUserListParams{PageParams: params})
@@ -110,6 +185,55 @@ func (c *CompanyService) listUsersWithIdentifiers(id string, params companyUserL
 	return c.Repository.listUsers(id, params)
 }
 
+// ListContacts lists the Contacts of a Company, using its Intercom ID
+func (c *CompanyService) ListContacts(id string, params PageParams) (CompanyContactList, error) {
+	if id == "" {
+		return CompanyContactList{}, errors.New("Missing Company Identifier")
+	}
+	return c.Repository.listContacts(id, params)
+}
+
+// ListSegments lists the Segments a Company belongs to, using its Intercom ID
+func (c *CompanyService) ListSegments(id string) (SegmentList, error) {
+	if id == "" {
+		return SegmentList{}, errors.New("Missing Company Identifier")
+	}
+	return c.Repository.listSegments(id)
+}
+
+// AttachContact attaches a Contact to a Company, using their Intercom IDs
+func (c *CompanyService) AttachContact(id, contactID string) (Company, error) {
+	if id == "" || contactID == "" {
+		return Company{}, errors.New("Missing Company or Contact Identifier")
+	}
+	return c.Repository.attachContact(id, contactID)
+}
+
+// DetachContact detaches a Contact from a Company, using their Intercom IDs
+func (c *CompanyService) DetachContact(id, contactID string) (Company, error) {
+	if id == "" || contactID == "" {
+		return Company{}, errors.New("Missing Company or Contact Identifier")
+	}
+	return c.Repository.detachContact(id, contactID)
+}
+
+// Tag a Company, using its Intercom ID, with the named Tag
+func (c *CompanyService) Tag(id, tagName string) (Tag, error) {
+	return c.tag(id, tagName, nil)
+}
+
+// Untag a Company, using its Intercom ID, removing the named Tag
+func (c *CompanyService) Untag(id, tagName string) (Tag, error) {
+	return c.tag(id, tagName, Bool(true))
+}
+
+func (c *CompanyService) tag(id, tagName string, untag *bool) (Tag, error) {
+	if id == "" {
+		return Tag{}, errors.New("Missing Company Identifier")
+	}
+	return c.Repository.tag(&TaggingList{Name: tagName, Companies: []Tagging{{ID: id, Untag: untag}}})
+}
+
 // List all Companies for App via Scroll API
 func (c *CompanyService) Scroll(scrollParam string) (CompanyList, error) {
 	return c.Repository.scroll(scrollParam)
@@ -120,6 +244,14 @@ func (c *CompanyService) Save(user *Company) (Company, error) {
 	return c.Repository.save(user)
 }
 
+// Delete a Company, using its Intercom ID
+func (c *CompanyService) Delete(id string) (DeletedObject, error) {
+	if id == "" {
+		return DeletedObject{}, errors.New("Missing Company Identifier")
+	}
+	return c.Repository.delete(id)
+}
+
 func (c Company) String() string {
 	return fmt.Sprintf("[intercom] company { id: %s name: %s, company_id: %s }", c.ID, c.Name, c.CompanyID)
 }
@@ -15,6 +15,12 @@ type CompanyRepository interface {
 	listUsers(string, companyUserListParams) (UserList, error)
 	scroll(scrollParam string) (CompanyList, error)
 	save(*Company) (Company, error)
+	delete(id string) (DeletedObject, error)
+	listContacts(id string, params PageParams) (CompanyContactList, error)
+	listSegments(id string) (SegmentList, error)
+	attachContact(id, contactID string) (Company, error)
+	detachContact(id, contactID string) (Company, error)
+	tag(taggingList *TaggingList) (Tag, error)
 }
 
 // CompanyAPI implements CompanyRepository
@@ -23,13 +29,16 @@ type CompanyAPI struct {
 }
 
 type requestCompany struct {
-	ID               string                 `json:"id,omitempty"`
-	CompanyID        string                 `json:"company_id,omitempty"`
-	Name             string                 `json:"name,omitempty"`
-	RemoteCreatedAt  int64                  `json:"remote_created_at,omitempty"`
-	MonthlySpend     int64                  `json:"monthly_spend,omitempty"`
-	Plan             string                 `json:"plan,omitempty"`
-	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
+	ID               string           `json:"id,omitempty"`
+	CompanyID        string           `json:"company_id,omitempty"`
+	Name             string           `json:"name,omitempty"`
+	RemoteCreatedAt  int64            `json:"remote_created_at,omitempty"`
+	MonthlySpend     int64            `json:"monthly_spend,omitempty"`
+	Plan             string           `json:"plan,omitempty"`
+	Industry         string           `json:"industry,omitempty"`
+	Size             int64            `json:"size,omitempty"`
+	Website          string           `json:"website,omitempty"`
+	CustomAttributes CustomAttributes `json:"custom_attributes,omitempty"`
 }
 
 func (api CompanyAPI) find(params CompanyIdentifiers) (Company, error) {
@@ -101,6 +110,9 @@ func (api CompanyAPI) save(company *Company) (Company, error) {
 		RemoteCreatedAt:  company.RemoteCreatedAt,
 		MonthlySpend:     company.MonthlySpend,
 		Plan:             api.getPlanName(company),
+		Industry:         company.Industry,
+		Size:             company.Size,
+		Website:          company.Website,
 		CustomAttributes: company.CustomAttributes,
 	}
 
@@ -113,6 +125,62 @@ func (api CompanyAPI) save(company *Company) (Company, error) {
 	return savedCompany, err
 }
 
+func (api CompanyAPI) delete(id string) (DeletedObject, error) {
+	return unmarshalToDeletedObject(api.httpClient.Delete(fmt.Sprintf("/companies/%s", id), nil))
+}
+
+func (api CompanyAPI) listContacts(id string, params PageParams) (CompanyContactList, error) {
+	contactList := CompanyContactList{}
+	data, err := api.httpClient.Get(fmt.Sprintf("/companies/%s/contacts", id), params)
+	if err != nil {
+		return contactList, err
+	}
+	err = json.Unmarshal(data, &contactList)
+	return contactList, err
+}
+
+func (api CompanyAPI) listSegments(id string) (SegmentList, error) {
+	segmentList := struct {
+		Segments []Segment `json:"data"`
+	}{}
+	data, err := api.httpClient.Get(fmt.Sprintf("/companies/%s/segments", id), nil)
+	if err != nil {
+		return SegmentList{}, err
+	}
+	err = json.Unmarshal(data, &segmentList)
+	return SegmentList{Segments: segmentList.Segments}, err
+}
+
+func (api CompanyAPI) attachContact(id, contactID string) (Company, error) {
+	body := struct {
+		ID string `json:"id"`
+	}{ID: id}
+	return unmarshalToCompany(api.httpClient.Post(fmt.Sprintf("/contacts/%s/companies", contactID), &body))
+}
+
+func (api CompanyAPI) detachContact(id, contactID string) (Company, error) {
+	return unmarshalToCompany(api.httpClient.Delete(fmt.Sprintf("/contacts/%s/companies/%s", contactID, id), nil))
+}
+
+func (api CompanyAPI) tag(taggingList *TaggingList) (Tag, error) {
+	savedTag := Tag{}
+	data, err := api.httpClient.Post("/tags", taggingList)
+	if err != nil {
+		return savedTag, err
+	}
+	err = json.Unmarshal(data, &savedTag)
+	return savedTag, err
+}
+
+func unmarshalToCompany(data []byte, err error) (Company, error) {
+	company := Company{}
+	if err != nil {
+		return company, err
+	}
+	err = json.Unmarshal(data, &company)
+	return company, err
+}
+
 func (api CompanyAPI) getPlanName(company *Company) string {
 	if company.Plan == nil {
 		return ""
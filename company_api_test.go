@@ -21,6 +21,9 @@ func TestCompanyAPIFind(t *testing.T) {
 	if company.RemoteCreatedAt != 1413218536 {
 		t.Errorf("RemoteCreatedAt was %d, expected %d", company.RemoteCreatedAt, 1413218536)
 	}
+	if company.Industry != "Software" || company.Size != 120 || company.Website != "https://www.example.com" {
+		t.Errorf("Industry, size and website were %s, %d, %s", company.Industry, company.Size, company.Website)
+	}
 	if company.CustomAttributes["big_company"] != true {
 		t.Errorf("CustomAttributes was %v, expected %v", company.CustomAttributes, map[string]interface{}{"big_company": true})
 	}
@@ -70,9 +73,88 @@ func TestCompanyAPISave(t *testing.T) {
 	api.save(&company)
 }
 
+func TestCompanyAPIDelete(t *testing.T) {
+	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/company_deleted.json", expectedURI: "/companies/54c42ed71623d8caa", t: t}
+	api := CompanyAPI{httpClient: &http}
+	deleted, err := api.delete("54c42ed71623d8caa")
+	if err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	if deleted.ID != "54c42ed71623d8caa" || deleted.Object != "company" || !deleted.Deleted {
+		t.Errorf("Deleted was %+v", deleted)
+	}
+}
+
+func TestCompanyAPIListContacts(t *testing.T) {
+	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/company_contacts.json", expectedURI: "/companies/54c42ed71623d8caa/contacts", t: t}
+	api := CompanyAPI{httpClient: &http}
+	contactList, err := api.listContacts("54c42ed71623d8caa", PageParams{})
+	if err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	if len(contactList.Contacts) != 1 || contactList.Contacts[0].ID != "5ba682d23d7cf92bef87bfd4" {
+		t.Errorf("Contacts were %v", contactList.Contacts)
+	}
+	if contactList.TotalCount != 1 || contactList.Pages.TotalPages != 1 {
+		t.Errorf("Total count was %d, total pages %d", contactList.TotalCount, contactList.Pages.TotalPages)
+	}
+}
+
+func TestCompanyAPIListSegments(t *testing.T) {
+	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/company_segments.json", expectedURI: "/companies/54c42ed71623d8caa/segments", t: t}
+	api := CompanyAPI{httpClient: &http}
+	segmentList, err := api.listSegments("54c42ed71623d8caa")
+	if err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	if len(segmentList.Segments) != 1 || segmentList.Segments[0].Name != "Active" {
+		t.Errorf("Segments were %v", segmentList.Segments)
+	}
+}
+
+func TestCompanyAPIAttachContact(t *testing.T) {
+	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/company.json", expectedURI: "/contacts/5ba682d23d7cf92bef87bfd4/companies", t: t}
+	http.f = func(body interface{}) {
+		if body.(*struct {
+			ID string `json:"id"`
+		}).ID != "54c42ed71623d8caa" {
+			t.Errorf("Wrong company attached")
+		}
+	}
+	api := CompanyAPI{httpClient: &http}
+	company, err := api.attachContact("54c42ed71623d8caa", "5ba682d23d7cf92bef87bfd4")
+	if err != nil || company.ID != "54c42ed71623d8caa" {
+		t.Errorf("Company was %s, error %v", company.ID, err)
+	}
+}
+
+func TestCompanyAPIDetachContact(t *testing.T) {
+	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/company.json", expectedURI: "/contacts/5ba682d23d7cf92bef87bfd4/companies/54c42ed71623d8caa", t: t}
+	api := CompanyAPI{httpClient: &http}
+	company, err := api.detachContact("54c42ed71623d8caa", "5ba682d23d7cf92bef87bfd4")
+	if err != nil || company.ID != "54c42ed71623d8caa" {
+		t.Errorf("Company was %s, error %v", company.ID, err)
+	}
+}
+
+func TestCompanyAPITag(t *testing.T) {
+	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/tag.json", expectedURI: "/tags", t: t}
+	http.f = func(body interface{}) {
+		taggingList := body.(*TaggingList)
+		if taggingList.Name != "Lead" || taggingList.Companies[0].ID != "54c42ed71623d8caa" {
+			t.Errorf("Tagging was %v", taggingList)
+		}
+	}
+	api := CompanyAPI{httpClient: &http}
+	if _, err := api.tag(&TaggingList{Name: "Lead", Companies: []Tagging{{ID: "54c42ed71623d8caa"}}}); err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+}
+
 type TestCompanyHTTPClient struct {
 	TestHTTPClient
 	t               *testing.T
+	f               func(body interface{})
 	fixtureFilename string
 	expectedURI     string
 }
@@ -85,8 +167,21 @@ func (t TestCompanyHTTPClient) Get(uri string, queryParams interface{}) ([]byte,
 }
 
 func (t TestCompanyHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
-	if uri != "/companies" {
+	if t.expectedURI != uri {
 		t.t.Errorf("Wrong endpoint called")
 	}
-	return nil, nil
+	if t.f != nil {
+		t.f(body)
+	}
+	if t.fixtureFilename == "" {
+		return nil, nil
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
+}
+
+func (t TestCompanyHTTPClient) Delete(uri string, body interface{}) ([]byte, error) {
+	if t.expectedURI != uri {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
 }
@@ -2,6 +2,8 @@ package intercom
 
 import (
 	"testing"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
 )
 
 func TestCompanyFindByID(t *testing.T) {
@@ -55,12 +57,82 @@ func TestCompanySave(t *testing.T) {
 	companyService.Save(&company)
 }
 
+func TestCompanyDelete(t *testing.T) {
+	deleted, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).Delete("46adad3f09126dca")
+	if deleted.ID != "46adad3f09126dca" || !deleted.Deleted {
+		t.Errorf("Company not deleted")
+	}
+	if _, err := (&CompanyService{Repository: TestCompanyAPI{t: t}}).Delete(""); err == nil {
+		t.Errorf("Expected an error for a missing identifier")
+	}
+}
+
+func TestCompanyListContacts(t *testing.T) {
+	contactList, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).ListContacts("46adad3f09126dca", PageParams{})
+	if contactList.Contacts[0].ID != "b123d" {
+		t.Errorf("Contact not listed")
+	}
+}
+
+func TestCompanyListSegments(t *testing.T) {
+	segmentList, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).ListSegments("46adad3f09126dca")
+	if segmentList.Segments[0].ID != "s123" {
+		t.Errorf("Segment not listed")
+	}
+}
+
+func TestCompanyAttachDetachContact(t *testing.T) {
+	companyService := CompanyService{Repository: TestCompanyAPI{t: t}}
+	if company, _ := companyService.AttachContact("46adad3f09126dca", "b123d"); company.ID != "46adad3f09126dca" {
+		t.Errorf("Contact not attached")
+	}
+	if company, _ := companyService.DetachContact("46adad3f09126dca", "b123d"); company.ID != "46adad3f09126dca" {
+		t.Errorf("Contact not detached")
+	}
+	if _, err := companyService.AttachContact("46adad3f09126dca", ""); err == nil {
+		t.Errorf("Expected an error for a missing contact")
+	}
+}
+
+func TestCompanyTagUntag(t *testing.T) {
+	companyService := CompanyService{Repository: TestCompanyAPI{t: t}}
+	if tag, _ := companyService.Tag("46adad3f09126dca", "Lead"); tag.ID != "tagged" || tag.Name != "Lead" {
+		t.Errorf("Company not tagged")
+	}
+	if tag, _ := companyService.Untag("46adad3f09126dca", "Lead"); tag.ID != "untagged" {
+		t.Errorf("Company not untagged")
+	}
+}
+
+func TestCompanySearch(t *testing.T) {
+	companyService := CompanyService{Repository: TestCompanyAPI{t: t}}
+	if _, err := companyService.Search(CompanySearchParams{}); err == nil {
+		t.Errorf("Expected a search without criteria to fail")
+	}
+	if list, _ := companyService.Search(CompanySearchParams{TagID: "t1"}); len(list.Companies) != 1 || list.Companies[0].ID != "46adad3f09126dca" {
+		t.Errorf("Companies were not listed by tag, got %v", list)
+	}
+	if list, err := companyService.Search(CompanySearchParams{Name: "My Co", TagID: "t1"}); err != nil || len(list.Companies) != 1 || list.Companies[0].Name != "My Co" {
+		t.Errorf("Company was not found by name and tag, got %v, error %v", list, err)
+	}
+	if list, err := companyService.Search(CompanySearchParams{Name: "My Co", SegmentID: "s2"}); err != nil || len(list.Companies) != 0 {
+		t.Errorf("Company outside the segment was found, got %v, error %v", list, err)
+	}
+	if list, err := companyService.Search(CompanySearchParams{CompanyID: "missing"}); err != nil || len(list.Companies) != 0 {
+		t.Errorf("Expected no companies for a missing company, got %v, error %v", list, err)
+	}
+}
+
 type TestCompanyAPI struct {
 	t *testing.T
 }
 
 func (t TestCompanyAPI) find(params CompanyIdentifiers) (Company, error) {
-	return Company{ID: params.ID, Name: params.Name, CompanyID: params.CompanyID}, nil
+	if params.CompanyID == "missing" {
+		return Company{}, interfaces.NewUnknownHTTPError(404)
+	}
+	return Company{ID: params.ID, Name: params.Name, CompanyID: params.CompanyID,
+		Tags: &TagList{Tags: []Tag{{ID: "t1"}}}, Segments: &SegmentList{Segments: []Segment{{ID: "s1"}}}}, nil
 }
 
 func (t TestCompanyAPI) list(params companyListParams) (CompanyList, error) {
@@ -85,3 +157,34 @@ func (t TestCompanyAPI) save(company *Company) (Company, error) {
 	}
 	return Company{}, nil
 }
+
+func (t TestCompanyAPI) delete(id string) (DeletedObject, error) {
+	return DeletedObject{ID: id, Object: "company", Deleted: true}, nil
+}
+
+func (t TestCompanyAPI) listContacts(id string, params PageParams) (CompanyContactList, error) {
+	return CompanyContactList{Contacts: []Contact{{ID: "b123d"}}}, nil
+}
+
+func (t TestCompanyAPI) listSegments(id string) (SegmentList, error) {
+	return SegmentList{Segments: []Segment{{ID: "s123"}}}, nil
+}
+
+func (t TestCompanyAPI) attachContact(id, contactID string) (Company, error) {
+	return Company{ID: id}, nil
+}
+
+func (t TestCompanyAPI) detachContact(id, contactID string) (Company, error) {
+	return Company{ID: id}, nil
+}
+
+func (t TestCompanyAPI) tag(taggingList *TaggingList) (Tag, error) {
+	company := taggingList.Companies[0]
+	if company.ID != "46adad3f09126dca" {
+		t.t.Errorf("Company ID was %s, expected 46adad3f09126dca", company.ID)
+	}
+	if company.Untag != nil && *company.Untag {
+		return Tag{ID: "untagged", Name: taggingList.Name}, nil
+	}
+	return Tag{ID: "tagged", Name: taggingList.Name}, nil
+}
@@ -1,6 +1,9 @@
 package intercom
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // ContactService handles interactions with the API through a ContactRepository.
 type ContactService struct {
@@ -18,27 +21,27 @@ type ContactList struct {
 // Not all of the fields are writeable to the API, non-writeable fields are
 // stripped out from the request. Please see the API documentation for details.
 type Contact struct {
-	ID                     string                 `json:"id,omitempty"`
-	Email                  string                 `json:"email,omitempty"`
-	Phone                  string                 `json:"phone,omitempty"`
-	UserID                 string                 `json:"user_id,omitempty"`
-	Name                   string                 `json:"name,omitempty"`
-	Avatar                 *UserAvatar            `json:"avatar,omitempty"`
-	LocationData           *LocationData          `json:"location_data,omitempty"`
-	LastRequestAt          int64                  `json:"last_request_at,omitempty"`
-	CreatedAt              int64                  `json:"created_at,omitempty"`
-	UpdatedAt              int64                  `json:"updated_at,omitempty"`
-	SessionCount           int64                  `json:"session_count,omitempty"`
-	LastSeenIP             string                 `json:"last_seen_ip,omitempty"`
-	SocialProfiles         *SocialProfileList     `json:"social_profiles,omitempty"`
-	UnsubscribedFromEmails *bool                  `json:"unsubscribed_from_emails,omitempty"`
-	UserAgentData          string                 `json:"user_agent_data,omitempty"`
-	Tags                   *TagList               `json:"tags,omitempty"`
-	Segments               *SegmentList           `json:"segments,omitempty"`
-	Companies              *CompanyList           `json:"companies,omitempty"`
-	CustomAttributes       map[string]interface{} `json:"custom_attributes,omitempty"`
-	UpdateLastRequestAt    *bool                  `json:"update_last_request_at,omitempty"`
-	NewSession             *bool                  `json:"new_session,omitempty"`
+	ID                     string             `json:"id,omitempty"`
+	Email                  string             `json:"email,omitempty"`
+	Phone                  string             `json:"phone,omitempty"`
+	UserID                 string             `json:"user_id,omitempty"`
+	Name                   string             `json:"name,omitempty"`
+	Avatar                 *UserAvatar        `json:"avatar,omitempty"`
+	LocationData           *LocationData      `json:"location_data,omitempty"`
+	LastRequestAt          int64              `json:"last_request_at,omitempty"`
+	CreatedAt              int64              `json:"created_at,omitempty"`
+	UpdatedAt              int64              `json:"updated_at,omitempty"`
+	SessionCount           int64              `json:"session_count,omitempty"`
+	LastSeenIP             string             `json:"last_seen_ip,omitempty"`
+	SocialProfiles         *SocialProfileList `json:"social_profiles,omitempty"`
+	UnsubscribedFromEmails *bool              `json:"unsubscribed_from_emails,omitempty"`
+	UserAgentData          string             `json:"user_agent_data,omitempty"`
+	Tags                   *TagList           `json:"tags,omitempty"`
+	Segments               *SegmentList       `json:"segments,omitempty"`
+	Companies              *CompanyList       `json:"companies,omitempty"`
+	CustomAttributes       CustomAttributes   `json:"custom_attributes,omitempty"`
+	UpdateLastRequestAt    *bool              `json:"update_last_request_at,omitempty"`
+	NewSession             *bool              `json:"new_session,omitempty"`
 }
 
 type contactListParams struct {
@@ -87,19 +90,19 @@ type SearchContact struct {
 		CountryCode   string `json:"country_code"`
 		ContinentCode string `json:"continent_code"`
 	} `json:"location"`
-	AndroidAppName    any                    `json:"android_app_name"`
-	AndroidAppVersion any                    `json:"android_app_version"`
-	AndroidDevice     any                    `json:"android_device"`
-	AndroidOsVersion  any                    `json:"android_os_version"`
-	AndroidSdkVersion any                    `json:"android_sdk_version"`
-	AndroidLastSeenAt any                    `json:"android_last_seen_at"`
-	IosAppName        any                    `json:"ios_app_name"`
-	IosAppVersion     any                    `json:"ios_app_version"`
-	IosDevice         any                    `json:"ios_device"`
-	IosOsVersion      any                    `json:"ios_os_version"`
-	IosSdkVersion     any                    `json:"ios_sdk_version"`
-	IosLastSeenAt     any                    `json:"ios_last_seen_at"`
-	CustomAttributes  map[string]interface{} `json:"custom_attributes"`
+	AndroidAppName    any              `json:"android_app_name"`
+	AndroidAppVersion any              `json:"android_app_version"`
+	AndroidDevice     any              `json:"android_device"`
+	AndroidOsVersion  any              `json:"android_os_version"`
+	AndroidSdkVersion any              `json:"android_sdk_version"`
+	AndroidLastSeenAt any              `json:"android_last_seen_at"`
+	IosAppName        any              `json:"ios_app_name"`
+	IosAppVersion     any              `json:"ios_app_version"`
+	IosDevice         any              `json:"ios_device"`
+	IosOsVersion      any              `json:"ios_os_version"`
+	IosSdkVersion     any              `json:"ios_sdk_version"`
+	IosLastSeenAt     any              `json:"ios_last_seen_at"`
+	CustomAttributes  CustomAttributes `json:"custom_attributes"`
 	Tags              struct {
 		Type       string `json:"type"`
 		Data       []Tag  `json:"data"`
@@ -121,32 +124,48 @@ type SearchContact struct {
 		TotalCount int       `json:"total_count"`
 		HasMore    bool      `json:"has_more"`
 	} `json:"companies"`
-	OptedOutSubscriptionTypes struct {
-		Type       string `json:"type"`
-		Data       []any  `json:"data"`
-		Url        string `json:"url"`
-		TotalCount int    `json:"total_count"`
-		HasMore    bool   `json:"has_more"`
-	} `json:"opted_out_subscription_types"`
-	OptedInSubscriptionTypes struct {
-		Type       string `json:"type"`
-		Data       []any  `json:"data"`
-		Url        string `json:"url"`
-		TotalCount int    `json:"total_count"`
-		HasMore    bool   `json:"has_more"`
-	} `json:"opted_in_subscription_types"`
-	UtmCampaign         any    `json:"utm_campaign"`
-	UtmContent          any    `json:"utm_content"`
-	UtmMedium           any    `json:"utm_medium"`
-	UtmSource           any    `json:"utm_source"`
-	UtmTerm             any    `json:"utm_term"`
-	Referrer            string `json:"referrer"`
-	SmsConsent          bool   `json:"sms_consent"`
-	UnsubscribedFromSms bool   `json:"unsubscribed_from_sms"`
+	OptedOutSubscriptionTypes SubscriptionTypeReferences `json:"opted_out_subscription_types"`
+	OptedInSubscriptionTypes  SubscriptionTypeReferences `json:"opted_in_subscription_types"`
+	UtmCampaign               any                        `json:"utm_campaign"`
+	UtmContent                any                        `json:"utm_content"`
+	UtmMedium                 any                        `json:"utm_medium"`
+	UtmSource                 any                        `json:"utm_source"`
+	UtmTerm                   any                        `json:"utm_term"`
+	Referrer                  string                     `json:"referrer"`
+	SmsConsent                bool                       `json:"sms_consent"`
+	UnsubscribedFromSms       bool                       `json:"unsubscribed_from_sms"`
+}
+
+// NoteList holds a page of Notes
+type NoteList struct {
+	Type       string     `json:"type,omitempty"`
+	Pages      PageParams `json:"pages"`
+	TotalCount int64      `json:"total_count"`
+	Notes      []Note     `json:"data"`
+}
+
+// A Note is left on a Contact by an Admin. Its Body is HTML.
+type Note struct {
+	Type      string `json:"type,omitempty"`
+	ID        string `json:"id"`
+	CreatedAt int64  `json:"created_at,omitempty"`
+	Contact   *struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	} `json:"contact,omitempty"`
+	Author *Admin `json:"author,omitempty"`
+	Body   string `json:"body"`
 }
 
 type ContactSearchParams struct {
-	Query map[string]string `json:"query,omitempty"`
+	Query      map[string]string        `json:"query,omitempty"`
+	Pagination *ContactSearchPagination `json:"pagination,omitempty"`
+}
+
+// ContactSearchPagination requests a page of search results: the one after the cursor of pages.next.
+type ContactSearchPagination struct {
+	PerPage       int    `json:"per_page,omitempty"`
+	StartingAfter string `json:"starting_after,omitempty"`
 }
 
 type contactSearchResult struct {
@@ -157,6 +176,10 @@ type contactSearchResult struct {
 		Page       int    `json:"page"`
 		PerPage    int    `json:"per_page"`
 		TotalPages int    `json:"total_pages"`
+		Next       *struct {
+			Page          int    `json:"page"`
+			StartingAfter string `json:"starting_after"`
+		} `json:"next,omitempty"`
 	} `json:"pages"`
 	Data []SearchContact `json:"data"`
 }
@@ -225,6 +248,30 @@ func (c *ContactService) Delete(contact *Contact) (Contact, error) {
 	return c.Repository.delete(contact.ID)
 }
 
+// ListCompanies lists the Companies a Contact belongs to.
+func (c *ContactService) ListCompanies(id string, params PageParams) (CompanyList, error) {
+	if id == "" {
+		return CompanyList{}, errors.New("Missing Contact ID")
+	}
+	return c.Repository.listCompanies(id, params)
+}
+
+// ListTags lists the Tags applied to a Contact.
+func (c *ContactService) ListTags(id string) (TagList, error) {
+	if id == "" {
+		return TagList{}, errors.New("Missing Contact ID")
+	}
+	return c.Repository.listTags(id)
+}
+
+// ListNotes lists the Notes Admins left on a Contact.
+func (c *ContactService) ListNotes(id string, params PageParams) (NoteList, error) {
+	if id == "" {
+		return NoteList{}, errors.New("Missing Contact ID")
+	}
+	return c.Repository.listNotes(id, params)
+}
+
 // MessageAddress gets the address for a Contact in order to message them
 func (c Contact) MessageAddress() MessageAddress {
 	return MessageAddress{
@@ -18,6 +18,9 @@ type ContactRepository interface {
 	update(*Contact) (Contact, error)
 	convert(*Contact, *User) (User, error)
 	delete(id string) (Contact, error)
+	listCompanies(id string, params PageParams) (CompanyList, error)
+	listTags(id string) (TagList, error)
+	listNotes(id string, params PageParams) (NoteList, error)
 }
 
 // ContactAPI implements ContactRepository
@@ -100,6 +103,42 @@ func (api ContactAPI) delete(id string) (Contact, error) {
 	return contact, err
 }
 
+func (api ContactAPI) listCompanies(id string, params PageParams) (CompanyList, error) {
+	list := CompanyList{}
+	data, err := api.httpClient.Get(fmt.Sprintf("/contacts/%s/companies", id), params)
+	if err != nil {
+		return list, err
+	}
+	response := struct {
+		Pages     PageParams `json:"pages"`
+		Companies []Company  `json:"data"`
+	}{}
+	err = json.Unmarshal(data, &response)
+	return CompanyList{Pages: response.Pages, Companies: response.Companies}, err
+}
+
+func (api ContactAPI) listTags(id string) (TagList, error) {
+	data, err := api.httpClient.Get(fmt.Sprintf("/contacts/%s/tags", id), nil)
+	if err != nil {
+		return TagList{}, err
+	}
+	response := struct {
+		Tags []Tag `json:"data"`
+	}{}
+	err = json.Unmarshal(data, &response)
+	return TagList{Tags: response.Tags}, err
+}
+
+func (api ContactAPI) listNotes(id string, params PageParams) (NoteList, error) {
+	list := NoteList{}
+	data, err := api.httpClient.Get(fmt.Sprintf("/contacts/%s/notes", id), params)
+	if err != nil {
+		return list, err
+	}
+	err = json.Unmarshal(data, &list)
+	return list, err
+}
+
 type convertRequest struct {
 	User    requestUser `json:"user"`
 	Contact requestUser `json:"contact"`
@@ -85,3 +85,33 @@ func TestContactAPIDelete(t *testing.T) {
 		t.Errorf("Expected UserID %s, got %s", "123", returned.UserID)
 	}
 }
+
+func TestContactAPIListCompanies(t *testing.T) {
+	http := TestUserHTTPClient{fixtureFilename: "fixtures/contact_companies.json", expectedURI: "/contacts/54c42e7ea7a765fa7/companies", t: t}
+	api := ContactAPI{httpClient: &http}
+	list, err := api.listCompanies("54c42e7ea7a765fa7", PageParams{Page: 1})
+	if err != nil || len(list.Companies) != 1 || list.Companies[0].Name != "Blue Sun" || list.Pages.TotalPages != 1 {
+		t.Errorf("Companies were %v, error %v", list, err)
+	}
+}
+
+func TestContactAPIListTags(t *testing.T) {
+	http := TestUserHTTPClient{fixtureFilename: "fixtures/contact_tags.json", expectedURI: "/contacts/54c42e7ea7a765fa7/tags", t: t}
+	api := ContactAPI{httpClient: &http}
+	list, err := api.listTags("54c42e7ea7a765fa7")
+	if err != nil || len(list.Tags) != 1 || list.Tags[0].Name != "Independent" {
+		t.Errorf("Tags were %v, error %v", list, err)
+	}
+}
+
+func TestContactAPIListNotes(t *testing.T) {
+	http := TestUserHTTPClient{fixtureFilename: "fixtures/contact_notes.json", expectedURI: "/contacts/54c42e7ea7a765fa7/notes", t: t}
+	api := ContactAPI{httpClient: &http}
+	list, err := api.listNotes("54c42e7ea7a765fa7", PageParams{Page: 1})
+	if err != nil || len(list.Notes) != 1 {
+		t.Fatalf("Notes were %v, error %v", list, err)
+	}
+	if note := list.Notes[0]; note.Body != "<p>Text for the note.</p>" || note.Author.Name != "Ciaran Lee" || note.Contact.ID != "54c42e7ea7a765fa7" {
+		t.Errorf("Note was %v", note)
+	}
+}
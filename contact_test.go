@@ -94,6 +94,10 @@ type TestContactAPI struct {
 	t *testing.T
 }
 
+func (t TestContactAPI) search(params ContactSearchParams) (contactSearchResult, error) {
+	return contactSearchResult{Data: []SearchContact{SearchContact{Id: "46adad3f09126dca", Email: "jamie@example.io"}}}, nil
+}
+
 func (t TestContactAPI) find(params UserIdentifiers) (Contact, error) {
 	return Contact{ID: params.ID, Email: params.Email, UserID: params.UserID}, nil
 }
@@ -121,3 +125,15 @@ func (t TestContactAPI) convert(c *Contact, u *User) (User, error) {
 func (t TestContactAPI) delete(id string) (Contact, error) {
 	return Contact{ID: id}, nil
 }
+
+func (t TestContactAPI) listCompanies(id string, params PageParams) (CompanyList, error) {
+	return CompanyList{Companies: []Company{{ID: "531ee472cce572a6ec000006"}}}, nil
+}
+
+func (t TestContactAPI) listTags(id string) (TagList, error) {
+	return TagList{Tags: []Tag{{ID: "24", Name: "Independent"}}}, nil
+}
+
+func (t TestContactAPI) listNotes(id string, params PageParams) (NoteList, error) {
+	return NoteList{Notes: []Note{{ID: "17495962", Body: "<p>Text for the note.</p>"}}}, nil
+}
@@ -0,0 +1,211 @@
+package intercom
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A ContentDataRecord is a row of a content data export: a receipt of a piece of content by a user,
+// and when the user first engaged with it. Times are zero where the user has not.
+type ContentDataRecord struct {
+	UserID         string
+	UserExternalID string
+	CompanyID      string
+	Email          string
+	Name           string
+
+	RulesetID        string
+	RulesetVersionID string
+	ContentType      string
+	ContentID        string
+	ContentTitle     string
+	ReceiptID        string
+	ReceivedAt       time.Time
+
+	SeriesID    string
+	SeriesTitle string
+	NodeID      string
+
+	FirstReply               time.Time
+	FirstCompletion          time.Time
+	FirstSeriesCompletion    time.Time
+	FirstSeriesDisengagement time.Time
+	FirstSeriesExit          time.Time
+	FirstGoalSuccess         time.Time
+	FirstOpen                time.Time
+	FirstClick               time.Time
+	FirstDismissal           time.Time
+	FirstUnsubscribe         time.Time
+	FirstHardBounce          time.Time
+
+	// Extra holds the columns which have no field, by header.
+	Extra map[string]string
+}
+
+// contentDataColumns maps the columns of a content data export to the fields of a ContentDataRecord.
+var contentDataColumns = map[string]func(*ContentDataRecord, string) error{
+	"user_id":                    stringColumn(func(r *ContentDataRecord) *string { return &r.UserID }),
+	"user_external_id":           stringColumn(func(r *ContentDataRecord) *string { return &r.UserExternalID }),
+	"company_id":                 stringColumn(func(r *ContentDataRecord) *string { return &r.CompanyID }),
+	"email":                      stringColumn(func(r *ContentDataRecord) *string { return &r.Email }),
+	"name":                       stringColumn(func(r *ContentDataRecord) *string { return &r.Name }),
+	"ruleset_id":                 stringColumn(func(r *ContentDataRecord) *string { return &r.RulesetID }),
+	"ruleset_version_id":         stringColumn(func(r *ContentDataRecord) *string { return &r.RulesetVersionID }),
+	"content_type":               stringColumn(func(r *ContentDataRecord) *string { return &r.ContentType }),
+	"content_id":                 stringColumn(func(r *ContentDataRecord) *string { return &r.ContentID }),
+	"content_title":              stringColumn(func(r *ContentDataRecord) *string { return &r.ContentTitle }),
+	"receipt_id":                 stringColumn(func(r *ContentDataRecord) *string { return &r.ReceiptID }),
+	"received_at":                timeColumn(func(r *ContentDataRecord) *time.Time { return &r.ReceivedAt }),
+	"series_id":                  stringColumn(func(r *ContentDataRecord) *string { return &r.SeriesID }),
+	"series_title":               stringColumn(func(r *ContentDataRecord) *string { return &r.SeriesTitle }),
+	"node_id":                    stringColumn(func(r *ContentDataRecord) *string { return &r.NodeID }),
+	"first_reply":                timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstReply }),
+	"first_completion":           timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstCompletion }),
+	"first_series_completion":    timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstSeriesCompletion }),
+	"first_series_disengagement": timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstSeriesDisengagement }),
+	"first_series_exit":          timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstSeriesExit }),
+	"first_goal_success":         timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstGoalSuccess }),
+	"first_open":                 timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstOpen }),
+	"first_click":                timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstClick }),
+	"first_dismisall":            timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstDismissal }), // Sic.
+	"first_dismissal":            timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstDismissal }),
+	"first_unsubscribe":          timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstUnsubscribe }),
+	"first_hard_bounce":          timeColumn(func(r *ContentDataRecord) *time.Time { return &r.FirstHardBounce }),
+}
+
+func stringColumn(field func(*ContentDataRecord) *string) func(*ContentDataRecord, string) error {
+	return func(r *ContentDataRecord, value string) error {
+		*field(r) = value
+		return nil
+	}
+}
+
+func timeColumn(field func(*ContentDataRecord) *time.Time) func(*ContentDataRecord, string) error {
+	return func(r *ContentDataRecord, value string) (err error) {
+		*field(r), err = parseContentDataTime(value)
+		return err
+	}
+}
+
+var contentDataTimeLayouts = [...]string{
+	time.RFC3339,
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+}
+
+// parseContentDataTime parses a time of a content data export, given as a Unix timestamp or a date and time.
+func parseContentDataTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	for _, layout := range contentDataTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid Time %q", value)
+}
+
+// A ContentDataReader reads the records of a content data export as they are downloaded.
+type ContentDataReader struct {
+	body    io.ReadCloser
+	gzip    *gzip.Reader
+	csv     *csv.Reader
+	columns []string
+	record  ContentDataRecord
+	err     error
+}
+
+// NewContentDataReader returns a ContentDataReader over a content data export, gzipped or not.
+// Columns are matched by the header row; unknown columns end up in Extra.
+func NewContentDataReader(body io.ReadCloser) (*ContentDataReader, error) {
+	r := &ContentDataReader{body: body}
+	buffered := bufio.NewReader(body)
+	var source io.Reader = buffered
+	if magic, _ := buffered.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			body.Close()
+			return nil, err
+		}
+		r.gzip, source = gz, gz
+	}
+	r.csv = csv.NewReader(source)
+	r.csv.FieldsPerRecord = -1
+	header, err := r.csv.Read()
+	if err == io.EOF {
+		return r, nil // An export without data may be empty.
+	}
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+	}
+	r.columns = header
+	return r, nil
+}
+
+// Next reads the next record, returning false once there are none or on an error; check Err.
+func (r *ContentDataReader) Next() bool {
+	if r.err != nil || r.columns == nil {
+		return false
+	}
+	row, err := r.csv.Read()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			r.err = err
+		}
+		return false
+	}
+	r.record = ContentDataRecord{}
+	for i, value := range row {
+		if i >= len(r.columns) {
+			break
+		}
+		set, ok := contentDataColumns[r.columns[i]]
+		if !ok {
+			if r.record.Extra == nil {
+				r.record.Extra = map[string]string{}
+			}
+			r.record.Extra[r.columns[i]] = value
+			continue
+		}
+		if err := set(&r.record, value); err != nil {
+			line, _ := r.csv.FieldPos(i)
+			r.err = fmt.Errorf("line %d, column %s: %w", line, r.columns[i], err)
+			return false
+		}
+	}
+	return true
+}
+
+// Record returns the current record.
+func (r *ContentDataReader) Record() ContentDataRecord {
+	return r.record
+}
+
+// Err returns the error which stopped reading, if any.
+func (r *ContentDataReader) Err() error {
+	return r.err
+}
+
+// Close closes the download.
+func (r *ContentDataReader) Close() error {
+	if r.gzip != nil {
+		r.gzip.Close()
+	}
+	return r.body.Close()
+}
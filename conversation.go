@@ -1,8 +1,17 @@
 package intercom
 
+import (
+	"errors"
+	"time"
+)
+
 // ConversationService handles interactions with the API through an ConversationRepository.
 type ConversationService struct {
 	Repository ConversationRepository
+
+	// AttachmentTypes lists the media types ReplyWithAttachments uploads, such as "application/pdf" or "image/*".
+	// DefaultReplyAttachmentTypes are used when empty.
+	AttachmentTypes []string
 }
 
 // ConversationList is a list of Conversations
@@ -84,8 +93,8 @@ type Conversation struct {
 		Type   string        `json:"type"`
 		Admins []interface{} `json:"admins"`
 	} `json:"teammates"`
-	Title            string                 `json:"title"`
-	CustomAttributes map[string]interface{} `json:"custom_attributes"`
+	Title            string           `json:"title"`
+	CustomAttributes CustomAttributes `json:"custom_attributes"`
 	Topics           struct {
 		Type   string `json:"type"`
 		Topics []struct {
@@ -165,6 +174,33 @@ type ConversationPart struct {
 
 type requestConversation Conversation
 
+type conversationPriorityRequest struct {
+	Priority string `json:"priority"`
+}
+
+type conversationCreateRequest struct {
+	From MessageAddress `json:"from"`
+	Body string         `json:"body"`
+}
+
+type conversationCustomerRequest struct {
+	AdminID  string                `json:"admin_id" url:"admin_id"`
+	Customer *conversationCustomer `json:"customer,omitempty" url:"-"`
+}
+
+type conversationCustomer struct {
+	IntercomUserID string `json:"intercom_user_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+	Email          string `json:"email,omitempty"`
+}
+
+type conversationRedactRequest struct {
+	Type               string `json:"type"`
+	ConversationID     string `json:"conversation_id"`
+	ConversationPartID string `json:"conversation_part_id,omitempty"`
+	SourceID           string `json:"source_id,omitempty"`
+}
+
 type ConversationFindParams struct {
 	DisplayType *string
 }
@@ -271,11 +307,97 @@ func (c *ConversationService) Update(conversation *Conversation) (Conversation,
 	return c.Repository.update(conversation)
 }
 
+// Create a Conversation started by a User or Contact.
+// The MessageResponse holds the ConversationID of the new Conversation.
+func (c *ConversationService) Create(from MessagePerson, body string) (MessageResponse, error) {
+	addr := from.MessageAddress()
+	if addr.ID == "" {
+		return MessageResponse{}, errors.New("Missing Contact Identifier")
+	}
+	return c.Repository.create(&conversationCreateRequest{From: MessageAddress{Type: addr.Type, ID: addr.ID}, Body: body})
+}
+
+// Snooze a Conversation until a time
+func (c *ConversationService) Snooze(id string, snoozer *Admin, until time.Time) (Conversation, error) {
+	reply := Reply{
+		Type:         "admin",
+		ReplyType:    CONVERSATION_SNOOZED.String(),
+		AdminID:      snoozer.MessageAddress().ID,
+		SnoozedUntil: until.Unix(),
+	}
+	return c.Repository.manage(id, &reply)
+}
+
+// SetPriority marks a Conversation as priority, or not
+func (c *ConversationService) SetPriority(id string, priority bool) (Conversation, error) {
+	if id == "" {
+		return Conversation{}, errors.New("Missing Conversation ID")
+	}
+	request := conversationPriorityRequest{Priority: "not_priority"}
+	if priority {
+		request.Priority = "priority"
+	}
+	return c.Repository.setPriority(id, request)
+}
+
+// AttachContact adds a User or Contact as a participant of a Conversation, on behalf of an Admin
+func (c *ConversationService) AttachContact(id string, admin *Admin, contact MessagePerson) (Conversation, error) {
+	addr := contact.MessageAddress()
+	if addr.ID == "" && addr.UserID == "" && addr.Email == "" {
+		return Conversation{}, errors.New("Missing Contact Identifier")
+	}
+	request := conversationCustomerRequest{
+		AdminID:  admin.MessageAddress().ID,
+		Customer: &conversationCustomer{IntercomUserID: addr.ID, UserID: addr.UserID, Email: addr.Email},
+	}
+	return c.Repository.attach(id, &request)
+}
+
+// DetachContact removes a participant from a Conversation, using their Intercom ID, on behalf of an Admin
+func (c *ConversationService) DetachContact(id string, admin *Admin, contactID string) (Conversation, error) {
+	if contactID == "" {
+		return Conversation{}, errors.New("Missing Contact Identifier")
+	}
+	return c.Repository.detach(id, contactID, conversationCustomerRequest{AdminID: admin.MessageAddress().ID})
+}
+
+// RedactPart redacts the body of a Conversation Part
+func (c *ConversationService) RedactPart(id, partID string) (Conversation, error) {
+	if id == "" {
+		return Conversation{}, errors.New("Missing Conversation ID")
+	}
+	if partID == "" {
+		return Conversation{}, errors.New("Missing Conversation Part ID")
+	}
+	return c.Repository.redact(&conversationRedactRequest{Type: "conversation_part", ConversationID: id, ConversationPartID: partID})
+}
+
+// RedactSource redacts the body of the message which started a Conversation
+func (c *ConversationService) RedactSource(id, sourceID string) (Conversation, error) {
+	if id == "" {
+		return Conversation{}, errors.New("Missing Conversation ID")
+	}
+	if sourceID == "" {
+		return Conversation{}, errors.New("Missing Source ID")
+	}
+	return c.Repository.redact(&conversationRedactRequest{Type: "source", ConversationID: id, SourceID: sourceID})
+}
+
+// RunAssignmentRules runs the assignment rules of the workspace against a Conversation
+func (c *ConversationService) RunAssignmentRules(id string) (Conversation, error) {
+	return c.Repository.runAssignmentRules(id)
+}
+
 /**/
 // Helpers
 /**/
 
 func (c *ConversationService) reply(id string, author MessagePerson, replyType ReplyType, body string, attachmentURLs []string) (Conversation, error) {
+	reply := c.buildReply(author, replyType, body, attachmentURLs)
+	return c.Repository.reply(id, &reply)
+}
+
+func (c *ConversationService) buildReply(author MessagePerson, replyType ReplyType, body string, attachmentURLs []string) Reply {
 	addr := author.MessageAddress()
 	reply := Reply{
 		Type:           addr.Type,
@@ -290,7 +412,7 @@ func (c *ConversationService) reply(id string, author MessagePerson, replyType R
 		reply.UserID = addr.UserID
 		reply.Email = addr.Email
 	}
-	return c.Repository.reply(id, &reply)
+	return reply
 }
 
 type ConversationListParams struct {
@@ -14,6 +14,13 @@ type ConversationRepository interface {
 	read(id string) (Conversation, error)
 	reply(id string, reply *Reply) (Conversation, error)
 	update(conversation *Conversation) (Conversation, error)
+	setPriority(id string, request conversationPriorityRequest) (Conversation, error)
+	create(request *conversationCreateRequest) (MessageResponse, error)
+	manage(id string, reply *Reply) (Conversation, error)
+	attach(id string, request *conversationCustomerRequest) (Conversation, error)
+	detach(id, contactID string, request conversationCustomerRequest) (Conversation, error)
+	redact(request *conversationRedactRequest) (Conversation, error)
+	runAssignmentRules(id string) (Conversation, error)
 }
 
 // ConversationAPI implements ConversationRepository
@@ -76,6 +83,40 @@ func (api ConversationAPI) update(conversation *Conversation) (Conversation, err
 	return unmarshalToConversation(api.httpClient.Put("/conversations/"+conversation.Id, &reqConv))
 }
 
+func (api ConversationAPI) setPriority(id string, request conversationPriorityRequest) (Conversation, error) {
+	return unmarshalToConversation(api.httpClient.Put(fmt.Sprintf("/conversations/%s", id), request))
+}
+
+func (api ConversationAPI) create(request *conversationCreateRequest) (MessageResponse, error) {
+	message := MessageResponse{}
+	data, err := api.httpClient.Post("/conversations", request)
+	if err != nil {
+		return message, err
+	}
+	err = json.Unmarshal(data, &message)
+	return message, err
+}
+
+func (api ConversationAPI) manage(id string, reply *Reply) (Conversation, error) {
+	return unmarshalToConversation(api.httpClient.Post(fmt.Sprintf("/conversations/%s/parts", id), reply))
+}
+
+func (api ConversationAPI) attach(id string, request *conversationCustomerRequest) (Conversation, error) {
+	return unmarshalToConversation(api.httpClient.Post(fmt.Sprintf("/conversations/%s/customers", id), request))
+}
+
+func (api ConversationAPI) detach(id, contactID string, request conversationCustomerRequest) (Conversation, error) {
+	return unmarshalToConversation(api.httpClient.DeleteWithBody(fmt.Sprintf("/conversations/%s/customers/%s", id, contactID), &request))
+}
+
+func (api ConversationAPI) redact(request *conversationRedactRequest) (Conversation, error) {
+	return unmarshalToConversation(api.httpClient.Post("/conversations/redact", request))
+}
+
+func (api ConversationAPI) runAssignmentRules(id string) (Conversation, error) {
+	return unmarshalToConversation(api.httpClient.Post(fmt.Sprintf("/conversations/%s/run_assignment_rules", id), struct{}{}))
+}
+
 /**/
 // Helpers
 /**/
@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"testing"
 )
@@ -8,18 +9,18 @@ import (
 func TestConversationFind(t *testing.T) {
 	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/147", fixtureFilename: "fixtures/conversation.json"}
 	api := ConversationAPI{httpClient: &http}
-	convo, _ := api.find("147")
-	if convo.ID != "147" {
-		t.Errorf("Conversation not retrieved, %s", convo.ID)
+	convo, _ := api.find("147", "plaintext")
+	if convo.Id != "147" {
+		t.Errorf("Conversation not retrieved, %s", convo.Id)
 	}
-	if convo.TagList == nil || convo.TagList.Tags[0].ID != "12345" {
-		t.Errorf("Conversation tags not retrieved, %s", convo.ID)
+	if len(convo.Tags.Tags) != 1 {
+		t.Errorf("Conversation tags not retrieved, %s", convo.Id)
 	}
-	if convo.ConversationMessage.ID != "537e564f316c33104c010020" {
-		t.Errorf("Conversation ID not retrieved, %s", convo.ConversationMessage.ID)
+	if convo.Source.Id != "537e564f316c33104c010020" {
+		t.Errorf("Conversation ID not retrieved, %s", convo.Source.Id)
 	}
-	if convo.ConversationMessage.URL != "/the/page/url.html" {
-		t.Errorf("Conversation URL not retrieved, %s", convo.ConversationMessage.URL)
+	if convo.Source.Url != "/the/page/url.html" {
+		t.Errorf("Conversation URL not retrieved, %s", convo.Source.Url)
 	}
 }
 
@@ -36,8 +37,19 @@ func TestConversationRead(t *testing.T) {
 	if err != nil {
 		t.Errorf("%v", err)
 	}
-	if convo.ID != "147" {
-		t.Errorf("Conversation not retrieved, %s", convo.ID)
+	if convo.Id != "147" {
+		t.Errorf("Conversation not retrieved, %s", convo.Id)
+	}
+}
+
+func TestConversationSetPriority(t *testing.T) {
+	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/147", fixtureFilename: "fixtures/conversation.json"}
+	api := ConversationAPI{httpClient: &http}
+	if _, err := api.setPriority("147", conversationPriorityRequest{Priority: "priority"}); err != nil {
+		t.Errorf("%v", err)
+	}
+	if body, _ := json.Marshal(http.lastBody); string(body) != `{"priority":"priority"}` {
+		t.Errorf("Sent %s", body)
 	}
 }
 
@@ -54,8 +66,8 @@ func TestConversationReply(t *testing.T) {
 	if err != nil {
 		t.Errorf("%v", err)
 	}
-	if convo.ID != "147" {
-		t.Errorf("Conversation not retrieved, %s", convo.ID)
+	if convo.Id != "147" {
+		t.Errorf("Conversation not retrieved, %s", convo.Id)
 	}
 }
 
@@ -72,8 +84,8 @@ func TestConversationReplyWithAttachment(t *testing.T) {
 	if err != nil {
 		t.Errorf("%v", err)
 	}
-	if convo.ID != "147" {
-		t.Errorf("Conversation not retrieved, %s", convo.ID)
+	if convo.Id != "147" {
+		t.Errorf("Conversation not retrieved, %s", convo.Id)
 	}
 }
 
@@ -81,20 +93,20 @@ func TestConversationListAll(t *testing.T) {
 	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations", fixtureFilename: "fixtures/conversations.json"}
 	api := ConversationAPI{httpClient: &http}
 	convos, _ := api.list(ConversationListParams{})
-	if convos.Conversations[0].ID != "147" {
+	if convos.Conversations[0].Id != "147" {
 		t.Errorf("Conversation not retrieved")
 	}
-	if convos.Conversations[0].User.ID != "536e564f316c83104c000020" {
-		t.Errorf("Conversation user not retrieved")
+	if convos.Conversations[0].Contacts.Contacts[0].Id != "536e564f316c83104c000020" {
+		t.Errorf("Conversation contact not retrieved")
 	}
-	if convos.Conversations[0].ConversationMessage.Author.ID != "25" {
-		t.Errorf("Conversation Message Author not retrieved")
+	if convos.Conversations[0].Source.Author.Id != "25" {
+		t.Errorf("Conversation Source Author not retrieved")
 	}
-	if convos.Conversations[0].ConversationParts.Parts[0].CreatedAt != 1400857494 {
+	if convos.Conversations[0].ConversationParts.ConversationParts[0].CreatedAt != 1400857494 {
 		t.Errorf("Conversation Part CreatedAt not retrieved")
 	}
-	if convos.Conversations[0].TagList != nil {
-		t.Errorf("Conversation Tags should be nil")
+	if len(convos.Conversations[0].Tags.Tags) != 0 {
+		t.Errorf("Conversation Tags should be empty")
 	}
 }
 
@@ -122,6 +134,71 @@ func TestConversationListAdminOpen(t *testing.T) {
 	api.list(ConversationListParams{Open: Bool(true)})
 }
 
+func TestConversationCreate(t *testing.T) {
+	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations", fixtureFilename: "fixtures/conversation_created.json"}
+	http.testFunc = func(t *testing.T, createRequest interface{}) {
+		from := createRequest.(*conversationCreateRequest).From
+		if from.Type != "contact" || from.ID != "5ba682d2" {
+			t.Errorf("Created from %v", from)
+		}
+	}
+	api := ConversationAPI{httpClient: &http}
+	message, err := api.create(&conversationCreateRequest{From: MessageAddress{Type: "contact", ID: "5ba682d2"}, Body: "Hello there"})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if message.ConversationID != "363" || message.ID != "403918330" {
+		t.Errorf("Message was %v", message)
+	}
+}
+
+func TestConversationManage(t *testing.T) {
+	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/147/parts", fixtureFilename: "fixtures/conversation.json"}
+	http.testFunc = func(t *testing.T, reply interface{}) {
+		if reply.(*Reply).ReplyType != "snoozed" || reply.(*Reply).SnoozedUntil != 1673609604 {
+			t.Errorf("Reply was %v", reply)
+		}
+	}
+	api := ConversationAPI{httpClient: &http}
+	convo, err := api.manage("147", &Reply{Type: "admin", ReplyType: "snoozed", AdminID: "25", SnoozedUntil: 1673609604})
+	if err != nil || convo.Id != "147" {
+		t.Errorf("Conversation was %s, error %v", convo.Id, err)
+	}
+}
+
+func TestConversationAttachDetach(t *testing.T) {
+	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/147/customers", fixtureFilename: "fixtures/conversation.json"}
+	api := ConversationAPI{httpClient: &http}
+	if _, err := api.attach("147", &conversationCustomerRequest{AdminID: "25", Customer: &conversationCustomer{IntercomUserID: "5ba682d2"}}); err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	http.expectedURI = "/conversations/147/customers/5ba682d2"
+	http.testFunc = func(t *testing.T, params interface{}) {
+		if params.(*conversationCustomerRequest).AdminID != "25" {
+			t.Errorf("admin id not supplied")
+		}
+	}
+	if _, err := api.detach("147", "5ba682d2", conversationCustomerRequest{AdminID: "25"}); err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+}
+
+func TestConversationRedact(t *testing.T) {
+	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/redact", fixtureFilename: "fixtures/conversation.json"}
+	api := ConversationAPI{httpClient: &http}
+	if _, err := api.redact(&conversationRedactRequest{Type: "source", ConversationID: "147", SourceID: "537e564f316c33104c010020"}); err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+}
+
+func TestConversationRunAssignmentRules(t *testing.T) {
+	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/147/run_assignment_rules", fixtureFilename: "fixtures/conversation.json"}
+	api := ConversationAPI{httpClient: &http}
+	if _, err := api.runAssignmentRules("147"); err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+}
+
 type TestConversationHTTPClient struct {
 	TestHTTPClient
 	t               *testing.T
@@ -129,6 +206,15 @@ type TestConversationHTTPClient struct {
 	fixtureFilename string
 	expectedURI     string
 	lastQueryParams interface{}
+	lastBody        interface{}
+}
+
+func (t *TestConversationHTTPClient) Put(uri string, body interface{}) ([]byte, error) {
+	t.lastBody = body
+	if t.expectedURI != uri {
+		t.t.Errorf("Wrong endpoint called")
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
 }
 
 func (t *TestConversationHTTPClient) Get(uri string, queryParams interface{}) ([]byte, error) {
@@ -150,3 +236,17 @@ func (t *TestConversationHTTPClient) Post(uri string, dataObject interface{}) ([
 	}
 	return ioutil.ReadFile(t.fixtureFilename)
 }
+
+func (t *TestConversationHTTPClient) Delete(uri string, queryParams interface{}) ([]byte, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, queryParams)
+	}
+	if t.expectedURI != uri {
+		t.t.Errorf("Wrong endpoint called")
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
+}
+
+func (t *TestConversationHTTPClient) DeleteWithBody(uri string, body interface{}) ([]byte, error) {
+	return t.Delete(uri, body)
+}
@@ -0,0 +1,29 @@
+package intercom
+
+// IterateAll returns a ConversationIterator over all Conversations, fetching pages of perPage as needed.
+// A perPage of 0 uses the API default.
+func (c *ConversationService) IterateAll(perPage int64) *ConversationIterator {
+	return &ConversationIterator{pageIterator[Conversation]{fetch: func(page int64) ([]Conversation, PageParams, error) {
+		list, err := c.ListAll(PageParams{Page: page, PerPage: perPage})
+		return list.Conversations, list.Pages, err
+	}}}
+}
+
+// IterateByUser returns a ConversationIterator over all Conversations of a User, fetching pages of perPage as needed.
+// A perPage of 0 uses the API default.
+func (c *ConversationService) IterateByUser(user *User, perPage int64) *ConversationIterator {
+	return &ConversationIterator{pageIterator[Conversation]{fetch: func(page int64) ([]Conversation, PageParams, error) {
+		list, err := c.ListByUser(user, SHOW_ALL, PageParams{Page: page, PerPage: perPage})
+		return list.Conversations, list.Pages, err
+	}}}
+}
+
+// ConversationIterator iterates over Conversations across pages.
+type ConversationIterator struct {
+	pageIterator[Conversation]
+}
+
+// Conversation returns the current Conversation.
+func (it *ConversationIterator) Conversation() Conversation {
+	return it.current()
+}
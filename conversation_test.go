@@ -1,11 +1,14 @@
 package intercom
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestFindConversation(t *testing.T) {
 	conversationService := ConversationService{Repository: TestConversationAPI{t: t}}
-	convo, _ := conversationService.Find("123")
-	if convo.ID != "123" {
+	convo, _ := conversationService.Find("123", ConversationFindParams{})
+	if convo.Id != "123" {
 		t.Errorf("Did not receive conversation")
 	}
 }
@@ -13,7 +16,7 @@ func TestFindConversation(t *testing.T) {
 func TestReadConversation(t *testing.T) {
 	conversationService := ConversationService{Repository: TestConversationAPI{t: t}}
 	convo, _ := conversationService.MarkRead("123")
-	if convo.ID != "123" {
+	if convo.Id != "123" {
 		t.Errorf("Did not receive conversation")
 	}
 }
@@ -91,10 +94,111 @@ func TestAssignConversation(t *testing.T) {
 	conversationService.Assign("123", &Admin{ID: "abc123"}, &Admin{ID: "def789"})
 }
 
+func TestCreateConversation(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, request interface{}) {
+		createRequest := request.(*conversationCreateRequest)
+		if createRequest.From != (MessageAddress{Type: "contact", ID: "abc123"}) || createRequest.Body != "Hello" {
+			t.Errorf("create request was %v", createRequest)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	message, _ := conversationService.Create(&Contact{ID: "abc123", Email: "alice@example.com"}, "Hello")
+	if message.ConversationID != "123" {
+		t.Errorf("Did not receive conversation id")
+	}
+	if _, err := conversationService.Create(&Contact{}, "Hello"); err == nil {
+		t.Errorf("Expected an error for a missing contact")
+	}
+}
+
+func TestSnoozeConversation(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, reply interface{}) {
+		if reply.(*Reply).ReplyType != "snoozed" || reply.(*Reply).AdminID != "abc123" {
+			t.Errorf("part was not snoozed, was %s", reply.(*Reply).ReplyType)
+		}
+		if reply.(*Reply).SnoozedUntil != 1673609604 {
+			t.Errorf("snoozed until %d", reply.(*Reply).SnoozedUntil)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	conversationService.Snooze("123", &Admin{ID: "abc123"}, time.Unix(1673609604, 0))
+}
+
+func TestSetConversationPriority(t *testing.T) {
+	expected := "priority"
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, request interface{}) {
+		if priority := request.(conversationPriorityRequest).Priority; priority != expected {
+			t.Errorf("priority was not %s, was %s", expected, priority)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	conversationService.SetPriority("123", true)
+	expected = "not_priority"
+	conversationService.SetPriority("123", false)
+	if _, err := conversationService.SetPriority("", true); err == nil {
+		t.Errorf("Expected an error for a missing conversation")
+	}
+}
+
+func TestAttachDetachConversationContact(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, request interface{}) {
+		switch r := request.(type) {
+		case *conversationCustomerRequest:
+			if r.AdminID != "abc123" || r.Customer.IntercomUserID != "def789" {
+				t.Errorf("attach request was %v", r)
+			}
+		case conversationCustomerRequest:
+			if r.AdminID != "abc123" {
+				t.Errorf("detach request was %v", r)
+			}
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	conversationService.AttachContact("123", &Admin{ID: "abc123"}, &Contact{ID: "def789"})
+	conversationService.DetachContact("123", &Admin{ID: "abc123"}, "def789")
+	if _, err := conversationService.DetachContact("123", &Admin{ID: "abc123"}, ""); err == nil {
+		t.Errorf("Expected an error for a missing contact")
+	}
+}
+
+func TestRedactConversation(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, request interface{}) {
+		r := request.(*conversationRedactRequest)
+		if r.Type == "conversation_part" && r.ConversationPartID != "456" {
+			t.Errorf("part id not supplied")
+		}
+		if r.Type == "source" && r.SourceID != "789" {
+			t.Errorf("source id not supplied")
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	conversationService.RedactPart("123", "456")
+	conversationService.RedactSource("123", "789")
+	if _, err := conversationService.RedactPart("123", ""); err == nil || err.Error() != "Missing Conversation Part ID" {
+		t.Errorf("Expected an error for a missing part, got %v", err)
+	}
+	if _, err := conversationService.RedactSource("", "789"); err == nil || err.Error() != "Missing Conversation ID" {
+		t.Errorf("Expected an error for a missing conversation, got %v", err)
+	}
+}
+
+func TestRunConversationAssignmentRules(t *testing.T) {
+	conversationService := ConversationService{Repository: TestConversationAPI{t: t}}
+	convo, _ := conversationService.RunAssignmentRules("123")
+	if convo.Id != "123" {
+		t.Errorf("Did not receive conversation")
+	}
+}
+
 func TestListAllConversations(t *testing.T) {
 	conversationService := ConversationService{Repository: TestConversationAPI{t: t}}
 	list, _ := conversationService.ListAll(PageParams{})
-	if list.Conversations[0].ID != "123" {
+	if list.Conversations[0].Id != "123" {
 		t.Errorf("did not receive conversation")
 	}
 }
@@ -109,7 +213,7 @@ func TestListUserConversationsUnread(t *testing.T) {
 	conversationService := ConversationService{Repository: testAPI}
 	user := User{}
 	list, _ := conversationService.ListByUser(&user, SHOW_UNREAD, PageParams{})
-	if list.Conversations[0].ID != "123" {
+	if list.Conversations[0].Id != "123" {
 		t.Errorf("did not receive conversation")
 	}
 }
@@ -124,7 +228,7 @@ func TestListUserConversationsAll(t *testing.T) {
 	conversationService := ConversationService{Repository: testAPI}
 	user := User{}
 	list, _ := conversationService.ListByUser(&user, SHOW_ALL, PageParams{})
-	if list.Conversations[0].ID != "123" {
+	if list.Conversations[0].Id != "123" {
 		t.Errorf("did not receive conversation")
 	}
 }
@@ -139,7 +243,7 @@ func TestListAdminConversationsAll(t *testing.T) {
 	conversationService := ConversationService{Repository: testAPI}
 	admin := Admin{}
 	list, _ := conversationService.ListByAdmin(&admin, SHOW_ALL, PageParams{})
-	if list.Conversations[0].ID != "123" {
+	if list.Conversations[0].Id != "123" {
 		t.Errorf("did not receive conversation")
 	}
 }
@@ -154,7 +258,7 @@ func TestListAdminConversationsOpen(t *testing.T) {
 	conversationService := ConversationService{Repository: testAPI}
 	admin := Admin{}
 	list, _ := conversationService.ListByAdmin(&admin, SHOW_OPEN, PageParams{})
-	if list.Conversations[0].ID != "123" {
+	if list.Conversations[0].Id != "123" {
 		t.Errorf("did not receive conversation")
 	}
 }
@@ -168,20 +272,84 @@ func (t TestConversationAPI) list(params ConversationListParams) (ConversationLi
 	if t.testFunc != nil {
 		t.testFunc(t.t, params)
 	}
-	return ConversationList{Conversations: []Conversation{Conversation{ID: "123"}}, Pages: PageParams{Page: 1, PerPage: 20}}, nil
+	return ConversationList{Conversations: []Conversation{Conversation{Id: "123"}}, Pages: PageParams{Page: 1, PerPage: 20}}, nil
 }
 
-func (t TestConversationAPI) find(id string) (Conversation, error) {
-	return Conversation{ID: "123"}, nil
+func (t TestConversationAPI) find(id string, displayType string) (Conversation, error) {
+	return Conversation{Id: "123"}, nil
 }
 
 func (t TestConversationAPI) read(id string) (Conversation, error) {
-	return Conversation{ID: "123"}, nil
+	return Conversation{Id: "123"}, nil
 }
 
 func (t TestConversationAPI) reply(id string, reply *Reply) (Conversation, error) {
 	if t.testFunc != nil {
 		t.testFunc(t.t, reply)
 	}
-	return Conversation{ID: "123"}, nil
+	return Conversation{Id: "123"}, nil
+}
+
+func (t TestConversationAPI) setPriority(id string, request conversationPriorityRequest) (Conversation, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, request)
+	}
+	return Conversation{Id: id, Priority: request.Priority}, nil
+}
+
+func (t TestConversationAPI) update(conversation *Conversation) (Conversation, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, conversation)
+	}
+	return Conversation{Id: conversation.Id}, nil
+}
+
+func (t TestConversationAPI) create(request *conversationCreateRequest) (MessageResponse, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, request)
+	}
+	return MessageResponse{ConversationID: "123"}, nil
+}
+
+func (t TestConversationAPI) manage(id string, reply *Reply) (Conversation, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, reply)
+	}
+	return Conversation{Id: id}, nil
+}
+
+func (t TestConversationAPI) attach(id string, request *conversationCustomerRequest) (Conversation, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, request)
+	}
+	return Conversation{Id: id}, nil
+}
+
+func (t TestConversationAPI) detach(id, contactID string, request conversationCustomerRequest) (Conversation, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, request)
+	}
+	return Conversation{Id: id}, nil
+}
+
+func (t TestConversationAPI) redact(request *conversationRedactRequest) (Conversation, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, request)
+	}
+	return Conversation{Id: request.ConversationID}, nil
+}
+
+func (t TestConversationAPI) runAssignmentRules(id string) (Conversation, error) {
+	return Conversation{Id: id}, nil
+}
+
+func TestIterateAllConversations(t *testing.T) {
+	it := (&ConversationService{Repository: TestConversationAPI{t: t}}).IterateAll(20)
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.Conversation().Id)
+	}
+	if it.Err() != nil || len(ids) != 1 || ids[0] != "123" {
+		t.Errorf("Iterated %v, error %v", ids, it.Err())
+	}
 }
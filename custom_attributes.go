@@ -0,0 +1,166 @@
+package intercom
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
+
+// CustomAttributes hold the custom data of a User, Contact, Company or Conversation.
+// It is a plain map, so it marshals to and from JSON exactly as before, with typed accessors on top.
+//
+// When saving, a key that is absent is left untouched by the API,
+// while a key set to null (see SetNull) clears the attribute.
+type CustomAttributes map[string]interface{}
+
+// NewCustomAttributes creates CustomAttributes from a struct tagged with `intercom`.
+// See MarshalCustomAttributes.
+func NewCustomAttributes(v interface{}) (CustomAttributes, error) {
+	return MarshalCustomAttributes(v)
+}
+
+// DecodeCustomAttributes decodes CustomAttributes into a new struct of type T tagged with `intercom`.
+// See UnmarshalCustomAttributes.
+func DecodeCustomAttributes[T any](c CustomAttributes) (T, error) {
+	var v T
+	err := UnmarshalCustomAttributes(c, &v)
+	return v, err
+}
+
+// Decode populates the `intercom` tagged fields of the struct pointed to by v.
+func (c CustomAttributes) Decode(v interface{}) error {
+	return UnmarshalCustomAttributes(c, v)
+}
+
+// Encode merges the `intercom` tagged fields of a struct into the CustomAttributes.
+func (c *CustomAttributes) Encode(v interface{}) error {
+	attributes, err := MarshalCustomAttributes(v)
+	if err != nil {
+		return err
+	}
+	for key, value := range attributes {
+		c.set(key, value)
+	}
+	return nil
+}
+
+// Has reports whether the attribute is present, even if null.
+func (c CustomAttributes) Has(key string) bool {
+	_, ok := c[key]
+	return ok
+}
+
+// IsNull reports whether the attribute is present and null.
+func (c CustomAttributes) IsNull(key string) bool {
+	value, ok := c[key]
+	return ok && value == nil
+}
+
+// String returns a string attribute, and whether it was present and a string.
+func (c CustomAttributes) String(key string) (string, bool) {
+	value, ok := c[key].(string)
+	return value, ok
+}
+
+// Int64 returns an integer attribute, and whether it was present and a whole number.
+// JSON numbers are decoded as float64, which this converts back.
+func (c CustomAttributes) Int64(key string) (int64, bool) {
+	if value, ok := c[key].(int64); ok {
+		return value, true
+	}
+	value, ok := customAttributeNumber(c[key])
+	if !ok || value != math.Trunc(value) {
+		return 0, false
+	}
+	return int64(value), true
+}
+
+// Float returns a float attribute, and whether it was present and a number.
+func (c CustomAttributes) Float(key string) (float64, bool) {
+	return customAttributeNumber(c[key])
+}
+
+// Bool returns a boolean attribute, and whether it was present and a boolean.
+func (c CustomAttributes) Bool(key string) (bool, bool) {
+	value, ok := c[key].(bool)
+	return value, ok
+}
+
+// Time returns a datetime or date attribute, and whether it was present and a time.
+// Intercom stores these as seconds since Unix Epoch; RFC 3339 and YYYY-MM-DD strings are also accepted.
+func (c CustomAttributes) Time(key string) (time.Time, bool) {
+	if value, ok := c[key].(string); ok {
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	}
+	seconds, ok := c.Int64(key)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
+
+// SetString sets a string attribute.
+func (c *CustomAttributes) SetString(key, value string) {
+	c.set(key, value)
+}
+
+// SetInt64 sets an integer attribute.
+func (c *CustomAttributes) SetInt64(key string, value int64) {
+	c.set(key, value)
+}
+
+// SetFloat sets a float attribute.
+func (c *CustomAttributes) SetFloat(key string, value float64) {
+	c.set(key, value)
+}
+
+// SetBool sets a boolean attribute.
+func (c *CustomAttributes) SetBool(key string, value bool) {
+	c.set(key, value)
+}
+
+// SetTime sets a datetime attribute, as seconds since Unix Epoch.
+func (c *CustomAttributes) SetTime(key string, value time.Time) {
+	c.set(key, value.Unix())
+}
+
+// SetNull sets an attribute to null, clearing it when saved.
+func (c *CustomAttributes) SetNull(key string) {
+	c.set(key, nil)
+}
+
+// Unset removes an attribute, leaving it untouched when saved.
+func (c CustomAttributes) Unset(key string) {
+	delete(c, key)
+}
+
+func (c *CustomAttributes) set(key string, value interface{}) {
+	if *c == nil {
+		*c = CustomAttributes{}
+	}
+	(*c)[key] = value
+}
+
+func customAttributeNumber(raw interface{}) (float64, bool) {
+	switch n := raw.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
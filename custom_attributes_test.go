@@ -0,0 +1,74 @@
+package intercom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCustomAttributesGetters(t *testing.T) {
+	user := User{}
+	json.Unmarshal([]byte(`{"custom_attributes": {"plan": "pro", "seats": 12, "mrr": 99.5, "is_cool": true, "renewal_at": 1700000000, "started_on": "2023-01-02", "cleared": null}}`), &user)
+	attributes := user.CustomAttributes
+	if plan, ok := attributes.String("plan"); !ok || plan != "pro" {
+		t.Errorf("plan was %v, expected pro", plan)
+	}
+	if seats, ok := attributes.Int64("seats"); !ok || seats != 12 {
+		t.Errorf("seats was %v, expected 12", seats)
+	}
+	if _, ok := attributes.Int64("mrr"); ok {
+		t.Errorf("mrr should not be an integer")
+	}
+	if mrr, ok := attributes.Float("mrr"); !ok || mrr != 99.5 {
+		t.Errorf("mrr was %v, expected 99.5", mrr)
+	}
+	if isCool, ok := attributes.Bool("is_cool"); !ok || !isCool {
+		t.Errorf("is_cool was %v, expected true", isCool)
+	}
+	if renewal, ok := attributes.Time("renewal_at"); !ok || renewal.Unix() != 1700000000 {
+		t.Errorf("renewal_at was %v, expected 1700000000", renewal)
+	}
+	if started, ok := attributes.Time("started_on"); !ok || started.Day() != 2 {
+		t.Errorf("started_on was %v, expected 2023-01-02", started)
+	}
+	if _, ok := attributes.String("seats"); ok {
+		t.Errorf("seats should not be a string")
+	}
+	if !attributes.IsNull("cleared") || attributes.IsNull("plan") || attributes.Has("missing") {
+		t.Errorf("null and missing attributes not distinguished")
+	}
+}
+
+func TestCustomAttributesSetters(t *testing.T) {
+	company := Company{}
+	company.CustomAttributes.SetString("plan", "pro")
+	company.CustomAttributes.SetInt64("seats", 12)
+	company.CustomAttributes.SetBool("is_cool", true)
+	company.CustomAttributes.SetTime("renewal_at", time.Unix(1700000000, 0))
+	company.CustomAttributes.SetNull("churned_at")
+	company.CustomAttributes.SetFloat("mrr", 1)
+	company.CustomAttributes.Unset("mrr")
+	b, _ := json.Marshal(company.CustomAttributes)
+	expected := `{"churned_at":null,"is_cool":true,"plan":"pro","renewal_at":1700000000,"seats":12}`
+	if string(b) != expected {
+		t.Errorf("Marshalled to %s, expected %s", b, expected)
+	}
+}
+
+func TestCustomAttributesDecode(t *testing.T) {
+	attributes := CustomAttributes{"plan_tier": "pro", "seats": float64(3)}
+	account, err := DecodeCustomAttributes[testAccountAttributes](attributes)
+	if err != nil {
+		t.Fatalf("Error decoding %s", err)
+	}
+	if account.PlanTier != "pro" || account.Seats != 3 {
+		t.Errorf("Decoded %v", account)
+	}
+	var contact Contact
+	if err := contact.CustomAttributes.Encode(account); err != nil {
+		t.Fatalf("Error encoding %s", err)
+	}
+	if seats, _ := contact.CustomAttributes.Int64("seats"); seats != 3 {
+		t.Errorf("Encoded seats were %d, expected 3", seats)
+	}
+}
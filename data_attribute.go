@@ -1,27 +1,175 @@
 package intercom
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
 
 // DataAttributeService handles interactions with the API through an DataAttributeRepository.
 type DataAttributeService struct {
 	Repository DataAttributeRepository
 }
 
-// A DataAttribute represents a new dataAttribute that happens to a User.
+// DataAttributeList holds a list of DataAttributes
+type DataAttributeList struct {
+	Type           string          `json:"type,omitempty"`
+	DataAttributes []DataAttribute `json:"data,omitempty"`
+}
+
+// A DataAttribute represents a data attribute of a Contact, Company or Conversation.
+// Not all of the fields are writeable to the API, non-writeable fields are
+// stripped out from the request. Please see the API documentation for details.
 type DataAttribute struct {
+	Type     string `json:"type,omitempty"`
+	ID       int64  `json:"id,omitempty"`
 	Name     string `json:"name"`
+	FullName string `json:"full_name,omitempty"` // The name of the attribute as used in the API, e.g. "custom_attributes.plan_tier".
+	Label    string `json:"label,omitempty"`
 	Model    string `json:"model"`     // The model that the data attribute belongs to. Enum: "contact" "company" "conversation"
 	DataType string `json:"data_type"` // The type of data stored for this attribute. Enum: "string" "integer" "float" "boolean" "datetime" "date"
 
-	Description string   `json:"description,omitempty"` // The readable description you see in the UI for the attribute.
-	Options     []string `json:"options,omitempty"`     // To create list attributes. Provide a set of hashes with value as the key of the options you want to make. data_type must be string.
+	Description       string                `json:"description,omitempty"`        // The readable description you see in the UI for the attribute.
+	Options           []DataAttributeOption `json:"options,omitempty"`            // To create list attributes. data_type must be string.
+	MessengerWritable *bool                 `json:"messenger_writable,omitempty"` // Whether the attribute can be updated by the Messenger.
+
+	APIWritable bool   `json:"api_writable,omitempty"`
+	UIWritable  bool   `json:"ui_writable,omitempty"`
+	Custom      bool   `json:"custom,omitempty"`
+	Archived    bool   `json:"archived,omitempty"`
+	AdminID     string `json:"admin_id,omitempty"`
+	CreatedAt   int64  `json:"created_at,omitempty"`
+	UpdatedAt   int64  `json:"updated_at,omitempty"`
+}
+
+// A DataAttributeOption is a single value of a list DataAttribute.
+type DataAttributeOption struct {
+	Value string `json:"value"`
+}
+
+// UnmarshalJSON accepts both the object form sent to the API
+// and the plain string form returned by it.
+func (o *DataAttributeOption) UnmarshalJSON(b []byte) error {
+	var value string
+	if err := json.Unmarshal(b, &value); err == nil {
+		o.Value = value
+		return nil
+	}
+	type option DataAttributeOption
+	return json.Unmarshal(b, (*option)(o))
+}
+
+type dataAttributeListParams struct {
+	Model           string `url:"model,omitempty"`
+	IncludeArchived bool   `url:"include_archived,omitempty"`
+}
+
+var dataAttributeModels = [...]string{
+	"contact",
+	"company",
+	"conversation",
+}
+
+var dataAttributeDataTypes = [...]string{
+	"string",
+	"integer",
+	"float",
+	"boolean",
+	"datetime",
+	"date",
+}
+
+// List DataAttributes for a model ("contact", "company" or "conversation").
+// An empty model lists the DataAttributes of every model.
+func (e *DataAttributeService) List(model string, includeArchived bool) (DataAttributeList, error) {
+	if model != "" {
+		if err := validateDataAttributeModel(model); err != nil {
+			return DataAttributeList{}, err
+		}
+	}
+	return e.Repository.list(dataAttributeListParams{Model: model, IncludeArchived: includeArchived})
 }
 
 // Create a new DataAttribute
-func (e *DataAttributeService) Create(dataAttribute *DataAttribute) error {
+func (e *DataAttributeService) Create(dataAttribute *DataAttribute) (DataAttribute, error) {
+	if err := validateDataAttribute(dataAttribute); err != nil {
+		return DataAttribute{}, err
+	}
 	return e.Repository.create(dataAttribute)
 }
 
+// Update an existing DataAttribute.
+// Only the Description, Options, Archived and MessengerWritable fields can be changed.
+// Archived is only sent when true, so updating an archived DataAttribute keeps it archived; see Unarchive.
+func (e *DataAttributeService) Update(dataAttribute *DataAttribute) (DataAttribute, error) {
+	var archived *bool
+	if dataAttribute.Archived {
+		archived = Bool(true)
+	}
+	return e.update(dataAttribute, archived)
+}
+
+func (e *DataAttributeService) update(dataAttribute *DataAttribute, archived *bool) (DataAttribute, error) {
+	if dataAttribute.ID == 0 {
+		return DataAttribute{}, errors.New("Missing DataAttribute ID")
+	}
+	if len(dataAttribute.Options) > 0 && dataAttribute.DataType != "" && dataAttribute.DataType != "string" {
+		return DataAttribute{}, errors.New("DataAttribute Options require a string DataType")
+	}
+	return e.Repository.update(dataAttribute, archived)
+}
+
+// Archive a DataAttribute by its ID
+func (e *DataAttributeService) Archive(id int64) (DataAttribute, error) {
+	return e.update(&DataAttribute{ID: id}, Bool(true))
+}
+
+// Unarchive a DataAttribute by its ID
+func (e *DataAttributeService) Unarchive(id int64) (DataAttribute, error) {
+	return e.update(&DataAttribute{ID: id}, Bool(false))
+}
+
+// NewDataAttributeOptions creates DataAttributeOptions from a list of values.
+func NewDataAttributeOptions(values ...string) []DataAttributeOption {
+	options := make([]DataAttributeOption, 0, len(values))
+	for _, value := range values {
+		options = append(options, DataAttributeOption{Value: value})
+	}
+	return options
+}
+
+func validateDataAttribute(dataAttribute *DataAttribute) error {
+	if dataAttribute.Name == "" {
+		return errors.New("Missing DataAttribute Name")
+	}
+	if err := validateDataAttributeModel(dataAttribute.Model); err != nil {
+		return err
+	}
+	if !containsString(dataAttributeDataTypes[:], dataAttribute.DataType) {
+		return fmt.Errorf("Invalid DataAttribute DataType %q", dataAttribute.DataType)
+	}
+	if len(dataAttribute.Options) > 0 && dataAttribute.DataType != "string" {
+		return errors.New("DataAttribute Options require a string DataType")
+	}
+	return nil
+}
+
+func validateDataAttributeModel(model string) error {
+	if !containsString(dataAttributeModels[:], model) {
+		return fmt.Errorf("Invalid DataAttribute Model %q", model)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (e DataAttribute) String() string {
-	return fmt.Sprintf("[intercom] dataAttribute { name: %s }", e.Name)
+	return fmt.Sprintf("[intercom] dataAttribute { id: %d, name: %s, model: %s }", e.ID, e.Name, e.Model)
 }
@@ -1,10 +1,17 @@
 package intercom
 
-import "github.com/stefanoschrs/go-intercom/interfaces"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
 
 // DataAttributeRepository defines the interface for working with DataAttributes through the API.
 type DataAttributeRepository interface {
-	create(*DataAttribute) error
+	list(dataAttributeListParams) (DataAttributeList, error)
+	create(*DataAttribute) (DataAttribute, error)
+	update(dataAttribute *DataAttribute, archived *bool) (DataAttribute, error)
 }
 
 // DataAttributeAPI implements DataAttributeRepository
@@ -12,7 +19,60 @@ type DataAttributeAPI struct {
 	httpClient interfaces.HTTPClient
 }
 
-func (api DataAttributeAPI) create(dataAttribute *DataAttribute) error {
-	_, err := api.httpClient.Post("/data_attributes", dataAttribute)
-	return err
+type requestDataAttribute struct {
+	Name              string                `json:"name"`
+	Model             string                `json:"model"`
+	DataType          string                `json:"data_type"`
+	Description       string                `json:"description,omitempty"`
+	Options           []DataAttributeOption `json:"options,omitempty"`
+	MessengerWritable *bool                 `json:"messenger_writable,omitempty"`
+}
+
+type requestDataAttributeUpdate struct {
+	Archived          *bool                 `json:"archived,omitempty"`
+	Description       string                `json:"description,omitempty"`
+	Options           []DataAttributeOption `json:"options,omitempty"`
+	MessengerWritable *bool                 `json:"messenger_writable,omitempty"`
+}
+
+func (api DataAttributeAPI) list(params dataAttributeListParams) (DataAttributeList, error) {
+	dataAttributeList := DataAttributeList{}
+	data, err := api.httpClient.Get("/data_attributes", params)
+	if err != nil {
+		return dataAttributeList, err
+	}
+	err = json.Unmarshal(data, &dataAttributeList)
+	return dataAttributeList, err
+}
+
+func (api DataAttributeAPI) create(dataAttribute *DataAttribute) (DataAttribute, error) {
+	requestDataAttribute := requestDataAttribute{
+		Name:              dataAttribute.Name,
+		Model:             dataAttribute.Model,
+		DataType:          dataAttribute.DataType,
+		Description:       dataAttribute.Description,
+		Options:           dataAttribute.Options,
+		MessengerWritable: dataAttribute.MessengerWritable,
+	}
+	return unmarshalToDataAttribute(api.httpClient.Post("/data_attributes", &requestDataAttribute))
+}
+
+// update a DataAttribute, leaving it archived or not if archived is nil.
+func (api DataAttributeAPI) update(dataAttribute *DataAttribute, archived *bool) (DataAttribute, error) {
+	requestDataAttribute := requestDataAttributeUpdate{
+		Archived:          archived,
+		Description:       dataAttribute.Description,
+		Options:           dataAttribute.Options,
+		MessengerWritable: dataAttribute.MessengerWritable,
+	}
+	return unmarshalToDataAttribute(api.httpClient.Put(fmt.Sprintf("/data_attributes/%d", dataAttribute.ID), &requestDataAttribute))
+}
+
+func unmarshalToDataAttribute(data []byte, err error) (DataAttribute, error) {
+	savedDataAttribute := DataAttribute{}
+	if err != nil {
+		return savedDataAttribute, err
+	}
+	err = json.Unmarshal(data, &savedDataAttribute)
+	return savedDataAttribute, err
 }
@@ -0,0 +1,117 @@
+package intercom
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+func TestDataAttributeAPIList(t *testing.T) {
+	http := TestDataAttributeHTTPClient{t: t, fixtureFilename: "fixtures/data_attributes.json", expectedURI: "/data_attributes"}
+	api := DataAttributeAPI{httpClient: &http}
+	dataAttributeList, err := api.list(dataAttributeListParams{Model: "contact", IncludeArchived: true})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if params, ok := http.lastParams.(dataAttributeListParams); !ok || params.Model != "contact" || !params.IncludeArchived {
+		t.Errorf("List params were %v, expected model contact including archived", http.lastParams)
+	}
+	dataAttributes := dataAttributeList.DataAttributes
+	if len(dataAttributes) != 2 {
+		t.Fatalf("Listed %d data attributes, expected 2", len(dataAttributes))
+	}
+	if dataAttributes[0].Custom {
+		t.Errorf("%s should not be custom", dataAttributes[0].Name)
+	}
+	if dataAttributes[1].FullName != "custom_attributes.plan_tier" {
+		t.Errorf("FullName was %s, expected custom_attributes.plan_tier", dataAttributes[1].FullName)
+	}
+	if len(dataAttributes[1].Options) != 3 || dataAttributes[1].Options[1].Value != "pro" {
+		t.Errorf("Options were %v, expected free, pro and enterprise", dataAttributes[1].Options)
+	}
+}
+
+func TestDataAttributeAPICreate(t *testing.T) {
+	http := TestDataAttributeHTTPClient{t: t, fixtureFilename: "fixtures/data_attribute.json", expectedURI: "/data_attributes"}
+	http.testFunc = func(t *testing.T, body interface{}) {
+		req := body.(*requestDataAttribute)
+		if req.Name != "plan_tier" || req.Model != "contact" || req.DataType != "string" {
+			t.Errorf("Request was %v, expected contact string plan_tier", req)
+		}
+	}
+	api := DataAttributeAPI{httpClient: &http}
+	dataAttribute, err := api.create(&DataAttribute{Name: "plan_tier", Model: "contact", DataType: "string", Options: NewDataAttributeOptions("free", "pro", "enterprise")})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if dataAttribute.ID != 12878 {
+		t.Errorf("ID was %d, expected 12878", dataAttribute.ID)
+	}
+	if !dataAttribute.APIWritable || !dataAttribute.Custom {
+		t.Errorf("DataAttribute should be api writable and custom")
+	}
+	if len(dataAttribute.Options) != 3 || dataAttribute.Options[2].Value != "enterprise" {
+		t.Errorf("Options were %v, expected free, pro and enterprise", dataAttribute.Options)
+	}
+}
+
+func TestDataAttributeAPIUpdate(t *testing.T) {
+	http := TestDataAttributeHTTPClient{t: t, fixtureFilename: "fixtures/data_attribute.json", expectedURI: "/data_attributes/12878"}
+	http.testFunc = func(t *testing.T, body interface{}) {
+		req := body.(*requestDataAttributeUpdate)
+		if req.Archived == nil || !*req.Archived {
+			t.Errorf("Archived was not sent")
+		}
+	}
+	api := DataAttributeAPI{httpClient: &http}
+	api.update(&DataAttribute{ID: 12878}, Bool(true))
+}
+
+func TestDataAttributeAPIUpdateKeepsArchived(t *testing.T) {
+	http := TestDataAttributeHTTPClient{t: t, fixtureFilename: "fixtures/data_attribute.json", expectedURI: "/data_attributes/12878"}
+	http.testFunc = func(t *testing.T, body interface{}) {
+		data, _ := json.Marshal(body)
+		if string(data) != `{"description":"Seats purchased"}` {
+			t.Errorf("Request body was %s", data)
+		}
+	}
+	api := DataAttributeAPI{httpClient: &http}
+	api.update(&DataAttribute{ID: 12878, Description: "Seats purchased"}, nil)
+}
+
+type TestDataAttributeHTTPClient struct {
+	TestHTTPClient
+	t               *testing.T
+	testFunc        func(t *testing.T, body interface{})
+	fixtureFilename string
+	expectedURI     string
+	lastParams      interface{}
+}
+
+func (t *TestDataAttributeHTTPClient) Get(uri string, queryParams interface{}) ([]byte, error) {
+	if t.expectedURI != uri {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	t.lastParams = queryParams
+	return ioutil.ReadFile(t.fixtureFilename)
+}
+
+func (t *TestDataAttributeHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
+	if t.expectedURI != uri {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	if t.testFunc != nil {
+		t.testFunc(t.t, body)
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
+}
+
+func (t *TestDataAttributeHTTPClient) Put(uri string, body interface{}) ([]byte, error) {
+	if t.expectedURI != uri {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	if t.testFunc != nil {
+		t.testFunc(t.t, body)
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
+}
@@ -0,0 +1,270 @@
+package intercom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DataAttributeSchema declares the custom DataAttributes of a model.
+// It is built from a Go struct whose fields are tagged with `intercom`:
+//
+//	type Account struct {
+//		PlanTier string    `intercom:"plan_tier,string,desc=The plan tier,options=free|pro"`
+//		Seats    int64     `intercom:"seats,desc=Number of seats"`
+//		Renewal  time.Time `intercom:"renewal_at,datetime,omitempty"`
+//		Internal string    `intercom:"-"`
+//	}
+//
+// The first tag value is the attribute name (defaulting to the field name),
+// followed by an optional data type (inferred from the field type when omitted)
+// and the options desc=, options= (separated by |), messenger_writable and omitempty.
+// Descriptions cannot contain commas.
+type DataAttributeSchema struct {
+	Model          string
+	DataAttributes []DataAttribute
+}
+
+type schemaField struct {
+	index     []int
+	name      string
+	dataType  string
+	omitEmpty bool
+	attribute DataAttribute
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// NewDataAttributeSchema builds a DataAttributeSchema for a model ("contact", "company" or "conversation")
+// from the `intercom` tags of a struct (or pointer to struct).
+func NewDataAttributeSchema(model string, v interface{}) (DataAttributeSchema, error) {
+	schema := DataAttributeSchema{Model: model}
+	if err := validateDataAttributeModel(model); err != nil {
+		return schema, err
+	}
+	fields, err := schemaFields(reflect.TypeOf(v))
+	if err != nil {
+		return schema, err
+	}
+	for _, field := range fields {
+		attribute := field.attribute
+		attribute.Model = model
+		schema.DataAttributes = append(schema.DataAttributes, attribute)
+	}
+	return schema, nil
+}
+
+// MarshalCustomAttributes converts a struct tagged with `intercom` into CustomAttributes
+// suitable for a User, Contact, Company or Conversation.
+// Nil pointers are sent as explicit nulls, clearing the attribute,
+// and fields tagged omitempty are left out when they hold a zero value.
+func MarshalCustomAttributes(v interface{}) (CustomAttributes, error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	fields, err := schemaFields(value.Type())
+	if err != nil {
+		return nil, err
+	}
+	attributes := CustomAttributes{}
+	for _, field := range fields {
+		fieldValue := value.FieldByIndex(field.index)
+		if field.omitEmpty && fieldValue.IsZero() {
+			continue
+		}
+		attributes[field.name] = marshalCustomAttribute(fieldValue)
+	}
+	return attributes, nil
+}
+
+// UnmarshalCustomAttributes populates the `intercom` tagged fields of the struct pointed to by v
+// from CustomAttributes, as read from a User, Contact, Company or Conversation.
+// Attributes which are missing or null leave the field untouched.
+func UnmarshalCustomAttributes(attributes CustomAttributes, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("UnmarshalCustomAttributes requires a non-nil pointer to a struct")
+	}
+	value = value.Elem()
+	fields, err := schemaFields(value.Type())
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		raw, ok := attributes[field.name]
+		if !ok || raw == nil {
+			continue
+		}
+		if err := unmarshalCustomAttribute(raw, value.FieldByIndex(field.index)); err != nil {
+			return fmt.Errorf("custom attribute %s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+func schemaFields(t reflect.Type) ([]schemaField, error) {
+	if t == nil {
+		return nil, errors.New("Missing schema struct")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema must be a struct, got %s", t)
+	}
+	fields := []schemaField{}
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag, tagged := structField.Tag.Lookup("intercom")
+		if tag == "-" || structField.PkgPath != "" {
+			continue
+		}
+		if structField.Anonymous && !tagged && structField.Type.Kind() == reflect.Struct && structField.Type != timeType {
+			embedded, err := schemaFields(structField.Type)
+			if err != nil {
+				return nil, err
+			}
+			for _, field := range embedded {
+				field.index = append([]int{i}, field.index...)
+				fields = append(fields, field)
+			}
+			continue
+		}
+		field, err := parseSchemaField(structField, tag)
+		if err != nil {
+			return nil, err
+		}
+		field.index = []int{i}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func parseSchemaField(structField reflect.StructField, tag string) (schemaField, error) {
+	parts := strings.Split(tag, ",")
+	field := schemaField{name: strings.TrimSpace(parts[0])}
+	if field.name == "" {
+		field.name = structField.Name
+	}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		key, value, _ := strings.Cut(part, "=")
+		switch {
+		case containsString(dataAttributeDataTypes[:], part):
+			field.dataType = part
+		case key == "desc":
+			field.attribute.Description = value
+		case key == "options":
+			field.attribute.Options = NewDataAttributeOptions(strings.Split(value, "|")...)
+		case part == "messenger_writable":
+			field.attribute.MessengerWritable = Bool(true)
+		case part == "omitempty":
+			field.omitEmpty = true
+		case part == "":
+		default:
+			return field, fmt.Errorf("field %s: unknown intercom tag option %q", structField.Name, part)
+		}
+	}
+	if field.dataType == "" {
+		dataType, err := inferDataType(structField.Type)
+		if err != nil {
+			return field, fmt.Errorf("field %s: %w", structField.Name, err)
+		}
+		field.dataType = dataType
+	}
+	if len(field.attribute.Options) > 0 && field.dataType != "string" {
+		return field, fmt.Errorf("field %s: options require a string data type", structField.Name)
+	}
+	field.attribute.Name = field.name
+	field.attribute.DataType = field.dataType
+	return field, nil
+}
+
+func inferDataType(t reflect.Type) (string, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return "datetime", nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer", nil
+	case reflect.Float32, reflect.Float64:
+		return "float", nil
+	}
+	return "", fmt.Errorf("cannot infer data type of %s", t)
+}
+
+func marshalCustomAttribute(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Unix()
+	}
+	return v.Interface()
+}
+
+func unmarshalCustomAttribute(raw interface{}, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		target := reflect.New(v.Type().Elem())
+		if err := unmarshalCustomAttribute(raw, target.Elem()); err != nil {
+			return err
+		}
+		v.Set(target)
+		return nil
+	}
+	if v.Type() == timeType {
+		seconds, ok := customAttributeNumber(raw)
+		if !ok {
+			return fmt.Errorf("cannot use %T as a datetime", raw)
+		}
+		v.Set(reflect.ValueOf(time.Unix(int64(seconds), 0)))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("cannot use %T as a string", raw)
+		}
+		v.SetString(s)
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return fmt.Errorf("cannot use %T as a boolean", raw)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := customAttributeNumber(raw)
+		if !ok || n != math.Trunc(n) || v.OverflowInt(int64(n)) {
+			return fmt.Errorf("cannot use %v as an integer", raw)
+		}
+		v.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := customAttributeNumber(raw)
+		if !ok || n < 0 || n != math.Trunc(n) || v.OverflowUint(uint64(n)) {
+			return fmt.Errorf("cannot use %v as an unsigned integer", raw)
+		}
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		n, ok := customAttributeNumber(raw)
+		if !ok {
+			return fmt.Errorf("cannot use %T as a float", raw)
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
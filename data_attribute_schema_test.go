@@ -0,0 +1,102 @@
+package intercom
+
+import (
+	"testing"
+	"time"
+)
+
+type testAccountAttributes struct {
+	PlanTier  string     `intercom:"plan_tier,string,desc=The plan tier,options=free|pro|enterprise"`
+	Seats     int64      `intercom:"seats,desc=Number of seats"`
+	Churned   bool       `intercom:"churned"`
+	MRR       float64    `intercom:"mrr,omitempty"`
+	RenewalAt time.Time  `intercom:"renewal_at,omitempty"`
+	TrialEnd  *time.Time `intercom:"trial_end"`
+	Internal  string     `intercom:"-"`
+}
+
+func TestNewDataAttributeSchema(t *testing.T) {
+	schema, err := NewDataAttributeSchema("company", testAccountAttributes{})
+	if err != nil {
+		t.Fatalf("Error building schema %s", err)
+	}
+	expected := []struct{ name, dataType string }{
+		{"plan_tier", "string"},
+		{"seats", "integer"},
+		{"churned", "boolean"},
+		{"mrr", "float"},
+		{"renewal_at", "datetime"},
+		{"trial_end", "datetime"},
+	}
+	if len(schema.DataAttributes) != len(expected) {
+		t.Fatalf("Schema had %d attributes, expected %d", len(schema.DataAttributes), len(expected))
+	}
+	for i, e := range expected {
+		dataAttribute := schema.DataAttributes[i]
+		if dataAttribute.Name != e.name || dataAttribute.DataType != e.dataType || dataAttribute.Model != "company" {
+			t.Errorf("Attribute was %s %s %s, expected company %s %s", dataAttribute.Model, dataAttribute.Name, dataAttribute.DataType, e.name, e.dataType)
+		}
+	}
+	if schema.DataAttributes[0].Description != "The plan tier" || len(schema.DataAttributes[0].Options) != 3 {
+		t.Errorf("plan_tier description or options not parsed: %v", schema.DataAttributes[0])
+	}
+}
+
+func TestNewDataAttributeSchemaInvalid(t *testing.T) {
+	if _, err := NewDataAttributeSchema("user", testAccountAttributes{}); err == nil {
+		t.Errorf("Invalid model should fail")
+	}
+	if _, err := NewDataAttributeSchema("contact", struct {
+		Tags []string `intercom:"tags"`
+	}{}); err == nil {
+		t.Errorf("Uninferrable type should fail")
+	}
+	if _, err := NewDataAttributeSchema("contact", struct {
+		Seats int `intercom:"seats,options=1|2"`
+	}{}); err == nil {
+		t.Errorf("Options on an integer should fail")
+	}
+}
+
+func TestMarshalCustomAttributes(t *testing.T) {
+	attributes, err := MarshalCustomAttributes(&testAccountAttributes{PlanTier: "pro", Seats: 12, Internal: "secret"})
+	if err != nil {
+		t.Fatalf("Error marshalling %s", err)
+	}
+	if attributes["plan_tier"] != "pro" || attributes["seats"] != int64(12) || attributes["churned"] != false {
+		t.Errorf("Attributes were %v", attributes)
+	}
+	if _, ok := attributes["mrr"]; ok {
+		t.Errorf("Empty mrr should be omitted")
+	}
+	if v, ok := attributes["trial_end"]; !ok || v != nil {
+		t.Errorf("Nil trial_end should be sent as null")
+	}
+	if _, ok := attributes["Internal"]; ok {
+		t.Errorf("Ignored field was marshalled")
+	}
+}
+
+func TestUnmarshalCustomAttributes(t *testing.T) {
+	attributes := map[string]interface{}{
+		"plan_tier":  "enterprise",
+		"seats":      float64(40),
+		"churned":    true,
+		"renewal_at": float64(1700000000),
+		"trial_end":  float64(1690000000),
+		"mrr":        nil,
+	}
+	account := testAccountAttributes{MRR: 9.5}
+	if err := UnmarshalCustomAttributes(attributes, &account); err != nil {
+		t.Fatalf("Error unmarshalling %s", err)
+	}
+	if account.PlanTier != "enterprise" || account.Seats != 40 || !account.Churned || account.MRR != 9.5 {
+		t.Errorf("Account was %v", account)
+	}
+	if account.RenewalAt.Unix() != 1700000000 || account.TrialEnd == nil || account.TrialEnd.Unix() != 1690000000 {
+		t.Errorf("Datetimes were %v and %v", account.RenewalAt, account.TrialEnd)
+	}
+	if err := UnmarshalCustomAttributes(map[string]interface{}{"seats": 1.5}, &account); err == nil {
+		t.Errorf("Fractional seats should fail")
+	}
+}
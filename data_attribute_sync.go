@@ -0,0 +1,143 @@
+package intercom
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DataAttributeSyncReport describes the differences between a DataAttributeSchema
+// and the DataAttributes of a workspace, and which of them were applied.
+type DataAttributeSyncReport struct {
+	Model      string
+	DryRun     bool
+	Created    []DataAttributeChange
+	Updated    []DataAttributeChange
+	Conflicts  []DataAttributeChange
+	Unchanged  []DataAttribute
+	Undeclared []DataAttribute // Custom DataAttributes in the workspace which the schema does not declare. They are never archived.
+}
+
+// A DataAttributeChange is a single difference found by a schema sync.
+type DataAttributeChange struct {
+	Declared DataAttribute
+	Existing *DataAttribute
+	Reason   string
+	Applied  bool
+}
+
+// HasChanges reports whether the sync found anything to create or update.
+func (r DataAttributeSyncReport) HasChanges() bool {
+	return len(r.Created) > 0 || len(r.Updated) > 0
+}
+
+// Sync diffs a DataAttributeSchema against the workspace, creating declared DataAttributes
+// which are missing and updating those whose description, options or messenger writability drifted.
+// Archived DataAttributes which are declared are unarchived.
+// Conflicts, such as a changed data type or a clash with a standard attribute, are reported and never applied.
+// With dryRun set nothing is written and the report describes what would be done.
+func (e *DataAttributeService) Sync(schema DataAttributeSchema, dryRun bool) (DataAttributeSyncReport, error) {
+	report := DataAttributeSyncReport{Model: schema.Model, DryRun: dryRun}
+	existingList, err := e.List(schema.Model, true)
+	if err != nil {
+		return report, err
+	}
+	existing := map[string]DataAttribute{}
+	for _, dataAttribute := range existingList.DataAttributes {
+		existing[dataAttribute.Name] = dataAttribute
+	}
+
+	declared := map[string]bool{}
+	for _, dataAttribute := range schema.DataAttributes {
+		declared[dataAttribute.Name] = true
+		current, ok := existing[dataAttribute.Name]
+		if !ok {
+			report.Created = append(report.Created, DataAttributeChange{Declared: dataAttribute, Reason: "missing"})
+			continue
+		}
+		if reason := dataAttributeConflict(dataAttribute, current); reason != "" {
+			report.Conflicts = append(report.Conflicts, DataAttributeChange{Declared: dataAttribute, Existing: &current, Reason: reason})
+			continue
+		}
+		if reason := dataAttributeDrift(dataAttribute, current); reason != "" {
+			report.Updated = append(report.Updated, DataAttributeChange{Declared: dataAttribute, Existing: &current, Reason: reason})
+			continue
+		}
+		report.Unchanged = append(report.Unchanged, current)
+	}
+	for _, dataAttribute := range existingList.DataAttributes {
+		if dataAttribute.Custom && !dataAttribute.Archived && !declared[dataAttribute.Name] {
+			report.Undeclared = append(report.Undeclared, dataAttribute)
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+	for i := range report.Created {
+		change := &report.Created[i]
+		if _, err := e.Create(&change.Declared); err != nil {
+			return report, fmt.Errorf("creating data attribute %s: %w", change.Declared.Name, err)
+		}
+		change.Applied = true
+	}
+	for i := range report.Updated {
+		change := &report.Updated[i]
+		update := change.Declared
+		update.ID = change.Existing.ID
+		update.Archived = false
+		var archived *bool
+		if change.Existing.Archived {
+			archived = Bool(false)
+		}
+		if _, err := e.update(&update, archived); err != nil {
+			return report, fmt.Errorf("updating data attribute %s: %w", change.Declared.Name, err)
+		}
+		change.Applied = true
+	}
+	return report, nil
+}
+
+func dataAttributeConflict(declared, existing DataAttribute) string {
+	if !existing.Custom {
+		return "clashes with a standard attribute"
+	}
+	if declared.DataType != existing.DataType {
+		return fmt.Sprintf("data type is %s, declared %s", existing.DataType, declared.DataType)
+	}
+	return ""
+}
+
+func dataAttributeDrift(declared, existing DataAttribute) string {
+	switch {
+	case existing.Archived:
+		return "archived"
+	case declared.Description != "" && declared.Description != existing.Description:
+		return "description changed"
+	case len(declared.Options) > 0 && !sameDataAttributeOptions(declared.Options, existing.Options):
+		return "options changed"
+	case declared.MessengerWritable != nil && (existing.MessengerWritable == nil || *declared.MessengerWritable != *existing.MessengerWritable):
+		return "messenger writable changed"
+	}
+	return ""
+}
+
+func sameDataAttributeOptions(a, b []DataAttributeOption) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	values := func(options []DataAttributeOption) []string {
+		v := make([]string, 0, len(options))
+		for _, option := range options {
+			v = append(v, option.Value)
+		}
+		sort.Strings(v)
+		return v
+	}
+	av, bv := values(a), values(b)
+	for i := range av {
+		if av[i] != bv[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package intercom
+
+import "testing"
+
+func TestDataAttributeSync(t *testing.T) {
+	api := &TestDataAttributeSyncAPI{existing: []DataAttribute{
+		{ID: 1, Name: "name", Model: "company", DataType: "string"},
+		{ID: 2, Name: "plan_tier", Model: "company", DataType: "string", Custom: true, Options: NewDataAttributeOptions("free", "pro")},
+		{ID: 3, Name: "seats", Model: "company", DataType: "string", Custom: true},
+		{ID: 4, Name: "churned", Model: "company", DataType: "boolean", Custom: true, Archived: true},
+		{ID: 5, Name: "legacy", Model: "company", DataType: "string", Custom: true},
+	}}
+	schema, _ := NewDataAttributeSchema("company", struct {
+		Name     string  `intercom:"name"`
+		PlanTier string  `intercom:"plan_tier,options=free|pro|enterprise"`
+		Seats    int64   `intercom:"seats"`
+		Churned  bool    `intercom:"churned"`
+		MRR      float64 `intercom:"mrr"`
+	}{})
+	dataAttributeService := DataAttributeService{Repository: api}
+
+	report, err := dataAttributeService.Sync(schema, true)
+	if err != nil {
+		t.Fatalf("Error syncing %s", err)
+	}
+	if len(report.Created) != 1 || report.Created[0].Declared.Name != "mrr" {
+		t.Errorf("Created were %v, expected mrr", report.Created)
+	}
+	if len(report.Updated) != 2 || report.Updated[0].Declared.Name != "plan_tier" || report.Updated[1].Declared.Name != "churned" {
+		t.Errorf("Updated were %v, expected plan_tier and churned", report.Updated)
+	}
+	if len(report.Conflicts) != 2 || report.Conflicts[0].Declared.Name != "name" || report.Conflicts[1].Declared.Name != "seats" {
+		t.Errorf("Conflicts were %v, expected name and seats", report.Conflicts)
+	}
+	if len(report.Undeclared) != 1 || report.Undeclared[0].Name != "legacy" {
+		t.Errorf("Undeclared were %v, expected legacy", report.Undeclared)
+	}
+	if len(api.created) != 0 || len(api.updated) != 0 {
+		t.Errorf("Dry run should not write")
+	}
+
+	report, err = dataAttributeService.Sync(schema, false)
+	if err != nil {
+		t.Fatalf("Error syncing %s", err)
+	}
+	if len(api.created) != 1 || len(api.updated) != 2 {
+		t.Errorf("Created %d and updated %d, expected 1 and 2", len(api.created), len(api.updated))
+	}
+	if api.updated[1].ID != 4 || api.archived[1] == nil || *api.archived[1] {
+		t.Errorf("churned should be unarchived, was %v", api.updated[1])
+	}
+	if api.archived[0] != nil {
+		t.Errorf("Unarchived data attributes should not send archived")
+	}
+	if !report.Created[0].Applied || !report.Updated[0].Applied {
+		t.Errorf("Changes not marked as applied")
+	}
+}
+
+type TestDataAttributeSyncAPI struct {
+	existing []DataAttribute
+	created  []DataAttribute
+	updated  []DataAttribute
+	archived []*bool
+}
+
+func (t *TestDataAttributeSyncAPI) list(params dataAttributeListParams) (DataAttributeList, error) {
+	return DataAttributeList{DataAttributes: t.existing}, nil
+}
+
+func (t *TestDataAttributeSyncAPI) create(dataAttribute *DataAttribute) (DataAttribute, error) {
+	t.created = append(t.created, *dataAttribute)
+	return *dataAttribute, nil
+}
+
+func (t *TestDataAttributeSyncAPI) update(dataAttribute *DataAttribute, archived *bool) (DataAttribute, error) {
+	t.updated = append(t.updated, *dataAttribute)
+	t.archived = append(t.archived, archived)
+	return *dataAttribute, nil
+}
@@ -0,0 +1,82 @@
+package intercom
+
+import "testing"
+
+func TestDataAttributeList(t *testing.T) {
+	dataAttributeList, _ := (&DataAttributeService{Repository: TestDataAttributeAPI{t: t}}).List("company", false)
+	if dataAttributeList.DataAttributes[0].Model != "company" {
+		t.Errorf("DataAttributes not listed for company")
+	}
+}
+
+func TestDataAttributeListInvalidModel(t *testing.T) {
+	_, err := (&DataAttributeService{Repository: TestDataAttributeAPI{t: t}}).List("visitor", false)
+	if err == nil {
+		t.Errorf("Invalid model should not be listed")
+	}
+}
+
+func TestDataAttributeCreate(t *testing.T) {
+	dataAttribute, err := (&DataAttributeService{Repository: TestDataAttributeAPI{t: t}}).Create(&DataAttribute{Name: "seats", Model: "company", DataType: "integer"})
+	if err != nil {
+		t.Fatalf("Error creating DataAttribute %s", err)
+	}
+	if dataAttribute.FullName != "custom_attributes.seats" {
+		t.Errorf("FullName was %s, expected custom_attributes.seats", dataAttribute.FullName)
+	}
+}
+
+func TestDataAttributeCreateInvalid(t *testing.T) {
+	dataAttributeService := DataAttributeService{Repository: TestDataAttributeAPI{t: t}}
+	invalid := []DataAttribute{
+		{Model: "company", DataType: "integer"},
+		{Name: "seats", Model: "user", DataType: "integer"},
+		{Name: "seats", Model: "company", DataType: "number"},
+		{Name: "seats", Model: "company", DataType: "integer", Options: NewDataAttributeOptions("1", "2")},
+	}
+	for _, dataAttribute := range invalid {
+		if _, err := dataAttributeService.Create(&dataAttribute); err == nil {
+			t.Errorf("%v should not be valid", dataAttribute)
+		}
+	}
+}
+
+func TestDataAttributeArchive(t *testing.T) {
+	dataAttribute, _ := (&DataAttributeService{Repository: TestDataAttributeAPI{t: t}}).Archive(12878)
+	if !dataAttribute.Archived {
+		t.Errorf("DataAttribute not archived")
+	}
+}
+
+func TestDataAttributeUnarchive(t *testing.T) {
+	dataAttribute, _ := (&DataAttributeService{Repository: TestDataAttributeAPI{t: t}}).Unarchive(12878)
+	if dataAttribute.ID != 12878 || dataAttribute.Archived {
+		t.Errorf("DataAttribute not unarchived")
+	}
+}
+
+func TestDataAttributeUpdateMissingID(t *testing.T) {
+	_, err := (&DataAttributeService{Repository: TestDataAttributeAPI{t: t}}).Update(&DataAttribute{Description: "Seats"})
+	if err == nil {
+		t.Errorf("DataAttribute without ID should not be updated")
+	}
+}
+
+type TestDataAttributeAPI struct {
+	t *testing.T
+}
+
+func (t TestDataAttributeAPI) list(params dataAttributeListParams) (DataAttributeList, error) {
+	return DataAttributeList{DataAttributes: []DataAttribute{DataAttribute{ID: 1, Name: "seats", Model: params.Model}}}, nil
+}
+
+func (t TestDataAttributeAPI) create(dataAttribute *DataAttribute) (DataAttribute, error) {
+	return DataAttribute{ID: 1, Name: dataAttribute.Name, FullName: "custom_attributes." + dataAttribute.Name, Model: dataAttribute.Model, DataType: dataAttribute.DataType}, nil
+}
+
+func (t TestDataAttributeAPI) update(dataAttribute *DataAttribute, archived *bool) (DataAttribute, error) {
+	if archived != nil {
+		dataAttribute.Archived = *archived
+	}
+	return *dataAttribute, nil
+}
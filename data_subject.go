@@ -0,0 +1,224 @@
+package intercom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// DataSubjectQuery identifies the Contacts of a data subject: every Contact with the Email or the ExternalID.
+type DataSubjectQuery struct {
+	Email      string `json:"email,omitempty"`
+	ExternalID string `json:"external_id,omitempty"`
+}
+
+// A DataSubjectArchive holds everything associated with the Contacts of a data subject.
+type DataSubjectArchive struct {
+	Query      DataSubjectQuery     `json:"query"`
+	ExportedAt int64                `json:"exported_at"`
+	Contacts   []DataSubjectContact `json:"contacts"`
+}
+
+// A DataSubjectContact is a Contact of a data subject, and the data associated with it.
+type DataSubjectContact struct {
+	Contact       SearchContact  `json:"contact"`
+	Companies     []Company      `json:"companies"`
+	Conversations []Conversation `json:"conversations"` // With their parts.
+	Events        []Event        `json:"events"`
+	Notes         []Note         `json:"notes"`
+	Tags          []Tag          `json:"tags"`
+}
+
+// A DataSubjectAuditEntry records a step of a data subject request.
+type DataSubjectAuditEntry struct {
+	Time       int64            `json:"time"`
+	Action     string           `json:"action"` // "search", "export", "archive" or "delete"
+	Query      DataSubjectQuery `json:"query"`
+	ContactID  string           `json:"contact_id,omitempty"`
+	DeletionID string           `json:"deletion_id,omitempty"` // The ID of the UserDeleteRequest.
+	Detail     string           `json:"detail,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// A DataSubjectProcessor handles data subject requests, such as under the GDPR: it exports everything associated
+// with the Contacts of a data subject, then permanently deletes them, recording each step to an audit trail.
+type DataSubjectProcessor struct {
+	Client *Client
+	Audit  io.Writer // Receives a JSON DataSubjectAuditEntry per line. Steps are not recorded if nil.
+	now    func() time.Time
+}
+
+// FindContacts finds every Contact with the email or external ID of a DataSubjectQuery.
+func (p DataSubjectProcessor) FindContacts(query DataSubjectQuery) ([]SearchContact, error) {
+	if query.Email == "" && query.ExternalID == "" {
+		return nil, errors.New("Missing Data Subject Email Or External ID")
+	}
+	contacts := []SearchContact{}
+	found := map[string]bool{}
+	for _, search := range [...][2]string{{"email", query.Email}, {"external_id", query.ExternalID}} {
+		if search[1] == "" {
+			continue
+		}
+		params := ContactSearchParams{Query: map[string]string{"field": search[0], "operator": "=", "value": search[1]}}
+		for {
+			result, err := p.Client.Contacts.Search(params)
+			if err != nil {
+				return nil, p.record(DataSubjectAuditEntry{Action: "search", Query: query}, err)
+			}
+			for _, contact := range result.Data {
+				if !found[contact.Id] {
+					found[contact.Id] = true
+					contacts = append(contacts, contact)
+				}
+			}
+			if result.Pages.Next == nil || result.Pages.Next.StartingAfter == "" {
+				break
+			}
+			params.Pagination = &ContactSearchPagination{StartingAfter: result.Pages.Next.StartingAfter}
+		}
+	}
+	return contacts, p.record(DataSubjectAuditEntry{Action: "search", Query: query, Detail: fmt.Sprintf("found %d contacts", len(contacts))}, nil)
+}
+
+// Export gathers the data of every Contact of a DataSubjectQuery.
+func (p DataSubjectProcessor) Export(query DataSubjectQuery) (DataSubjectArchive, error) {
+	archive := DataSubjectArchive{Query: query, ExportedAt: p.clock().Unix(), Contacts: []DataSubjectContact{}}
+	contacts, err := p.FindContacts(query)
+	if err != nil {
+		return archive, err
+	}
+	for _, contact := range contacts {
+		exported, err := p.exportContact(contact)
+		entry := DataSubjectAuditEntry{Action: "export", Query: query, ContactID: contact.Id}
+		if err == nil {
+			entry.Detail = fmt.Sprintf("%d companies, %d conversations, %d events, %d notes, %d tags",
+				len(exported.Companies), len(exported.Conversations), len(exported.Events), len(exported.Notes), len(exported.Tags))
+		}
+		if err := p.record(entry, err); err != nil {
+			return archive, err
+		}
+		archive.Contacts = append(archive.Contacts, exported)
+	}
+	return archive, nil
+}
+
+func (p DataSubjectProcessor) exportContact(contact SearchContact) (DataSubjectContact, error) {
+	exported := DataSubjectContact{Contact: contact}
+	companies := &pageIterator[Company]{fetch: func(page int64) ([]Company, PageParams, error) {
+		list, err := p.Client.Contacts.ListCompanies(contact.Id, PageParams{Page: page})
+		return list.Companies, list.Pages, err
+	}}
+	exported.Companies = []Company{}
+	for companies.Next() {
+		exported.Companies = append(exported.Companies, companies.current())
+	}
+	if companies.Err() != nil {
+		return exported, companies.Err()
+	}
+
+	tags, err := p.Client.Contacts.ListTags(contact.Id)
+	if err != nil {
+		return exported, err
+	}
+	exported.Tags = append([]Tag{}, tags.Tags...)
+
+	notes := &pageIterator[Note]{fetch: func(page int64) ([]Note, PageParams, error) {
+		list, err := p.Client.Contacts.ListNotes(contact.Id, PageParams{Page: page})
+		return list.Notes, list.Pages, err
+	}}
+	exported.Notes = []Note{}
+	for notes.Next() {
+		exported.Notes = append(exported.Notes, notes.current())
+	}
+	if notes.Err() != nil {
+		return exported, notes.Err()
+	}
+
+	conversations := p.Client.Conversations.IterateByUser(&User{ID: contact.Id}, 0)
+	exported.Conversations = []Conversation{}
+	for conversations.Next() {
+		conversation, err := p.Client.Conversations.Find(conversations.Conversation().Id, ConversationFindParams{})
+		if err != nil {
+			return exported, err
+		}
+		exported.Conversations = append(exported.Conversations, conversation)
+	}
+	if conversations.Err() != nil {
+		return exported, conversations.Err()
+	}
+
+	events := p.Client.Events.Iterate(EventListParams{IntercomUserID: contact.Id})
+	exported.Events = []Event{}
+	for events.Next() {
+		exported.Events = append(exported.Events, events.Event())
+	}
+	return exported, events.Err()
+}
+
+// Delete permanently deletes the Contacts of a DataSubjectArchive, returning the UserDeleteRequests made.
+// Every Contact is tried; the errors of those which could not be deleted are returned together.
+func (p DataSubjectProcessor) Delete(archive DataSubjectArchive) ([]UserDeleteRequest, error) {
+	requests := []UserDeleteRequest{}
+	var errs []error
+	for _, contact := range archive.Contacts {
+		request, err := p.Client.Users.PermanentlyDelete(contact.Contact.Id)
+		entry := DataSubjectAuditEntry{Action: "delete", Query: archive.Query, ContactID: contact.Contact.Id, DeletionID: request.ID.String()}
+		if err == nil {
+			requests = append(requests, request)
+		}
+		if err := p.record(entry, err); err != nil {
+			errs = append(errs, fmt.Errorf("contact %s: %w", contact.Contact.Id, err))
+		}
+	}
+	return requests, errors.Join(errs...)
+}
+
+// Process exports the data of a data subject to w as JSON, then permanently deletes their Contacts.
+// Nothing is deleted unless the whole archive was written.
+func (p DataSubjectProcessor) Process(query DataSubjectQuery, w io.Writer) (DataSubjectArchive, []UserDeleteRequest, error) {
+	archive, err := p.Export(query)
+	if err != nil {
+		return archive, nil, err
+	}
+	if err := p.Archive(archive, w); err != nil {
+		return archive, nil, err
+	}
+	requests, err := p.Delete(archive)
+	return archive, requests, err
+}
+
+// Archive writes a DataSubjectArchive to w as JSON, syncing w to disk if it is a file.
+func (p DataSubjectProcessor) Archive(archive DataSubjectArchive, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(archive)
+	if syncer, ok := w.(interface{ Sync() error }); ok && err == nil {
+		err = syncer.Sync()
+	}
+	return p.record(DataSubjectAuditEntry{Action: "archive", Query: archive.Query, Detail: fmt.Sprintf("%d contacts", len(archive.Contacts))}, err)
+}
+
+// record writes an audit entry for a step and its error, returning the error, or why the entry could not be written.
+func (p DataSubjectProcessor) record(entry DataSubjectAuditEntry, err error) error {
+	if p.Audit == nil {
+		return err
+	}
+	entry.Time = p.clock().Unix()
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	line, _ := json.Marshal(entry)
+	if _, werr := p.Audit.Write(append(line, '\n')); werr != nil && err == nil {
+		return fmt.Errorf("writing audit trail: %w", werr)
+	}
+	return err
+}
+
+func (p DataSubjectProcessor) clock() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,181 @@
+package intercom
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestDataSubjectServer(t *testing.T, deleted *[]string) *httptest.Server {
+	fixtures := map[string]string{
+		"/contacts/c1/tags":  "fixtures/contact_tags.json",
+		"/contacts/c1/notes": "fixtures/contact_notes.json",
+		"/conversations/147": "fixtures/conversation.json",
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		switch path := r.URL.Path; {
+		case path == "/contacts/search":
+			query := body["query"].(map[string]interface{})
+			switch query["field"].(string) + "=" + query["value"].(string) {
+			case "email=jamie@example.io":
+				// Two pages, through the starting_after cursor.
+				if pagination, _ := body["pagination"].(map[string]interface{}); pagination["starting_after"] == "WzE3MDAwMDAwMDAsImMxIl0=" {
+					w.Write([]byte(`{"type":"list","data":[{"type":"contact","id":"c2","email":"jamie@example.io"}],"pages":{"type":"pages","page":2,"per_page":1,"total_pages":2}}`))
+				} else {
+					w.Write([]byte(`{"type":"list","data":[{"type":"contact","id":"c1","email":"jamie@example.io"}],"pages":{"type":"pages","page":1,"per_page":1,"total_pages":2,"next":{"page":2,"starting_after":"WzE3MDAwMDAwMDAsImMxIl0="}}}`))
+				}
+			case "external_id=27":
+				w.Write([]byte(`{"type":"list","data":[{"type":"contact","id":"c1","external_id":"27"}]}`))
+			default:
+				w.Write([]byte(`{"type":"list","data":[]}`))
+			}
+		case path == "/contacts/c1/companies":
+			if r.URL.Query().Get("page") == "2" {
+				w.Write([]byte(`{"type":"list","data":[{"type":"company","id":"c1b","name":"Acme"}],"pages":{"type":"pages","page":2,"per_page":1,"total_pages":2}}`))
+			} else {
+				data, _ := ioutil.ReadFile("fixtures/contact_companies.json")
+				w.Write(bytes.Replace(data, []byte(`"total_pages": 1`), []byte(`"total_pages": 2`), 1))
+			}
+		case fixtures[path] != "":
+			data, _ := ioutil.ReadFile(fixtures[path])
+			w.Write(data)
+		case strings.HasPrefix(path, "/contacts/c2/"):
+			w.Write([]byte(`{"type":"list","data":[],"pages":{"page":1,"total_pages":1}}`))
+		case path == "/conversations":
+			if r.URL.Query().Get("intercom_user_id") == "c1" {
+				data, _ := ioutil.ReadFile("fixtures/conversations.json")
+				w.Write(data)
+			} else {
+				w.Write([]byte(`{"type":"conversation.list","conversations":[],"pages":{"page":1,"total_pages":1}}`))
+			}
+		case path == "/events":
+			if r.URL.Query().Get("intercom_user_id") == "c1" {
+				w.Write([]byte(`{"type":"event.list","events":[{"id":"e1","event_name":"signed_up","intercom_user_id":"c1","created_at":1389913941}],"pages":{}}`))
+			} else {
+				w.Write([]byte(`{"type":"event.list","events":[],"pages":{}}`))
+			}
+		case path == "/user_delete_requests":
+			id := body["intercom_user_id"].(string)
+			if id == "c2" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"type":"error.list","errors":[{"code":"not_found","message":"User Not Found"}]}`))
+				return
+			}
+			*deleted = append(*deleted, id)
+			w.Write([]byte(`{"id":8542}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestDataSubjectProcessor(server *httptest.Server, audit *bytes.Buffer) DataSubjectProcessor {
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	return DataSubjectProcessor{Client: ic, Audit: audit, now: func() time.Time { return time.Unix(1700000000, 0) }}
+}
+
+func TestDataSubjectExport(t *testing.T) {
+	deleted := []string{}
+	server := newTestDataSubjectServer(t, &deleted)
+	defer server.Close()
+	audit := &bytes.Buffer{}
+	processor := newTestDataSubjectProcessor(server, audit)
+
+	archive, err := processor.Export(DataSubjectQuery{Email: "jamie@example.io", ExternalID: "27"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archive.ExportedAt != 1700000000 || len(archive.Contacts) != 2 {
+		t.Fatalf("Archive was %+v", archive)
+	}
+	jamie := archive.Contacts[0]
+	if jamie.Contact.Id != "c1" || len(jamie.Companies) != 2 || jamie.Companies[1].Name != "Acme" || len(jamie.Tags) != 1 || len(jamie.Notes) != 1 || len(jamie.Events) != 1 {
+		t.Errorf("First contact was %+v", jamie)
+	}
+	if len(jamie.Conversations) != 1 || len(jamie.Conversations[0].ConversationParts.ConversationParts) != 5 {
+		t.Errorf("Conversations were %+v", jamie.Conversations)
+	}
+	if other := archive.Contacts[1]; other.Contact.Id != "c2" || other.Companies == nil || len(other.Conversations) != 0 {
+		t.Errorf("Second contact was %+v", other)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Export deleted %v", deleted)
+	}
+
+	expected := []string{
+		`{"time":1700000000,"action":"search","query":{"email":"jamie@example.io","external_id":"27"},"detail":"found 2 contacts"}`,
+		`{"time":1700000000,"action":"export","query":{"email":"jamie@example.io","external_id":"27"},"contact_id":"c1","detail":"2 companies, 1 conversations, 1 events, 1 notes, 1 tags"}`,
+		`{"time":1700000000,"action":"export","query":{"email":"jamie@example.io","external_id":"27"},"contact_id":"c2","detail":"0 companies, 0 conversations, 0 events, 0 notes, 0 tags"}`,
+	}
+	if lines := strings.Split(strings.TrimSpace(audit.String()), "\n"); strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Audit trail was\n%s", audit)
+	}
+
+	if _, err := processor.Export(DataSubjectQuery{}); err == nil {
+		t.Errorf("Expected a query without email or external ID to fail")
+	}
+}
+
+func TestDataSubjectProcess(t *testing.T) {
+	deleted := []string{}
+	server := newTestDataSubjectServer(t, &deleted)
+	defer server.Close()
+	audit := &bytes.Buffer{}
+	processor := newTestDataSubjectProcessor(server, audit)
+
+	out := &bytes.Buffer{}
+	archive, requests, err := processor.Process(DataSubjectQuery{Email: "jamie@example.io"}, out)
+	if err == nil || !strings.Contains(err.Error(), "contact c2") {
+		t.Errorf("Expected the deletion of c2 to fail, got %v", err)
+	}
+	if len(requests) != 1 || requests[0].ID.String() != "8542" || strings.Join(deleted, ",") != "c1" {
+		t.Errorf("Delete requests were %v, deleted %v", requests, deleted)
+	}
+	written := DataSubjectArchive{}
+	if err := json.Unmarshal(out.Bytes(), &written); err != nil || len(written.Contacts) != len(archive.Contacts) {
+		t.Errorf("Archive written was %s, error %v", out, err)
+	}
+	lines := strings.Split(strings.TrimSpace(audit.String()), "\n")
+	if len(lines) != 6 || !strings.Contains(lines[4], `"action":"delete","query":{"email":"jamie@example.io"},"contact_id":"c1","deletion_id":"8542"`) ||
+		!strings.Contains(lines[5], `"contact_id":"c2"`) || !strings.Contains(lines[5], `"error":`) {
+		t.Errorf("Audit trail was\n%s", audit)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestDataSubjectProcessArchiveFailure(t *testing.T) {
+	deleted := []string{}
+	server := newTestDataSubjectServer(t, &deleted)
+	defer server.Close()
+	processor := newTestDataSubjectProcessor(server, &bytes.Buffer{})
+
+	if _, _, err := processor.Process(DataSubjectQuery{ExternalID: "27"}, failingWriter{}); err == nil || err.Error() != "disk full" {
+		t.Errorf("Expected the archive error, got %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Contacts were deleted without an archive: %v", deleted)
+	}
+
+	processor.Audit = failingWriter{}
+	if _, _, err := processor.Process(DataSubjectQuery{ExternalID: "27"}, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "writing audit trail") {
+		t.Errorf("Expected the audit error, got %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Contacts were deleted without an audit trail: %v", deleted)
+	}
+}
@@ -1,6 +1,10 @@
 package intercom
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // EventService handles interactions with the API through an EventRepository.
 type EventService struct {
@@ -9,12 +13,58 @@ type EventService struct {
 
 // An Event represents a new event that happens to a User.
 type Event struct {
-	ID        string                 `json:"id,omitempty"`
-	Email     string                 `json:"email,omitempty"`
-	UserID    string                 `json:"user_id,omitempty"`
-	EventName string                 `json:"event_name,omitempty"`
-	CreatedAt int64                  `json:"created_at,omitempty"`
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ID             string                 `json:"id,omitempty"`
+	Email          string                 `json:"email,omitempty"`
+	UserID         string                 `json:"user_id,omitempty"`
+	IntercomUserID string                 `json:"intercom_user_id,omitempty"`
+	EventName      string                 `json:"event_name,omitempty"`
+	CreatedAt      int64                  `json:"created_at,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// EventList holds a page of Events for a User, and the link to the next page
+type EventList struct {
+	Type   string     `json:"type,omitempty"`
+	Events []Event    `json:"events"`
+	Pages  EventPages `json:"pages"`
+}
+
+// EventPages holds the paging information of an EventList
+type EventPages struct {
+	Next string `json:"next,omitempty"`
+}
+
+// EventSummaryList holds the EventSummaries of a User
+type EventSummaryList struct {
+	Type           string         `json:"type,omitempty"`
+	Email          string         `json:"email,omitempty"`
+	UserID         string         `json:"user_id,omitempty"`
+	IntercomUserID string         `json:"intercom_user_id,omitempty"`
+	Events         []EventSummary `json:"events"`
+}
+
+// An EventSummary aggregates all the occurrences of an Event for a User.
+type EventSummary struct {
+	Name        string    `json:"name"`
+	Count       int64     `json:"count"`
+	First       time.Time `json:"first"`
+	Last        time.Time `json:"last"`
+	Description string    `json:"description,omitempty"`
+}
+
+// EventListParams identify the User to list Events for.
+// One of IntercomUserID, UserID or Email is required.
+type EventListParams struct {
+	IntercomUserID string `url:"intercom_user_id,omitempty"`
+	UserID         string `url:"user_id,omitempty"`
+	Email          string `url:"email,omitempty"`
+	PerPage        int64  `url:"per_page,omitempty"`
+}
+
+type eventListParams struct {
+	EventListParams
+	Type    string `url:"type"`
+	Summary bool   `url:"summary,omitempty"`
 }
 
 // Save a new Event
@@ -22,6 +72,82 @@ func (e *EventService) Save(event *Event) error {
 	return e.Repository.save(event)
 }
 
+// List the first page of Events for a User.
+func (e *EventService) List(params EventListParams) (EventList, error) {
+	if err := params.validate(); err != nil {
+		return EventList{}, err
+	}
+	return e.Repository.list(eventListParams{EventListParams: params, Type: "user"})
+}
+
+// ListNext lists the page of Events following an EventList.
+// It returns an empty EventList once there are no more pages.
+func (e *EventService) ListNext(eventList EventList) (EventList, error) {
+	if eventList.Pages.Next == "" {
+		return EventList{}, nil
+	}
+	return e.Repository.listNext(eventList.Pages.Next)
+}
+
+// Summaries lists the EventSummaries (name, count, first and last occurrence) for a User.
+func (e *EventService) Summaries(params EventListParams) (EventSummaryList, error) {
+	if err := params.validate(); err != nil {
+		return EventSummaryList{}, err
+	}
+	return e.Repository.summaries(eventListParams{EventListParams: params, Type: "user", Summary: true})
+}
+
+// Iterate returns an EventIterator over all the Events for a User, fetching pages as needed.
+func (e *EventService) Iterate(params EventListParams) *EventIterator {
+	var list EventList
+	return &EventIterator{pageIterator[Event]{fetch: func(page int64) ([]Event, PageParams, error) {
+		var err error
+		if page == 1 {
+			list, err = e.List(params)
+		} else {
+			list, err = e.ListNext(list)
+		}
+		// Pages of Events are linked rather than numbered, and may be empty: skip to one with Events, or the last.
+		for err == nil && len(list.Events) == 0 && list.Pages.Next != "" {
+			list, err = e.ListNext(list)
+		}
+		pages := PageParams{Page: page, TotalPages: page}
+		if list.Pages.Next != "" {
+			pages.TotalPages = page + 1
+		}
+		return list.Events, pages, err
+	}}}
+}
+
+// EventIterator iterates over Events across pages.
+//
+//	it := ic.Events.Iterate(intercom.EventListParams{UserID: "27"})
+//	for it.Next() {
+//		fmt.Println(it.Event())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type EventIterator struct {
+	pageIterator[Event]
+}
+
+// Event returns the current Event.
+func (it *EventIterator) Event() Event {
+	return it.current()
+}
+
+func (p EventListParams) validate() error {
+	if p.IntercomUserID == "" && p.UserID == "" && p.Email == "" {
+		return errors.New("Missing User Identifier")
+	}
+	return nil
+}
+
 func (e Event) String() string {
 	return fmt.Sprintf("[intercom] event { name: %s, user_id: %s, email: %s }", e.EventName, e.UserID, e.Email)
 }
+
+func (e EventSummary) String() string {
+	return fmt.Sprintf("[intercom] event_summary { name: %s, count: %d }", e.Name, e.Count)
+}
@@ -1,10 +1,17 @@
 package intercom
 
-import "github.com/stefanoschrs/go-intercom/interfaces"
+import (
+	"encoding/json"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
 
 // EventRepository defines the interface for working with Events through the API.
 type EventRepository interface {
 	save(*Event) error
+	list(eventListParams) (EventList, error)
+	listNext(next string) (EventList, error)
+	summaries(eventListParams) (EventSummaryList, error)
 }
 
 // EventAPI implements EventRepository
@@ -16,3 +23,34 @@ func (api EventAPI) save(event *Event) error {
 	_, err := api.httpClient.Post("/events", event)
 	return err
 }
+
+func (api EventAPI) list(params eventListParams) (EventList, error) {
+	return unmarshalToEventList(api.httpClient.Get("/events", params))
+}
+
+func (api EventAPI) listNext(next string) (EventList, error) {
+	path, params, err := parsePageURL(next)
+	if err != nil {
+		return EventList{}, err
+	}
+	return unmarshalToEventList(api.httpClient.Get(path, params))
+}
+
+func (api EventAPI) summaries(params eventListParams) (EventSummaryList, error) {
+	summaryList := EventSummaryList{}
+	data, err := api.httpClient.Get("/events", params)
+	if err != nil {
+		return summaryList, err
+	}
+	err = json.Unmarshal(data, &summaryList)
+	return summaryList, err
+}
+
+func unmarshalToEventList(data []byte, err error) (EventList, error) {
+	eventList := EventList{}
+	if err != nil {
+		return eventList, err
+	}
+	err = json.Unmarshal(data, &eventList)
+	return eventList, err
+}
@@ -1,6 +1,8 @@
 package intercom
 
 import (
+	"io/ioutil"
+	"net/url"
 	"testing"
 	"time"
 
@@ -24,14 +26,80 @@ func TestEventAPISaveFail(t *testing.T) {
 	}
 }
 
+func TestEventAPIList(t *testing.T) {
+	http := TestEventHTTPClient{t: t, expectedURI: "/events", fixtureFilename: "fixtures/events.json"}
+	api := EventAPI{httpClient: &http}
+	eventList, err := api.list(eventListParams{EventListParams: EventListParams{IntercomUserID: "54c42e7ea7a765fa7", PerPage: 2}, Type: "user"})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if params, ok := http.lastQueryParams.(eventListParams); !ok || params.Type != "user" || params.IntercomUserID != "54c42e7ea7a765fa7" {
+		t.Errorf("List params were %v", http.lastQueryParams)
+	}
+	if len(eventList.Events) != 2 || eventList.Events[1].EventName != "placed-order" {
+		t.Errorf("Events were %v", eventList.Events)
+	}
+	if eventList.Events[0].IntercomUserID != "54c42e7ea7a765fa7" {
+		t.Errorf("IntercomUserID was %s, expected 54c42e7ea7a765fa7", eventList.Events[0].IntercomUserID)
+	}
+	if eventList.Pages.Next == "" {
+		t.Errorf("Next page not parsed")
+	}
+}
+
+func TestEventAPIListNext(t *testing.T) {
+	http := TestEventHTTPClient{t: t, expectedURI: "/events", fixtureFilename: "fixtures/events_page_2.json"}
+	api := EventAPI{httpClient: &http}
+	eventList, err := api.listNext("https://api.intercom.io/events?type=user&intercom_user_id=54c42e7ea7a765fa7&per_page=2&before=1389913821")
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	params, ok := http.lastQueryParams.(pageURLParams)
+	if !ok || url.Values(params.Query).Get("before") != "1389913821" {
+		t.Errorf("Next page params were %v", http.lastQueryParams)
+	}
+	if len(eventList.Events) != 1 || eventList.Pages.Next != "" {
+		t.Errorf("Last page was %v", eventList)
+	}
+}
+
+func TestEventAPISummaries(t *testing.T) {
+	http := TestEventHTTPClient{t: t, expectedURI: "/events", fixtureFilename: "fixtures/event_summaries.json"}
+	api := EventAPI{httpClient: &http}
+	summaryList, err := api.summaries(eventListParams{EventListParams: EventListParams{UserID: "342311"}, Type: "user", Summary: true})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if params, ok := http.lastQueryParams.(eventListParams); !ok || !params.Summary {
+		t.Errorf("Summary was not requested")
+	}
+	summary := summaryList.Events[0]
+	if summary.Name != "invited-friend" || summary.Count != 2 {
+		t.Errorf("Summary was %v", summary)
+	}
+	if summary.First.Unix() != 1389913941 || summary.Last.Unix() != 1389914141 {
+		t.Errorf("First was %v and Last was %v", summary.First, summary.Last)
+	}
+}
+
 type TestEventHTTPClient struct {
 	TestHTTPClient
-	t           *testing.T
-	expectedURI string
-	shouldFail  bool
+	t               *testing.T
+	expectedURI     string
+	fixtureFilename string
+	shouldFail      bool
+	lastQueryParams interface{}
+}
+
+func (t *TestEventHTTPClient) Get(uri string, queryParams interface{}) ([]byte, error) {
+	if t.expectedURI != uri {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	t.lastQueryParams = queryParams
+	return ioutil.ReadFile(t.fixtureFilename)
 }
 
-func (t TestEventHTTPClient) Post(uri string, event interface{}) ([]byte, error) {
+func (t *TestEventHTTPClient) Post(uri string, event interface{}) ([]byte, error) {
 	if uri != "/events" {
 		t.t.Errorf("Wrong endpoint called")
 	}
@@ -0,0 +1,246 @@
+package intercom
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrEventPublisherFull is returned by Publish when the buffer is full and the Event was dropped.
+	ErrEventPublisherFull = errors.New("intercom: event publisher buffer is full")
+	// ErrEventPublisherClosed is returned by Publish once the EventPublisher is closed.
+	ErrEventPublisherClosed = errors.New("intercom: event publisher is closed")
+)
+
+// EventPublisherConfig configures an EventPublisher. Zero values take the defaults noted.
+type EventPublisherConfig struct {
+	BufferSize        int                 // Events buffered before Publish drops them. Defaults to 1000.
+	BatchSize         int                 // Events per batch. Defaults to 100.
+	FlushInterval     time.Duration       // Maximum time an Event waits for its batch to fill. Defaults to 5s.
+	Concurrency       int                 // Batches sent concurrently. Defaults to 4.
+	MaxRetries        int                 // Retries of a failed request. Defaults to 3, a negative value disables retries.
+	RetryBackoff      time.Duration       // Wait before the first retry, doubled on each retry. Defaults to 500ms.
+	RequestsPerSecond float64             // Limits the rate of API requests when set.
+	UseBulkJobs       bool                // Send each batch as a bulk event Job instead of saving Events one by one. Ignored without a JobService.
+	OnError           func(*Event, error) // Called for every Event which could not be sent.
+	sleep             func(context.Context, time.Duration) error
+}
+
+// EventPublisherMetrics counts what happened to published Events.
+type EventPublisherMetrics struct {
+	Sent      int64 // Events saved by the API, one by one.
+	Submitted int64 // Events submitted in bulk Jobs; the API processes them later, see JobService.WaitForCompletion.
+	Retried   int64 // Retried requests.
+	Failed    int64 // Events the API rejected, or which ran out of retries.
+	Dropped   int64 // Events dropped because the buffer was full or Close timed out.
+}
+
+// EventPublisher sends Events in the background, batching them and retrying failures,
+// so that publishing an Event never waits on the API.
+type EventPublisher struct {
+	events  *EventService
+	jobs    *JobService
+	config  EventPublisherConfig
+	input   chan *Event
+	batches chan []*Event
+	done    chan struct{}
+	retrier *retrier
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.RWMutex
+	closed bool
+
+	sent      atomic.Int64
+	submitted atomic.Int64
+	failed    atomic.Int64
+	dropped   atomic.Int64
+}
+
+// NewEventPublisher starts an EventPublisher saving Events through an EventService,
+// or a JobService when UseBulkJobs is set. Without a JobService, Events are saved one by one.
+func NewEventPublisher(events *EventService, jobs *JobService, config EventPublisherConfig) *EventPublisher {
+	config = config.withDefaults()
+	if jobs == nil {
+		config.UseBulkJobs = false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &EventPublisher{
+		events:  events,
+		jobs:    jobs,
+		config:  config,
+		input:   make(chan *Event, config.BufferSize),
+		batches: make(chan []*Event),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	p.retrier = newRetrier(ctx, config.MaxRetries, config.RetryBackoff, config.RequestsPerSecond, config.sleep)
+
+	var workers sync.WaitGroup
+	for i := 0; i < config.Concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for batch := range p.batches {
+				p.send(batch)
+			}
+		}()
+	}
+	go func() {
+		p.batch()
+		close(p.batches)
+		workers.Wait()
+		p.retrier.stop()
+		close(p.done)
+	}()
+	return p
+}
+
+// Publish queues an Event without blocking.
+// It returns ErrEventPublisherFull if the buffer is full, and ErrEventPublisherClosed after Close.
+func (p *EventPublisher) Publish(event *Event) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		p.dropped.Add(1)
+		return ErrEventPublisherClosed
+	}
+	select {
+	case p.input <- event:
+		return nil
+	default:
+		p.dropped.Add(1)
+		return ErrEventPublisherFull
+	}
+}
+
+// Close stops accepting Events and waits for the buffered ones to be sent.
+// If ctx is done first, pending retries are abandoned, the remaining Events are dropped and ctx.Err() is returned.
+func (p *EventPublisher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.input)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-p.done
+		return ctx.Err()
+	}
+}
+
+// Metrics returns a snapshot of the EventPublisherMetrics.
+func (p *EventPublisher) Metrics() EventPublisherMetrics {
+	return EventPublisherMetrics{
+		Sent:      p.sent.Load(),
+		Submitted: p.submitted.Load(),
+		Retried:   p.retrier.retried.Load(),
+		Failed:    p.failed.Load(),
+		Dropped:   p.dropped.Load(),
+	}
+}
+
+func (p *EventPublisher) batch() {
+	ticker := time.NewTicker(p.config.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]*Event, 0, p.config.BatchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			p.batches <- batch
+			batch = make([]*Event, 0, p.config.BatchSize)
+		}
+	}
+	for {
+		select {
+		case event, ok := <-p.input:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= p.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (p *EventPublisher) send(batch []*Event) {
+	if p.config.UseBulkJobs {
+		items := make([]*JobItem, 0, len(batch))
+		for _, event := range batch {
+			items = append(items, NewEventJobItem(event))
+		}
+		err := p.retrier.do(func() error {
+			_, err := p.jobs.NewEventJob(items...)
+			return err
+		})
+		if err == nil {
+			p.submitted.Add(int64(len(batch)))
+			return
+		}
+		p.record(batch, err)
+		return
+	}
+	for _, event := range batch {
+		event := event
+		err := p.retrier.do(func() error {
+			return p.events.Save(event)
+		})
+		p.record([]*Event{event}, err)
+	}
+}
+
+func (p *EventPublisher) record(events []*Event, err error) {
+	switch {
+	case err == nil:
+		p.sent.Add(int64(len(events)))
+		return
+	case p.ctx.Err() != nil:
+		p.dropped.Add(int64(len(events)))
+	default:
+		p.failed.Add(int64(len(events)))
+	}
+	if p.config.OnError != nil {
+		for _, event := range events {
+			p.config.OnError(event, err)
+		}
+	}
+}
+
+func (c EventPublisherConfig) withDefaults() EventPublisherConfig {
+	if c.BufferSize <= 0 {
+		c.BufferSize = 1000
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = 5 * time.Second
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = 4
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = 3
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = 500 * time.Millisecond
+	}
+	if c.sleep == nil {
+		c.sleep = sleepContext
+	}
+	return c
+}
@@ -0,0 +1,172 @@
+package intercom
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
+
+func TestEventPublisherSendsOnClose(t *testing.T) {
+	repo := &TestEventPublisherAPI{}
+	publisher := NewEventPublisher(&EventService{Repository: repo}, nil, EventPublisherConfig{BatchSize: 10, FlushInterval: time.Hour})
+	for i := 0; i < 25; i++ {
+		if err := publisher.Publish(&Event{UserID: "27", EventName: "govent"}); err != nil {
+			t.Fatalf("Publish failed %s", err)
+		}
+	}
+	if err := publisher.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed %s", err)
+	}
+	if repo.count() != 25 {
+		t.Errorf("Saved %d events, expected 25", repo.count())
+	}
+	if metrics := publisher.Metrics(); metrics.Sent != 25 || metrics.Dropped != 0 {
+		t.Errorf("Metrics were %+v", metrics)
+	}
+	if err := publisher.Publish(&Event{}); err != ErrEventPublisherClosed {
+		t.Errorf("Publishing after Close returned %v", err)
+	}
+}
+
+func TestEventPublisherFlushesOnInterval(t *testing.T) {
+	repo := &TestEventPublisherAPI{}
+	publisher := NewEventPublisher(&EventService{Repository: repo}, nil, EventPublisherConfig{BatchSize: 100, FlushInterval: 5 * time.Millisecond})
+	defer publisher.Close(context.Background())
+	publisher.Publish(&Event{UserID: "27", EventName: "govent"})
+	deadline := time.Now().Add(time.Second)
+	for repo.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if repo.count() != 1 {
+		t.Errorf("Event was not flushed")
+	}
+}
+
+func TestEventPublisherRetries(t *testing.T) {
+	repo := &TestEventPublisherAPI{failures: []error{
+		interfaces.HTTPError{StatusCode: 429, Code: "rate_limit_exceeded"},
+		interfaces.HTTPError{StatusCode: 503, Code: "service_unavailable"},
+	}}
+	config := EventPublisherConfig{Concurrency: 1, sleep: func(context.Context, time.Duration) error { return nil }}
+	publisher := NewEventPublisher(&EventService{Repository: repo}, nil, config)
+	publisher.Publish(&Event{UserID: "27", EventName: "govent"})
+	publisher.Close(context.Background())
+	if metrics := publisher.Metrics(); metrics.Sent != 1 || metrics.Retried != 2 {
+		t.Errorf("Metrics were %+v", metrics)
+	}
+}
+
+func TestEventPublisherDoesNotRetryClientErrors(t *testing.T) {
+	repo := &TestEventPublisherAPI{failures: []error{interfaces.HTTPError{StatusCode: 404, Code: "not_found"}}}
+	var failed []*Event
+	config := EventPublisherConfig{Concurrency: 1, OnError: func(e *Event, err error) { failed = append(failed, e) }}
+	publisher := NewEventPublisher(&EventService{Repository: repo}, nil, config)
+	publisher.Publish(&Event{UserID: "404", EventName: "govent"})
+	publisher.Close(context.Background())
+	if metrics := publisher.Metrics(); metrics.Failed != 1 || metrics.Retried != 0 {
+		t.Errorf("Metrics were %+v", metrics)
+	}
+	if len(failed) != 1 || failed[0].UserID != "404" {
+		t.Errorf("OnError was not called with the event")
+	}
+}
+
+func TestEventPublisherDropsWhenFull(t *testing.T) {
+	block := make(chan struct{})
+	repo := &TestEventPublisherAPI{block: block}
+	publisher := NewEventPublisher(&EventService{Repository: repo}, nil, EventPublisherConfig{BufferSize: 1, BatchSize: 1, Concurrency: 1})
+	var err error
+	for i := 0; i < 10 && err == nil; i++ {
+		err = publisher.Publish(&Event{UserID: "27", EventName: "govent"})
+	}
+	if err != ErrEventPublisherFull {
+		t.Errorf("Expected the buffer to fill, got %v", err)
+	}
+	close(block)
+	publisher.Close(context.Background())
+	if metrics := publisher.Metrics(); metrics.Dropped != 1 {
+		t.Errorf("Metrics were %+v", metrics)
+	}
+}
+
+func TestEventPublisherCloseTimeout(t *testing.T) {
+	repo := &TestEventPublisherAPI{failures: []error{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}}
+	config := EventPublisherConfig{Concurrency: 1, RetryBackoff: time.Hour}
+	publisher := NewEventPublisher(&EventService{Repository: repo}, nil, config)
+	publisher.Publish(&Event{UserID: "27", EventName: "govent"})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := publisher.Close(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Close returned %v, expected deadline exceeded", err)
+	}
+	if metrics := publisher.Metrics(); metrics.Dropped != 1 || metrics.Sent != 0 {
+		t.Errorf("Metrics were %+v", metrics)
+	}
+}
+
+func TestEventPublisherBulkJobs(t *testing.T) {
+	var items int
+	jobRepo := &TestJobRepository{t: t, f: func(job *JobRequest) {
+		if job.bulkType != "events" {
+			t.Errorf("Job was %s, expected events", job.bulkType)
+		}
+		items += len(job.Items)
+	}}
+	publisher := NewEventPublisher(nil, &JobService{Repository: jobRepo}, EventPublisherConfig{BatchSize: 2, Concurrency: 1, UseBulkJobs: true})
+	for i := 0; i < 5; i++ {
+		publisher.Publish(&Event{UserID: "27", EventName: "govent"})
+	}
+	publisher.Close(context.Background())
+	if items != 5 {
+		t.Errorf("Sent %d items in jobs, expected 5", items)
+	}
+	if metrics := publisher.Metrics(); metrics.Submitted != 5 || metrics.Sent != 0 {
+		t.Errorf("Metrics were %+v", metrics)
+	}
+}
+
+func TestEventPublisherBulkJobsWithoutJobService(t *testing.T) {
+	repo := &TestEventPublisherAPI{}
+	publisher := NewEventPublisher(&EventService{Repository: repo}, nil, EventPublisherConfig{BatchSize: 2, Concurrency: 1, UseBulkJobs: true})
+	for i := 0; i < 3; i++ {
+		publisher.Publish(&Event{UserID: "27", EventName: "govent"})
+	}
+	publisher.Close(context.Background())
+	if metrics := publisher.Metrics(); metrics.Sent != 3 || metrics.Submitted != 0 {
+		t.Errorf("Metrics were %+v", metrics)
+	}
+}
+
+type TestEventPublisherAPI struct {
+	TestEventAPI
+	mu       sync.Mutex
+	saved    []Event
+	failures []error
+	block    chan struct{}
+}
+
+func (t *TestEventPublisherAPI) save(event *Event) error {
+	if t.block != nil {
+		<-t.block
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.failures) > 0 {
+		err := t.failures[0]
+		t.failures = t.failures[1:]
+		return err
+	}
+	t.saved = append(t.saved, *event)
+	return nil
+}
+
+func (t *TestEventPublisherAPI) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.saved)
+}
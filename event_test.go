@@ -41,11 +41,66 @@ func successBody(t *testing.T, event Event) error {
 	return nil
 }
 
+func TestEventList(t *testing.T) {
+	eventService := EventService{Repository: TestEventAPI{t: t}}
+	eventList, _ := eventService.List(EventListParams{UserID: "27"})
+	if eventList.Events[0].UserID != "27" {
+		t.Errorf("Events not listed for user")
+	}
+	if _, err := eventService.List(EventListParams{}); err == nil {
+		t.Errorf("Listing without a user identifier should fail")
+	}
+}
+
+func TestEventIterate(t *testing.T) {
+	eventService := EventService{Repository: TestEventAPI{t: t}}
+	it := eventService.Iterate(EventListParams{UserID: "27"})
+	names := []string{}
+	for it.Next() {
+		names = append(names, it.Event().EventName)
+	}
+	if it.Err() != nil {
+		t.Errorf("Iteration failed %s", it.Err())
+	}
+	if len(names) != 3 || names[2] != "govent-3" {
+		t.Errorf("Iterated over %v, expected 3 events", names)
+	}
+	if it := eventService.Iterate(EventListParams{}); it.Next() || it.Err() == nil {
+		t.Errorf("Iterating without a user identifier should fail")
+	}
+}
+
+func TestEventSummaries(t *testing.T) {
+	eventService := EventService{Repository: TestEventAPI{t: t}}
+	summaryList, _ := eventService.Summaries(EventListParams{Email: "jamie@example.io"})
+	if summaryList.Events[0].Count != 3 {
+		t.Errorf("Summary count was %d, expected 3", summaryList.Events[0].Count)
+	}
+}
+
 type TestEventAPI struct {
 	t    *testing.T
 	body func(*testing.T, Event) error
 }
 
+func (t TestEventAPI) list(params eventListParams) (EventList, error) {
+	return EventList{
+		Events: []Event{{UserID: params.UserID, EventName: "govent-1"}, {UserID: params.UserID, EventName: "govent-2"}},
+		Pages:  EventPages{Next: "https://api.intercom.io/events?page=2"},
+	}, nil
+}
+
+func (t TestEventAPI) listNext(next string) (EventList, error) {
+	if next == "https://api.intercom.io/events?page=2" {
+		return EventList{Pages: EventPages{Next: "https://api.intercom.io/events?page=3"}}, nil
+	}
+	return EventList{Events: []Event{{EventName: "govent-3"}}}, nil
+}
+
+func (t TestEventAPI) summaries(params eventListParams) (EventSummaryList, error) {
+	return EventSummaryList{Email: params.Email, Events: []EventSummary{{Name: "govent", Count: 3}}}, nil
+}
+
 func (t TestEventAPI) save(event *Event) error {
 	return t.body(t.t, *event)
 }
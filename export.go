@@ -0,0 +1,98 @@
+package intercom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ExportService handles content data exports: message engagement (receipts, opens, clicks, replies and so on)
+// for content sent in a date range.
+type ExportService struct {
+	Repository ExportRepository
+}
+
+// A DataExport is a content data export job.
+type DataExport struct {
+	JobIdentifier     string `json:"job_identifier"`
+	Status            string `json:"status"` // "pending", "in_progress", "completed", "no_data", "failed" or "canceled"
+	DownloadURL       string `json:"download_url"`
+	DownloadExpiresAt string `json:"download_expires_at"`
+}
+
+var finishedExportStatuses = [...]string{"completed", "no_data", "failed", "canceled"}
+
+// Finished reports whether the DataExport will no longer change status.
+func (e DataExport) Finished() bool {
+	return containsString(finishedExportStatuses[:], e.Status)
+}
+
+// Downloadable reports whether the DataExport has data to download.
+func (e DataExport) Downloadable() bool {
+	return e.Status == "completed"
+}
+
+// CreateContentDataExport starts exporting the content data of messages sent from from until to.
+func (e *ExportService) CreateContentDataExport(from, to time.Time) (DataExport, error) {
+	if !from.Before(to) {
+		return DataExport{}, fmt.Errorf("Invalid Export Range %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	return e.Repository.create(from.Unix(), to.Unix())
+}
+
+// Find a DataExport by its job identifier.
+func (e *ExportService) Find(id string) (DataExport, error) {
+	if id == "" {
+		return DataExport{}, errors.New("Missing Export Job Identifier")
+	}
+	return e.Repository.find(id)
+}
+
+// Cancel a DataExport which has not finished.
+func (e *ExportService) Cancel(id string) (DataExport, error) {
+	if id == "" {
+		return DataExport{}, errors.New("Missing Export Job Identifier")
+	}
+	return e.Repository.cancel(id)
+}
+
+// WaitForCompletion polls a DataExport every pollInterval until it is finished, or ctx is done.
+// A pollInterval of 0 or less polls every 5 seconds.
+// A failed or canceled DataExport is returned without an error; check its Status.
+func (e *ExportService) WaitForCompletion(ctx context.Context, id string, pollInterval time.Duration) (DataExport, error) {
+	var export DataExport
+	err := pollUntil(ctx, pollInterval, func() (bool, error) {
+		var err error
+		export, err = e.Find(id)
+		return export.Finished(), err
+	})
+	return export, err
+}
+
+// Download the content data of a completed DataExport, returning a ContentDataReader over its records.
+// The ContentDataReader must be closed.
+func (e *ExportService) Download(id string) (*ContentDataReader, error) {
+	if id == "" {
+		return nil, errors.New("Missing Export Job Identifier")
+	}
+	body, err := e.Repository.download(id)
+	if err != nil {
+		return nil, err
+	}
+	return NewContentDataReader(body)
+}
+
+// ContentData downloads a completed DataExport and reads all of its records.
+func (e *ExportService) ContentData(id string) ([]ContentDataRecord, error) {
+	reader, err := e.Download(id)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	records := []ContentDataRecord{}
+	for reader.Next() {
+		records = append(records, reader.Record())
+	}
+	return records, reader.Err()
+}
@@ -0,0 +1,62 @@
+package intercom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
+
+// ExportRepository defines the interface for working with content data exports through the API.
+type ExportRepository interface {
+	create(from, to int64) (DataExport, error)
+	find(id string) (DataExport, error)
+	cancel(id string) (DataExport, error)
+	download(id string) (io.ReadCloser, error)
+}
+
+// ExportAPI implements ExportRepository
+type ExportAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+type requestDataExport struct {
+	CreatedAtAfter  int64 `json:"created_at_after"`
+	CreatedAtBefore int64 `json:"created_at_before"`
+}
+
+func (api ExportAPI) create(from, to int64) (DataExport, error) {
+	return unmarshalToDataExport(api.httpClient.Post("/export/content/data", requestDataExport{CreatedAtAfter: from, CreatedAtBefore: to}))
+}
+
+func (api ExportAPI) find(id string) (DataExport, error) {
+	return unmarshalToDataExport(api.httpClient.Get(fmt.Sprintf("/export/content/data/%s", id), nil))
+}
+
+func (api ExportAPI) cancel(id string) (DataExport, error) {
+	return unmarshalToDataExport(api.httpClient.Post(fmt.Sprintf("/export/cancel/%s", id), nil))
+}
+
+// download streams the export when the HTTPClient can, as it may be large, and buffers it otherwise.
+func (api ExportAPI) download(id string) (io.ReadCloser, error) {
+	url := fmt.Sprintf("/download/content/data/%s", id)
+	if streamer, ok := api.httpClient.(interfaces.HTTPStreamer); ok {
+		return streamer.GetStream(url, "application/octet-stream")
+	}
+	data, err := api.httpClient.Get(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func unmarshalToDataExport(data []byte, err error) (DataExport, error) {
+	export := DataExport{}
+	if err != nil {
+		return export, err
+	}
+	err = json.Unmarshal(data, &export)
+	return export, err
+}
@@ -0,0 +1,52 @@
+package intercom
+
+import (
+	"io"
+	"testing"
+)
+
+func TestExportAPICreate(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/data_export.json", expectedURI: "/export/content/data"}
+	http.f = func(body interface{}) {
+		if request := body.(requestDataExport); request.CreatedAtAfter != 1709251200 || request.CreatedAtBefore != 1711929600 {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := ExportAPI{httpClient: &http}
+	export, err := api.create(1709251200, 1711929600)
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if export.JobIdentifier != "orzzsbd7hk67xyu" || export.Status != "pending" {
+		t.Errorf("Export was %v", export)
+	}
+}
+
+func TestExportAPIFind(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/data_export.json", expectedURI: "/export/content/data/orzzsbd7hk67xyu"}
+	api := ExportAPI{httpClient: &http}
+	if export, err := api.find("orzzsbd7hk67xyu"); err != nil || export.JobIdentifier != "orzzsbd7hk67xyu" {
+		t.Errorf("Found %v, error %v", export, err)
+	}
+}
+
+func TestExportAPICancel(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/data_export.json", expectedURI: "/export/cancel/orzzsbd7hk67xyu"}
+	api := ExportAPI{httpClient: &http}
+	if _, err := api.cancel("orzzsbd7hk67xyu"); err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+}
+
+func TestExportAPIDownloadBuffered(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/content_data.csv.gz", expectedURI: "/download/content/data/orzzsbd7hk67xyu"}
+	api := ExportAPI{httpClient: &http}
+	body, err := api.download("orzzsbd7hk67xyu")
+	if err != nil {
+		t.Fatalf("Error downloading %s", err)
+	}
+	defer body.Close()
+	if data, _ := io.ReadAll(body); len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Errorf("Downloaded %d bytes which are not gzipped", len(data))
+	}
+}
@@ -0,0 +1,162 @@
+package intercom
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestExportServer serves an export which is in progress for the first polls, then completed with the fixture archive.
+func newTestExportServer(t *testing.T, polls int, status string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path := r.URL.Path; {
+		case r.Method == http.MethodPost && path == "/export/content/data":
+			w.Write([]byte(`{"job_identifier":"orzzsbd7hk67xyu","status":"pending","download_url":"","download_expires_at":""}`))
+		case r.Method == http.MethodGet && path == "/export/content/data/orzzsbd7hk67xyu":
+			if polls > 0 {
+				polls--
+				w.Write([]byte(`{"job_identifier":"orzzsbd7hk67xyu","status":"in_progress"}`))
+				return
+			}
+			w.Write([]byte(`{"job_identifier":"orzzsbd7hk67xyu","status":"` + status + `","download_url":"https://api.intercom.io/download/content/data/orzzsbd7hk67xyu","download_expires_at":"2024-04-02T10:00:00Z"}`))
+		case r.Method == http.MethodPost && path == "/export/cancel/orzzsbd7hk67xyu":
+			w.Write([]byte(`{"job_identifier":"orzzsbd7hk67xyu","status":"canceled"}`))
+		case r.Method == http.MethodGet && path == "/download/content/data/orzzsbd7hk67xyu":
+			if accept := r.Header.Get("Accept"); accept != "application/octet-stream" {
+				t.Errorf("Accept was %s", accept)
+			}
+			data, _ := ioutil.ReadFile("fixtures/content_data.csv.gz")
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(data)
+		case path == "/download/content/data/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type":"error.list","errors":[{"code":"not_found","message":"Export not found"}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestExportClient(server *httptest.Server) *Client {
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	return ic
+}
+
+func TestExportCreateRange(t *testing.T) {
+	exports := ExportService{}
+	now := time.Now()
+	if _, err := exports.CreateContentDataExport(now, now.Add(-time.Hour)); err == nil || !strings.HasPrefix(err.Error(), "Invalid Export Range") {
+		t.Errorf("Expected an invalid range, got %v", err)
+	}
+	if _, err := exports.Find(""); err == nil {
+		t.Errorf("Expected a find without job identifier to fail")
+	}
+}
+
+func TestExportWaitAndDownload(t *testing.T) {
+	server := newTestExportServer(t, 2, "completed")
+	defer server.Close()
+	ic := newTestExportClient(server)
+
+	export, err := ic.Exports.CreateContentDataExport(time.Unix(1709251200, 0), time.Unix(1711929600, 0))
+	if err != nil || export.JobIdentifier != "orzzsbd7hk67xyu" {
+		t.Fatalf("Created %v, error %v", export, err)
+	}
+	export, err = ic.Exports.WaitForCompletion(context.Background(), export.JobIdentifier, time.Millisecond)
+	if err != nil || !export.Downloadable() || export.DownloadExpiresAt == "" {
+		t.Fatalf("Waited for %v, error %v", export, err)
+	}
+
+	records, err := ic.Exports.ContentData(export.JobIdentifier)
+	if err != nil || len(records) != 3 {
+		t.Fatalf("Read %d records, error %v", len(records), err)
+	}
+	jamie := records[0]
+	if jamie.UserExternalID != "27" || jamie.ContentType != "email" || jamie.ContentTitle != "Spring release" || jamie.ReceiptID != "9001" {
+		t.Errorf("Record was %+v", jamie)
+	}
+	if !jamie.ReceivedAt.Equal(time.Date(2024, 3, 4, 10, 15, 0, 0, time.UTC)) || !jamie.FirstClick.Equal(time.Date(2024, 3, 4, 10, 21, 30, 0, time.UTC)) || !jamie.FirstReply.IsZero() {
+		t.Errorf("Record times were %v, %v, %v", jamie.ReceivedAt, jamie.FirstClick, jamie.FirstReply)
+	}
+	if sam := records[1]; sam.Name != `Sam "Lee"` || sam.ReceivedAt.Unix() != 1709547300 || sam.FirstUnsubscribe.IsZero() || sam.FirstOpen.Unix() > 0 {
+		t.Errorf("Record was %+v", sam)
+	}
+	if alex := records[2]; alex.SeriesTitle != "Welcome series" || alex.NodeID != "3" || alex.FirstCompletion.IsZero() || alex.Extra["tour_step"] != "4" {
+		t.Errorf("Record was %+v", alex)
+	}
+}
+
+func TestExportWaitFinishedStatuses(t *testing.T) {
+	for _, status := range []string{"no_data", "failed"} {
+		server := newTestExportServer(t, 1, status)
+		ic := newTestExportClient(server)
+		export, err := ic.Exports.WaitForCompletion(context.Background(), "orzzsbd7hk67xyu", time.Millisecond)
+		if err != nil || export.Status != status || export.Downloadable() {
+			t.Errorf("Waited for %v, error %v", export, err)
+		}
+		server.Close()
+	}
+}
+
+func TestExportWaitCanceledContext(t *testing.T) {
+	server := newTestExportServer(t, 1000, "completed")
+	defer server.Close()
+	ic := newTestExportClient(server)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	export, err := ic.Exports.WaitForCompletion(ctx, "orzzsbd7hk67xyu", time.Hour)
+	if !errors.Is(err, context.Canceled) || export.Status != "in_progress" {
+		t.Errorf("Waited for %v, error %v", export, err)
+	}
+	if export, err := ic.Exports.Cancel("orzzsbd7hk67xyu"); err != nil || export.Status != "canceled" || !export.Finished() {
+		t.Errorf("Canceled %v, error %v", export, err)
+	}
+}
+
+func TestExportWaitDefaultInterval(t *testing.T) {
+	server := newTestExportServer(t, 1000, "completed")
+	defer server.Close()
+	ic := newTestExportClient(server)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := ic.Exports.WaitForCompletion(ctx, "orzzsbd7hk67xyu", 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestExportDownloadError(t *testing.T) {
+	server := newTestExportServer(t, 0, "completed")
+	defer server.Close()
+	ic := newTestExportClient(server)
+	if _, err := ic.Exports.Download("missing"); err == nil || err.(IntercomError).GetStatusCode() != 404 {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestContentDataReaderPlainCSV(t *testing.T) {
+	csv := "received_at,receipt_id,first_open\n2024-03-04T10:15:00Z,1,\nyesterday,2,\n"
+	reader, err := NewContentDataReader(io.NopCloser(strings.NewReader(csv)))
+	if err != nil {
+		t.Fatalf("Error reading header %s", err)
+	}
+	defer reader.Close()
+	if !reader.Next() || reader.Record().ReceiptID != "1" || reader.Record().ReceivedAt.Day() != 4 {
+		t.Errorf("Record was %+v", reader.Record())
+	}
+	if reader.Next() || reader.Err() == nil || !strings.Contains(reader.Err().Error(), `line 3, column received_at: Invalid Time "yesterday"`) {
+		t.Errorf("Expected an invalid time, got %v", reader.Err())
+	}
+
+	empty, err := NewContentDataReader(io.NopCloser(strings.NewReader("")))
+	if err != nil || empty.Next() || empty.Err() != nil {
+		t.Errorf("Expected an empty export, error %v", err)
+	}
+}
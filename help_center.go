@@ -0,0 +1,271 @@
+package intercom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// HelpCenterService handles interactions with the API through a HelpCenterRepository.
+// It covers Help Centers and the Collections and Sections organising their Articles.
+type HelpCenterService struct {
+	Repository HelpCenterRepository
+}
+
+// HelpCenterList holds a list of HelpCenters
+type HelpCenterList struct {
+	Type        string       `json:"type,omitempty"`
+	HelpCenters []HelpCenter `json:"data"`
+}
+
+// A HelpCenter is a public site of Articles.
+type HelpCenter struct {
+	ID              string `json:"id"`
+	WorkspaceID     string `json:"workspace_id,omitempty"`
+	Identifier      string `json:"identifier,omitempty"`
+	DisplayName     string `json:"display_name,omitempty"`
+	WebsiteTurnedOn bool   `json:"website_turned_on"`
+	CreatedAt       int64  `json:"created_at,omitempty"`
+	UpdatedAt       int64  `json:"updated_at,omitempty"`
+}
+
+// CollectionList holds a page of Collections
+type CollectionList struct {
+	Type        string       `json:"type,omitempty"`
+	Pages       PageParams   `json:"pages"`
+	TotalCount  int64        `json:"total_count"`
+	Collections []Collection `json:"data"`
+}
+
+// A Collection groups Articles and Sections of a HelpCenter.
+// Not all of the fields are writeable to the API, non-writeable fields are
+// stripped out from the request. Please see the API documentation for details.
+type Collection struct {
+	ID                string            `json:"id,omitempty"`
+	WorkspaceID       string            `json:"workspace_id,omitempty"`
+	Name              string            `json:"name"`
+	Description       string            `json:"description,omitempty"`
+	ParentID          string            `json:"parent_id,omitempty"`      // The ID of the Collection holding this one, if nested.
+	HelpCenterID      string            `json:"help_center_id,omitempty"` // The HelpCenter of the Collection, the default one if empty.
+	DefaultLocale     string            `json:"default_locale,omitempty"`
+	TranslatedContent GroupTranslations `json:"translated_content,omitempty"`
+	Icon              string            `json:"icon,omitempty"`
+	Order             int64             `json:"order,omitempty"`
+	URL               string            `json:"url,omitempty"`
+	CreatedAt         int64             `json:"created_at,omitempty"`
+	UpdatedAt         int64             `json:"updated_at,omitempty"`
+}
+
+// SectionList holds a page of Sections
+type SectionList struct {
+	Type       string     `json:"type,omitempty"`
+	Pages      PageParams `json:"pages"`
+	TotalCount int64      `json:"total_count"`
+	Sections   []Section  `json:"data"`
+}
+
+// A Section groups Articles within a Collection.
+type Section struct {
+	ID                string            `json:"id,omitempty"`
+	WorkspaceID       string            `json:"workspace_id,omitempty"`
+	Name              string            `json:"name"`
+	ParentID          string            `json:"parent_id"` // The ID of the Collection holding the Section.
+	DefaultLocale     string            `json:"default_locale,omitempty"`
+	TranslatedContent GroupTranslations `json:"translated_content,omitempty"`
+	Icon              string            `json:"icon,omitempty"`
+	Order             int64             `json:"order,omitempty"`
+	URL               string            `json:"url,omitempty"`
+	CreatedAt         int64             `json:"created_at,omitempty"`
+	UpdatedAt         int64             `json:"updated_at,omitempty"`
+}
+
+// A DeletedObject confirms the deletion of an object, such as an Article, a Company or a NewsItem.
+type DeletedObject struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
+
+// UnmarshalJSON decodes a Collection, whose HelpCenterID the API sends as a number.
+func (c *Collection) UnmarshalJSON(b []byte) error {
+	type collection Collection
+	decoded := struct {
+		*collection
+		ParentID     json.Number `json:"parent_id"`
+		HelpCenterID json.Number `json:"help_center_id"`
+	}{collection: (*collection)(c)}
+	err := json.Unmarshal(b, &decoded)
+	c.ParentID, c.HelpCenterID = string(decoded.ParentID), string(decoded.HelpCenterID)
+	return err
+}
+
+// UnmarshalJSON decodes a Section, whose ParentID the API sends as a number.
+func (s *Section) UnmarshalJSON(b []byte) error {
+	type section Section
+	decoded := struct {
+		*section
+		ParentID json.Number `json:"parent_id"`
+	}{section: (*section)(s)}
+	err := json.Unmarshal(b, &decoded)
+	s.ParentID = string(decoded.ParentID)
+	return err
+}
+
+// GroupContent is the name and description of a Collection or Section in one locale.
+type GroupContent struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// GroupTranslations hold the content of a Collection or Section by locale.
+type GroupTranslations map[string]GroupContent
+
+// UnmarshalJSON skips the type of the translations, and the locales without translation.
+func (t *GroupTranslations) UnmarshalJSON(b []byte) error {
+	translations, err := unmarshalTranslations[GroupContent](b)
+	*t = translations
+	return err
+}
+
+// List HelpCenters
+func (h *HelpCenterService) List() (HelpCenterList, error) {
+	return h.Repository.list()
+}
+
+// Find a HelpCenter by its ID
+func (h *HelpCenterService) Find(id string) (HelpCenter, error) {
+	if id == "" {
+		return HelpCenter{}, errors.New("Missing HelpCenter ID")
+	}
+	return h.Repository.find(id)
+}
+
+// ListCollections lists Collections
+func (h *HelpCenterService) ListCollections(params PageParams) (CollectionList, error) {
+	return h.Repository.listCollections(params)
+}
+
+// IterateCollections returns a CollectionIterator over all Collections, fetching pages of perPage as needed.
+// A perPage of 0 uses the API default.
+func (h *HelpCenterService) IterateCollections(perPage int64) *CollectionIterator {
+	return &CollectionIterator{pageIterator[Collection]{fetch: func(page int64) ([]Collection, PageParams, error) {
+		list, err := h.ListCollections(PageParams{Page: page, PerPage: perPage})
+		return list.Collections, list.Pages, err
+	}}}
+}
+
+// FindCollection finds a Collection by its ID
+func (h *HelpCenterService) FindCollection(id string) (Collection, error) {
+	if id == "" {
+		return Collection{}, errors.New("Missing Collection ID")
+	}
+	return h.Repository.findCollection(id)
+}
+
+// CreateCollection creates a Collection. A Name is required.
+func (h *HelpCenterService) CreateCollection(collection *Collection) (Collection, error) {
+	if collection.Name == "" {
+		return Collection{}, errors.New("Missing Collection Name")
+	}
+	if !isNumericID(collection.HelpCenterID) {
+		return Collection{}, fmt.Errorf("Invalid Collection Help Center ID %q", collection.HelpCenterID)
+	}
+	return h.Repository.createCollection(collection)
+}
+
+// UpdateCollection updates a Collection. Only the fields set are changed.
+func (h *HelpCenterService) UpdateCollection(collection *Collection) (Collection, error) {
+	if collection.ID == "" {
+		return Collection{}, errors.New("Missing Collection ID")
+	}
+	if !isNumericID(collection.HelpCenterID) {
+		return Collection{}, fmt.Errorf("Invalid Collection Help Center ID %q", collection.HelpCenterID)
+	}
+	return h.Repository.updateCollection(collection)
+}
+
+// DeleteCollection deletes a Collection by its ID
+func (h *HelpCenterService) DeleteCollection(id string) (DeletedObject, error) {
+	if id == "" {
+		return DeletedObject{}, errors.New("Missing Collection ID")
+	}
+	return h.Repository.deleteCollection(id)
+}
+
+// ListSections lists Sections
+func (h *HelpCenterService) ListSections(params PageParams) (SectionList, error) {
+	return h.Repository.listSections(params)
+}
+
+// FindSection finds a Section by its ID
+func (h *HelpCenterService) FindSection(id string) (Section, error) {
+	if id == "" {
+		return Section{}, errors.New("Missing Section ID")
+	}
+	return h.Repository.findSection(id)
+}
+
+// CreateSection creates a Section. A Name and the ParentID of its Collection are required.
+func (h *HelpCenterService) CreateSection(section *Section) (Section, error) {
+	if section.Name == "" {
+		return Section{}, errors.New("Missing Section Name")
+	}
+	if section.ParentID == "" {
+		return Section{}, errors.New("Missing Section Collection")
+	}
+	if !isNumericID(section.ParentID) {
+		return Section{}, fmt.Errorf("Invalid Section Collection ID %q", section.ParentID)
+	}
+	return h.Repository.createSection(section)
+}
+
+// UpdateSection updates a Section. Only the fields set are changed.
+func (h *HelpCenterService) UpdateSection(section *Section) (Section, error) {
+	if section.ID == "" {
+		return Section{}, errors.New("Missing Section ID")
+	}
+	if !isNumericID(section.ParentID) {
+		return Section{}, fmt.Errorf("Invalid Section Collection ID %q", section.ParentID)
+	}
+	return h.Repository.updateSection(section)
+}
+
+// DeleteSection deletes a Section by its ID
+func (h *HelpCenterService) DeleteSection(id string) (DeletedObject, error) {
+	if id == "" {
+		return DeletedObject{}, errors.New("Missing Section ID")
+	}
+	return h.Repository.deleteSection(id)
+}
+
+// CollectionIterator iterates over Collections across pages.
+type CollectionIterator struct {
+	pageIterator[Collection]
+}
+
+// Collection returns the current Collection.
+func (it *CollectionIterator) Collection() Collection {
+	return it.current()
+}
+
+func (h HelpCenter) String() string {
+	return fmt.Sprintf("[intercom] help_center { id: %s, identifier: %s }", h.ID, h.Identifier)
+}
+
+func (c Collection) String() string {
+	return fmt.Sprintf("[intercom] collection { id: %s, name: %s }", c.ID, c.Name)
+}
+
+func (s Section) String() string {
+	return fmt.Sprintf("[intercom] section { id: %s, name: %s, parent_id: %s }", s.ID, s.Name, s.ParentID)
+}
+
+// isNumericID reports whether an ID can be sent where the API expects a number. Empty IDs are left out of requests.
+func isNumericID(id string) bool {
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
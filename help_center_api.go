@@ -0,0 +1,162 @@
+package intercom
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
+
+// HelpCenterRepository defines the interface for working with HelpCenters, Collections and Sections through the API.
+type HelpCenterRepository interface {
+	list() (HelpCenterList, error)
+	find(id string) (HelpCenter, error)
+
+	listCollections(PageParams) (CollectionList, error)
+	findCollection(id string) (Collection, error)
+	createCollection(*Collection) (Collection, error)
+	updateCollection(*Collection) (Collection, error)
+	deleteCollection(id string) (DeletedObject, error)
+
+	listSections(PageParams) (SectionList, error)
+	findSection(id string) (Section, error)
+	createSection(*Section) (Section, error)
+	updateSection(*Section) (Section, error)
+	deleteSection(id string) (DeletedObject, error)
+}
+
+// HelpCenterAPI implements HelpCenterRepository
+type HelpCenterAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+type requestCollection struct {
+	Name              string            `json:"name,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	ParentID          string            `json:"parent_id,omitempty"`
+	HelpCenterID      json.Number       `json:"help_center_id,omitempty"`
+	TranslatedContent GroupTranslations `json:"translated_content,omitempty"`
+}
+
+type requestSection struct {
+	Name              string            `json:"name,omitempty"`
+	ParentID          json.Number       `json:"parent_id,omitempty"`
+	TranslatedContent GroupTranslations `json:"translated_content,omitempty"`
+}
+
+func (api HelpCenterAPI) list() (HelpCenterList, error) {
+	helpCenterList := HelpCenterList{}
+	data, err := api.httpClient.Get("/help_center/help_centers", nil)
+	if err != nil {
+		return helpCenterList, err
+	}
+	err = json.Unmarshal(data, &helpCenterList)
+	return helpCenterList, err
+}
+
+func (api HelpCenterAPI) find(id string) (HelpCenter, error) {
+	helpCenter := HelpCenter{}
+	data, err := api.httpClient.Get(fmt.Sprintf("/help_center/help_centers/%s", id), nil)
+	if err != nil {
+		return helpCenter, err
+	}
+	err = json.Unmarshal(data, &helpCenter)
+	return helpCenter, err
+}
+
+func (api HelpCenterAPI) listCollections(params PageParams) (CollectionList, error) {
+	collectionList := CollectionList{}
+	data, err := api.httpClient.Get("/help_center/collections", params)
+	if err != nil {
+		return collectionList, err
+	}
+	err = json.Unmarshal(data, &collectionList)
+	return collectionList, err
+}
+
+func (api HelpCenterAPI) findCollection(id string) (Collection, error) {
+	return unmarshalToCollection(api.httpClient.Get(fmt.Sprintf("/help_center/collections/%s", id), nil))
+}
+
+func (api HelpCenterAPI) createCollection(collection *Collection) (Collection, error) {
+	return unmarshalToCollection(api.httpClient.Post("/help_center/collections", newRequestCollection(collection)))
+}
+
+func (api HelpCenterAPI) updateCollection(collection *Collection) (Collection, error) {
+	return unmarshalToCollection(api.httpClient.Put(fmt.Sprintf("/help_center/collections/%s", collection.ID), newRequestCollection(collection)))
+}
+
+func (api HelpCenterAPI) deleteCollection(id string) (DeletedObject, error) {
+	return unmarshalToDeletedObject(api.httpClient.Delete(fmt.Sprintf("/help_center/collections/%s", id), nil))
+}
+
+func (api HelpCenterAPI) listSections(params PageParams) (SectionList, error) {
+	sectionList := SectionList{}
+	data, err := api.httpClient.Get("/help_center/sections", params)
+	if err != nil {
+		return sectionList, err
+	}
+	err = json.Unmarshal(data, &sectionList)
+	return sectionList, err
+}
+
+func (api HelpCenterAPI) findSection(id string) (Section, error) {
+	return unmarshalToSection(api.httpClient.Get(fmt.Sprintf("/help_center/sections/%s", id), nil))
+}
+
+func (api HelpCenterAPI) createSection(section *Section) (Section, error) {
+	return unmarshalToSection(api.httpClient.Post("/help_center/sections", newRequestSection(section)))
+}
+
+func (api HelpCenterAPI) updateSection(section *Section) (Section, error) {
+	return unmarshalToSection(api.httpClient.Put(fmt.Sprintf("/help_center/sections/%s", section.ID), newRequestSection(section)))
+}
+
+func (api HelpCenterAPI) deleteSection(id string) (DeletedObject, error) {
+	return unmarshalToDeletedObject(api.httpClient.Delete(fmt.Sprintf("/help_center/sections/%s", id), nil))
+}
+
+func newRequestCollection(collection *Collection) *requestCollection {
+	return &requestCollection{
+		Name:              collection.Name,
+		Description:       collection.Description,
+		ParentID:          collection.ParentID,
+		HelpCenterID:      json.Number(collection.HelpCenterID),
+		TranslatedContent: collection.TranslatedContent,
+	}
+}
+
+func newRequestSection(section *Section) *requestSection {
+	return &requestSection{
+		Name:              section.Name,
+		ParentID:          json.Number(section.ParentID),
+		TranslatedContent: section.TranslatedContent,
+	}
+}
+
+func unmarshalToCollection(data []byte, err error) (Collection, error) {
+	savedCollection := Collection{}
+	if err != nil {
+		return savedCollection, err
+	}
+	err = json.Unmarshal(data, &savedCollection)
+	return savedCollection, err
+}
+
+func unmarshalToSection(data []byte, err error) (Section, error) {
+	savedSection := Section{}
+	if err != nil {
+		return savedSection, err
+	}
+	err = json.Unmarshal(data, &savedSection)
+	return savedSection, err
+}
+
+func unmarshalToDeletedObject(data []byte, err error) (DeletedObject, error) {
+	deleted := DeletedObject{}
+	if err != nil {
+		return deleted, err
+	}
+	err = json.Unmarshal(data, &deleted)
+	return deleted, err
+}
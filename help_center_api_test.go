@@ -0,0 +1,81 @@
+package intercom
+
+import "testing"
+
+func TestHelpCenterAPIList(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/help_centers.json", expectedURI: "/help_center/help_centers"}
+	api := HelpCenterAPI{httpClient: &http}
+	list, err := api.list()
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if len(list.HelpCenters) != 1 || list.HelpCenters[0].Identifier != "help" || !list.HelpCenters[0].WebsiteTurnedOn {
+		t.Errorf("Help centers were %v", list.HelpCenters)
+	}
+}
+
+func TestHelpCenterAPIListCollections(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/collections.json", expectedURI: "/help_center/collections"}
+	api := HelpCenterAPI{httpClient: &http}
+	list, err := api.listCollections(PageParams{Page: 1})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if len(list.Collections) != 2 || list.Collections[0].ParentID != "" || list.Collections[1].ParentID != "145" || list.Pages.TotalPages != 1 {
+		t.Errorf("Collections were %v", list)
+	}
+}
+
+func TestHelpCenterAPIFindCollection(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/collection.json", expectedURI: "/help_center/collections/145"}
+	api := HelpCenterAPI{httpClient: &http}
+	collection, err := api.findCollection("145")
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if collection.Name != "Onboarding" || collection.HelpCenterID != "123" || len(collection.TranslatedContent) != 2 || collection.TranslatedContent["fr"].Name != "Prise en main" {
+		t.Errorf("Collection was %v", collection)
+	}
+}
+
+func TestHelpCenterAPIUpdateCollection(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/collection.json", expectedURI: "/help_center/collections/145"}
+	http.f = func(body interface{}) {
+		if request := body.(*requestCollection); request.Name != "" || request.TranslatedContent["fr"].Name != "Prise en main" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := HelpCenterAPI{httpClient: &http}
+	api.updateCollection(&Collection{ID: "145", TranslatedContent: GroupTranslations{"fr": {Name: "Prise en main"}}})
+}
+
+func TestHelpCenterAPICreateSection(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/section.json", expectedURI: "/help_center/sections"}
+	http.f = func(body interface{}) {
+		if request := body.(*requestSection); request.Name != "Accounts" || request.ParentID != "145" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := HelpCenterAPI{httpClient: &http}
+	section, err := api.createSection(&Section{Name: "Accounts", ParentID: "145"})
+	if err != nil || section.ID != "2" || section.TranslatedContent["en"].Name != "Accounts" {
+		t.Errorf("Created %v, error %v", section, err)
+	}
+}
+
+func TestHelpCenterAPIListSections(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/sections.json", expectedURI: "/help_center/sections"}
+	api := HelpCenterAPI{httpClient: &http}
+	list, err := api.listSections(PageParams{})
+	if err != nil || len(list.Sections) != 1 || list.Sections[0].ParentID != "145" {
+		t.Errorf("Sections were %v, error %v", list, err)
+	}
+}
+
+func TestHelpCenterAPIDeleteSection(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/company_deleted.json", expectedURI: "/help_center/sections/2"}
+	api := HelpCenterAPI{httpClient: &http}
+	if _, err := api.deleteSection("2"); err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+}
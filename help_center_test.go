@@ -0,0 +1,100 @@
+package intercom
+
+import "testing"
+
+func TestHelpCenterValidation(t *testing.T) {
+	helpCenter := HelpCenterService{Repository: TestHelpCenterAPI{}}
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{second(helpCenter.Find("")), "Missing HelpCenter ID"},
+		{second(helpCenter.CreateCollection(&Collection{})), "Missing Collection Name"},
+		{second(helpCenter.UpdateCollection(&Collection{Name: "Onboarding"})), "Missing Collection ID"},
+		{second(helpCenter.UpdateCollection(&Collection{ID: "145", HelpCenterID: "main"})), `Invalid Collection Help Center ID "main"`},
+		{second(helpCenter.DeleteCollection("")), "Missing Collection ID"},
+		{second(helpCenter.CreateSection(&Section{ParentID: "145"})), "Missing Section Name"},
+		{second(helpCenter.CreateSection(&Section{Name: "Accounts"})), "Missing Section Collection"},
+		{second(helpCenter.UpdateSection(&Section{Name: "Accounts"})), "Missing Section ID"},
+		{second(helpCenter.CreateSection(&Section{Name: "Accounts", ParentID: "onboarding"})), `Invalid Section Collection ID "onboarding"`},
+	}
+	for _, c := range cases {
+		if c.err == nil || c.err.Error() != c.expected {
+			t.Errorf("Expected %s, got %v", c.expected, c.err)
+		}
+	}
+}
+
+func TestHelpCenterCreateCollection(t *testing.T) {
+	helpCenter := HelpCenterService{Repository: TestHelpCenterAPI{}}
+	collection, err := helpCenter.CreateCollection(&Collection{Name: "Onboarding"})
+	if err != nil || collection.ID != "145" {
+		t.Errorf("Created %v, error %v", collection, err)
+	}
+}
+
+func TestHelpCenterIterateCollections(t *testing.T) {
+	helpCenter := HelpCenterService{Repository: TestHelpCenterAPI{}}
+	it := helpCenter.IterateCollections(0)
+	names := []string{}
+	for it.Next() {
+		names = append(names, it.Collection().Name)
+	}
+	if it.Err() != nil || len(names) != 1 || names[0] != "Onboarding" {
+		t.Errorf("Iterated %v, error %v", names, it.Err())
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
+
+type TestHelpCenterAPI struct{}
+
+func (t TestHelpCenterAPI) list() (HelpCenterList, error) {
+	return HelpCenterList{HelpCenters: []HelpCenter{{ID: "123"}}}, nil
+}
+
+func (t TestHelpCenterAPI) find(id string) (HelpCenter, error) {
+	return HelpCenter{ID: id}, nil
+}
+
+func (t TestHelpCenterAPI) listCollections(params PageParams) (CollectionList, error) {
+	return CollectionList{Collections: []Collection{{ID: "145", Name: "Onboarding"}}, Pages: PageParams{Page: 1, TotalPages: 1}}, nil
+}
+
+func (t TestHelpCenterAPI) findCollection(id string) (Collection, error) {
+	return Collection{ID: id}, nil
+}
+
+func (t TestHelpCenterAPI) createCollection(collection *Collection) (Collection, error) {
+	return Collection{ID: "145", Name: collection.Name}, nil
+}
+
+func (t TestHelpCenterAPI) updateCollection(collection *Collection) (Collection, error) {
+	return *collection, nil
+}
+
+func (t TestHelpCenterAPI) deleteCollection(id string) (DeletedObject, error) {
+	return DeletedObject{ID: id, Object: "collection", Deleted: true}, nil
+}
+
+func (t TestHelpCenterAPI) listSections(params PageParams) (SectionList, error) {
+	return SectionList{}, nil
+}
+
+func (t TestHelpCenterAPI) findSection(id string) (Section, error) {
+	return Section{ID: id}, nil
+}
+
+func (t TestHelpCenterAPI) createSection(section *Section) (Section, error) {
+	return Section{ID: "2", Name: section.Name}, nil
+}
+
+func (t TestHelpCenterAPI) updateSection(section *Section) (Section, error) {
+	return *section, nil
+}
+
+func (t TestHelpCenterAPI) deleteSection(id string) (DeletedObject, error) {
+	return DeletedObject{ID: id, Object: "section", Deleted: true}, nil
+}
@@ -0,0 +1,154 @@
+// Package helpsync syncs a directory of Markdown documents to Intercom Help Center articles.
+//
+// Each document has a front matter block holding its title and, optionally, its collection,
+// locale, state, description and key:
+//
+//	---
+//	title: Getting started
+//	collection: Onboarding
+//	state: published
+//	---
+//	Welcome to **Example**!
+//
+// The key identifies the article across syncs. It defaults to the path of the document without
+// its extension (and locale suffix, as in getting-started.fr.md), so documents can be edited freely,
+// but moving one makes it a new article unless its key is set.
+package helpsync
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A Document is a Markdown document to sync, in one locale.
+type Document struct {
+	Path        string // Relative to the directory loaded.
+	Key         string
+	Title       string
+	Description string
+	Collection  string // Name or ID of the Collection of the article.
+	Locale      string // Empty for the default locale.
+	State       string // "published" or "draft", or empty for the default state.
+	Markdown    string
+}
+
+// HTML converts the Markdown of the Document.
+func (d Document) HTML() string {
+	return MarkdownToHTML(d.Markdown)
+}
+
+// ParseDocument parses a Markdown document with its front matter.
+func ParseDocument(path string, data []byte) (Document, error) {
+	doc := Document{Path: filepath.ToSlash(path)}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return doc, fmt.Errorf("helpsync: %s has no front matter", path)
+	}
+	end := strings.Index(text[4:], "\n---")
+	if end < 0 {
+		return doc, fmt.Errorf("helpsync: %s has an unterminated front matter", path)
+	}
+	frontMatter := text[4 : 4+end]
+	body := strings.TrimPrefix(text[4+end+4:], "\n")
+
+	scanner := bufio.NewScanner(strings.NewReader(frontMatter))
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			return doc, fmt.Errorf("helpsync: %s:%d: expected name: value", path, line+1)
+		}
+		value = unquote(strings.TrimSpace(value))
+		switch strings.TrimSpace(name) {
+		case "title":
+			doc.Title = value
+		case "description":
+			doc.Description = value
+		case "collection":
+			doc.Collection = value
+		case "locale":
+			doc.Locale = value
+		case "state":
+			doc.State = value
+		case "key":
+			doc.Key = value
+		default:
+			return doc, fmt.Errorf("helpsync: %s:%d: unknown front matter %q", path, line+1, strings.TrimSpace(name))
+		}
+	}
+	if doc.Title == "" {
+		return doc, fmt.Errorf("helpsync: %s has no title", path)
+	}
+	if doc.State != "" && doc.State != "published" && doc.State != "draft" {
+		return doc, fmt.Errorf("helpsync: %s has an invalid state %q", path, doc.State)
+	}
+	if doc.Key == "" {
+		doc.Key = strings.TrimSuffix(doc.Path, filepath.Ext(doc.Path))
+		if doc.Locale != "" {
+			doc.Key = strings.TrimSuffix(doc.Key, "."+doc.Locale)
+		}
+	}
+	doc.Markdown = body
+	return doc, nil
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		if value[0] == '"' {
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				return unquoted
+			}
+		}
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// LoadDirectory parses the Markdown documents (*.md) of a directory and its subdirectories.
+// Files and directories starting with "." or "_" are skipped.
+func LoadDirectory(dir string) ([]Document, error) {
+	docs := []Document{}
+	var errs []error
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if path != dir && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || filepath.Ext(name) != ".md" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relative, _ := filepath.Rel(dir, path)
+		doc, err := ParseDocument(relative, data)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Path < docs[j].Path })
+	return docs, errors.Join(errs...)
+}
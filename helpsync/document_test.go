@@ -0,0 +1,54 @@
+package helpsync
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	doc, err := ParseDocument("guides/setup.de.md", []byte("---\r\ntitle: \"Einrichtung: Teil 1\"\r\nlocale: de\r\n# a comment\r\nstate: draft\r\n---\r\nHallo *Welt*\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Title != "Einrichtung: Teil 1" || doc.Locale != "de" || doc.State != "draft" || doc.Key != "guides/setup" {
+		t.Errorf("Document was %+v", doc)
+	}
+	if doc.HTML() != "<p>Hallo <em>Welt</em></p>" {
+		t.Errorf("HTML was %s", doc.HTML())
+	}
+
+	doc, _ = ParseDocument("setup.md", []byte("---\ntitle: Setup\nkey: onboarding/setup\n---\n"))
+	if doc.Key != "onboarding/setup" {
+		t.Errorf("Key was %s", doc.Key)
+	}
+}
+
+func TestParseDocumentErrors(t *testing.T) {
+	cases := map[string]string{
+		"no front matter":               "# Setup",
+		"unterminated":                  "---\ntitle: Setup\n",
+		"no title":                      "---\nstate: draft\n---\n",
+		"invalid state":                 "---\ntitle: Setup\nstate: archived\n---\n",
+		"unknown front matter \"tags\"": "---\ntitle: Setup\ntags: a, b\n---\n",
+		"expected name: value":          "---\ntitle: Setup\nstate\n---\n",
+	}
+	for message, data := range cases {
+		if _, err := ParseDocument("setup.md", []byte(data)); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Parsing %q returned %v, expected %s", data, err, message)
+		}
+	}
+}
+
+func TestLoadDirectory(t *testing.T) {
+	docs, err := LoadDirectory("../fixtures/helpdocs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{}
+	for _, doc := range docs {
+		keys = append(keys, doc.Key+":"+doc.Locale)
+	}
+	if strings.Join(keys, " ") != "billing/invoices: getting-started:fr getting-started:" {
+		t.Errorf("Documents were %v", keys)
+	}
+}
@@ -0,0 +1,62 @@
+package helpsync
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// A Manifest maps the keys of documents to the articles they were synced to,
+// with a hash of their content to skip unchanged articles.
+type Manifest struct {
+	Articles map[string]ManifestEntry `json:"articles"`
+}
+
+// A ManifestEntry is the article a document was synced to.
+type ManifestEntry struct {
+	ID       string `json:"id"`
+	Hash     string `json:"hash"`
+	Archived bool   `json:"archived,omitempty"`
+}
+
+// LoadManifest reads a Manifest, returning an empty one if the file does not exist.
+func LoadManifest(path string) (Manifest, error) {
+	manifest := Manifest{Articles: map[string]ManifestEntry{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, err
+	}
+	if manifest.Articles == nil {
+		manifest.Articles = map[string]ManifestEntry{}
+	}
+	return manifest, nil
+}
+
+// Save writes the Manifest, replacing the file atomically.
+func (m Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
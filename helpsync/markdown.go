@@ -0,0 +1,328 @@
+package helpsync
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stefanoschrs/go-intercom/internal/safeurl"
+)
+
+// MarkdownToHTML converts Markdown to the HTML subset Intercom articles accept:
+// paragraphs, h1 to h3 headings, bold, italic, code, code blocks, links, images, lists, quotes and line breaks.
+// Raw HTML is escaped, and links and images other than http(s) (and mailto for links) are dropped.
+func MarkdownToHTML(markdown string) string {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	markdown = strings.ReplaceAll(markdown, "\x00", "")
+	out := &strings.Builder{}
+	writeBlocks(out, strings.Split(markdown, "\n"))
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+var (
+	headingPattern      = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleLinePattern     = regexp.MustCompile(`^ {0,3}(?:(?:- *){3,}|(?:\* *){3,}|(?:_ *){3,})$`)
+	unorderedPattern    = regexp.MustCompile(`^( {0,3})[-*+]\s+`)
+	orderedPattern      = regexp.MustCompile(`^( {0,3})\d{1,9}[.)]\s+`)
+	fencePattern        = regexp.MustCompile("^ {0,3}(```+|~~~+)")
+	blockquotePattern   = regexp.MustCompile(`^ {0,3}> ?`)
+	hardBreakSentinel   = "\x00"
+	markdownPunctuation = "\\`*_{}[]()#+-.!<>\"'~|"
+)
+
+func writeBlocks(out *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case fencePattern.MatchString(line):
+			i = writeCodeBlock(out, lines, i)
+		case headingPattern.MatchString(line):
+			match := headingPattern.FindStringSubmatch(line)
+			level := len(match[1])
+			if level > 3 {
+				level = 3
+			}
+			tag := "h" + strconv.Itoa(level)
+			out.WriteString("<" + tag + ">" + inline(match[2]) + "</" + tag + ">\n")
+			i++
+		case ruleLinePattern.MatchString(line):
+			// Intercom articles have no horizontal rules; a rule only separates blocks.
+			i++
+		case blockquotePattern.MatchString(line):
+			quoted := []string{}
+			for ; i < len(lines) && blockquotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, blockquotePattern.ReplaceAllString(lines[i], ""))
+			}
+			out.WriteString("<blockquote>\n")
+			writeBlocks(out, quoted)
+			out.WriteString("</blockquote>\n")
+		case unorderedPattern.MatchString(line) || orderedPattern.MatchString(line):
+			i = writeList(out, lines, i)
+		default:
+			i = writeParagraph(out, lines, i)
+		}
+	}
+}
+
+func writeCodeBlock(out *strings.Builder, lines []string, i int) int {
+	fence := fencePattern.FindStringSubmatch(lines[i])[1]
+	code := []string{}
+	for i++; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+	out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+	return i
+}
+
+func writeParagraph(out *strings.Builder, lines []string, i int) int {
+	text := &strings.Builder{}
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" || (text.Len() > 0 && interruptsParagraph(line)) {
+			break
+		}
+		if text.Len() > 0 {
+			text.WriteString("\n")
+		}
+		trimmed := strings.TrimRight(line, " \t")
+		switch {
+		case strings.HasSuffix(line, "  "):
+			text.WriteString(strings.TrimLeft(trimmed, " \t") + hardBreakSentinel)
+		case strings.HasSuffix(trimmed, `\`):
+			text.WriteString(strings.TrimLeft(strings.TrimSuffix(trimmed, `\`), " \t") + hardBreakSentinel)
+		default:
+			text.WriteString(strings.TrimLeft(trimmed, " \t"))
+		}
+	}
+	paragraph := strings.TrimSuffix(text.String(), hardBreakSentinel)
+	out.WriteString("<p>" + inline(paragraph) + "</p>\n")
+	return i
+}
+
+func interruptsParagraph(line string) bool {
+	return headingPattern.MatchString(line) || fencePattern.MatchString(line) || blockquotePattern.MatchString(line) ||
+		ruleLinePattern.MatchString(line) || unorderedPattern.MatchString(line) || orderedPattern.MatchString(line)
+}
+
+// writeList writes a list and its items, whose content is indented past their marker.
+func writeList(out *strings.Builder, lines []string, i int) int {
+	ordered := !unorderedPattern.MatchString(lines[i])
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	out.WriteString("<" + tag + ">\n")
+	loose := false
+	items := [][]string{}
+	for i < len(lines) {
+		marker := listMarker(lines[i], ordered)
+		if marker == "" {
+			break
+		}
+		item := []string{lines[i][len(marker):]}
+		indent := len(marker)
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				// A blank line continues the item if the next line is indented.
+				if i+1 < len(lines) && leadingSpaces(lines[i+1]) >= indent {
+					item = append(item, "")
+					loose = true
+					continue
+				}
+				if i+1 < len(lines) && listMarker(lines[i+1], ordered) != "" {
+					loose = true
+					i++
+				}
+				break
+			}
+			if leadingSpaces(line) >= indent {
+				item = append(item, line[indent:])
+			} else if listMarker(line, ordered) != "" || interruptsParagraph(line) {
+				break
+			} else {
+				item = append(item, strings.TrimLeft(line, " "))
+			}
+		}
+		items = append(items, item)
+	}
+	for _, item := range items {
+		content := &strings.Builder{}
+		writeBlocks(content, item)
+		body := strings.TrimSuffix(content.String(), "\n")
+		if !loose && strings.HasPrefix(body, "<p>") {
+			// Tight list items hold their text without a paragraph.
+			if end := strings.Index(body, "</p>"); end >= 0 {
+				body = body[3:end] + body[end+4:]
+			}
+		}
+		out.WriteString("<li>" + body + "</li>\n")
+	}
+	out.WriteString("</" + tag + ">\n")
+	return i
+}
+
+func listMarker(line string, ordered bool) string {
+	if ordered {
+		return orderedPattern.FindString(line)
+	}
+	return unorderedPattern.FindString(line)
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+var (
+	inlineLinkPattern = regexp.MustCompile(`^\(\s*(<[^>]*>|[^\s()]*(?:\([^\s()]*\)[^\s()]*)*)(?:\s+"[^"]*")?\s*\)`)
+	autolinkPattern   = regexp.MustCompile(`^<((?:https?://|mailto:)[^\s<>]+)>`)
+)
+
+// inline converts the inline Markdown of a block of text.
+func inline(text string) string {
+	out := &strings.Builder{}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte(markdownPunctuation, text[i+1]) >= 0:
+			out.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+		case c == '`':
+			i = writeCodeSpan(out, text, i)
+		case c == '!' && strings.HasPrefix(text[i+1:], "["):
+			if n := writeLink(out, text[i+1:], true); n > 0 {
+				i += 1 + n
+			} else {
+				out.WriteString("!")
+				i++
+			}
+		case c == '[':
+			if n := writeLink(out, text[i:], false); n > 0 {
+				i += n
+			} else {
+				out.WriteString("[")
+				i++
+			}
+		case c == '*' || c == '_':
+			i = writeEmphasis(out, text, i)
+		case c == '<' && autolinkPattern.MatchString(text[i:]):
+			match := autolinkPattern.FindStringSubmatch(text[i:])
+			href := html.EscapeString(match[1])
+			out.WriteString(`<a href="` + href + `">` + html.EscapeString(strings.TrimPrefix(match[1], "mailto:")) + "</a>")
+			i += len(match[0])
+		case text[i:i+1] == hardBreakSentinel:
+			out.WriteString("<br>\n")
+			i++
+			for i < len(text) && text[i] == '\n' {
+				i++
+			}
+		default:
+			out.WriteString(html.EscapeString(text[i : i+1]))
+			i++
+		}
+	}
+	return out.String()
+}
+
+func writeCodeSpan(out *strings.Builder, text string, i int) int {
+	run := i
+	for run < len(text) && text[run] == '`' {
+		run++
+	}
+	fence := text[i:run]
+	end := strings.Index(text[run:], fence)
+	if end < 0 {
+		out.WriteString(fence)
+		return run
+	}
+	code := text[run : run+end]
+	if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+		code = code[1 : len(code)-1]
+	}
+	out.WriteString("<code>" + html.EscapeString(strings.ReplaceAll(code, "\n", " ")) + "</code>")
+	return run + end + len(fence)
+}
+
+// writeLink writes the link or image starting with the "[" of text, returning its length or 0 if text has none.
+func writeLink(out *strings.Builder, text string, image bool) int {
+	depth, end := 0, -1
+	for j := 0; j < len(text) && end < 0; j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				end = j
+			}
+		}
+	}
+	if end < 0 {
+		return 0
+	}
+	match := inlineLinkPattern.FindStringSubmatch(text[end+1:])
+	if match == nil {
+		return 0
+	}
+	label := text[1:end]
+	url := strings.TrimSuffix(strings.TrimPrefix(match[1], "<"), ">")
+	switch {
+	case image && safeurl.Allowed(url, false):
+		out.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(label) + `">`)
+	case image:
+		out.WriteString(html.EscapeString(label))
+	case safeurl.Allowed(url, true):
+		out.WriteString(`<a href="` + html.EscapeString(url) + `">` + inline(label) + "</a>")
+	default:
+		out.WriteString(inline(label))
+	}
+	return end + 1 + len(match[0])
+}
+
+// writeEmphasis writes the bold or italic text delimited by the run of * or _ at i.
+func writeEmphasis(out *strings.Builder, text string, i int) int {
+	c := text[i]
+	run := i
+	for run < len(text) && text[run] == c && run-i < 2 {
+		run++
+	}
+	delimiter := text[i:run]
+	opens := run < len(text) && text[run] != ' ' && text[run] != '\n'
+	if c == '_' && i > 0 && isWordByte(text[i-1]) {
+		opens = false
+	}
+	if opens {
+		for end := run + 1; end <= len(text)-len(delimiter); end++ {
+			if text[end:end+len(delimiter)] != delimiter || text[end-1] == ' ' || text[end-1] == '\\' {
+				continue
+			}
+			after := end + len(delimiter)
+			if after < len(text) && text[after] == c {
+				continue
+			}
+			if c == '_' && after < len(text) && isWordByte(text[after]) {
+				continue
+			}
+			tag := "em"
+			if len(delimiter) == 2 {
+				tag = "strong"
+			}
+			out.WriteString("<" + tag + ">" + inline(text[run:end]) + "</" + tag + ">")
+			return after
+		}
+	}
+	out.WriteString(delimiter)
+	return run
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
@@ -0,0 +1,34 @@
+package helpsync
+
+import "testing"
+
+func TestMarkdownToHTML(t *testing.T) {
+	cases := []struct{ name, markdown, expected string }{
+		{"paragraphs", "Hello\nworld\n\nAgain", "<p>Hello\nworld</p>\n<p>Again</p>"},
+		{"headings", "# One\n## Two ##\n#### Four", "<h1>One</h1>\n<h2>Two</h2>\n<h3>Four</h3>"},
+		{"emphasis", "**bold** and *italic* and __bold__ and _italic_", "<p><strong>bold</strong> and <em>italic</em> and <strong>bold</strong> and <em>italic</em></p>"},
+		{"intraword underscores", "snake_case_name", "<p>snake_case_name</p>"},
+		{"unclosed emphasis", "2 * 3 = 6 and *open", "<p>2 * 3 = 6 and *open</p>"},
+		{"code span", "Run `go test <pkg>`", "<p>Run <code>go test &lt;pkg&gt;</code></p>"},
+		{"code block", "```go\nif a < b {\n}\n```", "<pre><code>if a &lt; b {\n}</code></pre>"},
+		{"link", "See [the **docs**](https://example.com/docs?a=1&b=2 \"Docs\")", `<p>See <a href="https://example.com/docs?a=1&amp;b=2">the <strong>docs</strong></a></p>`},
+		{"unsafe link", "[click](javascript:alert(1))", "<p>click</p>"},
+		{"image", "![Setup screen](https://example.com/setup.png)", `<p><img src="https://example.com/setup.png" alt="Setup screen"></p>`},
+		{"autolink", "<https://example.com> or <mailto:help@example.com>", `<p><a href="https://example.com">https://example.com</a> or <a href="mailto:help@example.com">help@example.com</a></p>`},
+		{"raw html", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"escapes", `\*not italic\*`, "<p>*not italic*</p>"},
+		{"hard break", "line one  \nline two\\\nline three", "<p>line one<br>\nline two<br>\nline three</p>"},
+		{"blockquote", "> Quoted\n> **text**", "<blockquote>\n<p>Quoted\n<strong>text</strong></p>\n</blockquote>"},
+		{"unordered list", "- one\n- two\n  continued\n* three", "<ul>\n<li>one</li>\n<li>two\ncontinued</li>\n<li>three</li>\n</ul>"},
+		{"ordered list", "1. one\n2. two", "<ol>\n<li>one</li>\n<li>two</li>\n</ol>"},
+		{"nested list", "- one\n  - one a\n  - one b\n- two", "<ul>\n<li>one\n<ul>\n<li>one a</li>\n<li>one b</li>\n</ul></li>\n<li>two</li>\n</ul>"},
+		{"loose list", "- one\n\n- two", "<ul>\n<li><p>one</p></li>\n<li><p>two</p></li>\n</ul>"},
+		{"list after paragraph", "Steps:\n1. Open\n2. Close", "<p>Steps:</p>\n<ol>\n<li>Open</li>\n<li>Close</li>\n</ol>"},
+		{"rule", "Above\n\n---\n\nBelow", "<p>Above</p>\n<p>Below</p>"},
+	}
+	for _, c := range cases {
+		if html := MarkdownToHTML(c.markdown); html != c.expected {
+			t.Errorf("%s: converted\n%s\nexpected\n%s", c.name, html, c.expected)
+		}
+	}
+}
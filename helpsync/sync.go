@@ -0,0 +1,416 @@
+package helpsync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	intercom "github.com/stefanoschrs/go-intercom"
+)
+
+// A Syncer creates, updates and archives Help Center articles to match Documents.
+type Syncer struct {
+	Articles      *intercom.ArticleService
+	HelpCenter    *intercom.HelpCenterService
+	AuthorID      int64  // The Admin authoring the articles.
+	DefaultLocale string // Locale of the articles, and of documents without one. Defaults to "en".
+	DefaultState  string // State of documents without one. Defaults to "draft".
+	ManifestPath  string // Maps document keys to articles across syncs. Documents not in it are matched to articles by title, and their articles updated.
+	DryRun        bool   // Report the changes without making them.
+	Delete        bool   // Delete the articles of removed documents, rather than unpublishing them.
+}
+
+// Op is the change made to an article.
+type Op int
+
+const (
+	UNCHANGED Op = iota
+	CREATE
+	UPDATE
+	ARCHIVE
+	DELETE
+	CREATE_COLLECTION
+)
+
+var ops = [...]string{
+	"unchanged",
+	"create",
+	"update",
+	"archive",
+	"delete",
+	"create collection",
+}
+
+func (op Op) String() string {
+	return ops[op]
+}
+
+// An Action is a change made, or to make in a dry run, to an article or collection.
+type Action struct {
+	Op        Op
+	Key       string   // The key of the documents of the article.
+	Title     string   // The title of the article, or the name of the collection.
+	ArticleID string   // The ID of the article, or of the collection created. Empty for articles to create.
+	Locales   []string // Locales of the translations of the article.
+	Err       error    // Why the change failed.
+}
+
+func (a Action) String() string {
+	s := fmt.Sprintf("%-9s %s", a.Op, a.Key)
+	if a.Title != "" {
+		s += fmt.Sprintf(" %q", a.Title)
+	}
+	if a.ArticleID != "" {
+		s += " #" + a.ArticleID
+	}
+	if len(a.Locales) > 0 {
+		s += " [" + strings.Join(a.Locales, ", ") + "]"
+	}
+	if a.Err != nil {
+		s += ": " + a.Err.Error()
+	}
+	return s
+}
+
+// A Report lists the Actions of a sync.
+type Report struct {
+	DryRun  bool
+	Actions []Action
+}
+
+// Count returns the number of Actions of an Op.
+func (r Report) Count(op Op) int {
+	n := 0
+	for _, action := range r.Actions {
+		if action.Op == op {
+			n++
+		}
+	}
+	return n
+}
+
+// Err joins the errors of the Actions which failed.
+func (r Report) Err() error {
+	var errs []error
+	for _, action := range r.Actions {
+		if action.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", action.Op, action.Key, action.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r Report) String() string {
+	out := &strings.Builder{}
+	if r.DryRun {
+		out.WriteString("Dry run, nothing was changed.\n")
+	}
+	for _, action := range r.Actions {
+		if action.Op != UNCHANGED {
+			out.WriteString(action.String() + "\n")
+		}
+	}
+	fmt.Fprintf(out, "%d created, %d updated, %d archived, %d deleted, %d unchanged\n",
+		r.Count(CREATE), r.Count(UPDATE), r.Count(ARCHIVE), r.Count(DELETE), r.Count(UNCHANGED))
+	return out.String()
+}
+
+// An article groups the Documents of a key: in the default locale, and its translations.
+type article struct {
+	key          string
+	doc          Document
+	translations map[string]Document
+}
+
+// Sync makes the Help Center match the Documents, then saves the Manifest.
+// Changes which fail are reported in the Report, and their errors returned together once every change was tried.
+func (s Syncer) Sync(docs []Document) (Report, error) {
+	s = s.withDefaults()
+	report := Report{DryRun: s.DryRun}
+	if s.AuthorID == 0 {
+		return report, errors.New("helpsync: missing AuthorID")
+	}
+	articles, err := s.group(docs)
+	if err != nil {
+		return report, err
+	}
+	manifest := Manifest{Articles: map[string]ManifestEntry{}}
+	if s.ManifestPath != "" {
+		if manifest, err = LoadManifest(s.ManifestPath); err != nil {
+			return report, err
+		}
+	}
+	remote, err := s.remoteArticles()
+	if err != nil {
+		return report, err
+	}
+	collections, err := s.syncCollections(articles, &report)
+	if err != nil {
+		return report, err
+	}
+
+	// Title matching bootstraps the manifest: the first sync adopts the existing articles of the documents,
+	// and updates them as there is no hash yet to tell whether they changed.
+	claimed := map[string]bool{}
+	for _, entry := range manifest.Articles {
+		claimed[entry.ID] = true
+	}
+	byTitle := map[string][]string{}
+	for id, a := range remote {
+		if !claimed[id] {
+			byTitle[a.Title] = append(byTitle[a.Title], id)
+		}
+	}
+
+	for _, a := range articles {
+		desired, hash := s.article(a, collections)
+		action := Action{Key: a.key, Title: a.doc.Title, Locales: sortedLocales(a.translations)}
+		entry, known := manifest.Articles[a.key]
+		if _, exists := remote[entry.ID]; !known || !exists {
+			entry = ManifestEntry{}
+			if ids := byTitle[desired.Title]; len(ids) == 1 {
+				entry.ID = ids[0]
+				delete(byTitle, desired.Title)
+			}
+		}
+		switch {
+		case entry.ID == "":
+			action.Op = CREATE
+		case entry.Hash == hash && !entry.Archived && !changed(desired, remote[entry.ID]):
+			action.Op = UNCHANGED
+		default:
+			action.Op = UPDATE
+		}
+		action.ArticleID = entry.ID
+		if !s.DryRun && action.Op != UNCHANGED {
+			var saved intercom.Article
+			if action.Op == CREATE {
+				saved, action.Err = s.Articles.Create(&desired)
+			} else {
+				desired.ID = entry.ID
+				saved, action.Err = s.Articles.Update(&desired)
+			}
+			if action.Err == nil {
+				action.ArticleID = saved.ID
+			}
+		}
+		if action.Err == nil {
+			manifest.Articles[a.key] = ManifestEntry{ID: action.ArticleID, Hash: hash}
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	for _, key := range s.removed(articles, manifest) {
+		entry := manifest.Articles[key]
+		if _, exists := remote[entry.ID]; !exists {
+			delete(manifest.Articles, key)
+			continue
+		}
+		action := Action{Op: ARCHIVE, Key: key, Title: remote[entry.ID].Title, ArticleID: entry.ID}
+		if s.Delete {
+			action.Op = DELETE
+		}
+		if !s.DryRun {
+			if s.Delete {
+				_, action.Err = s.Articles.Delete(entry.ID)
+			} else {
+				_, action.Err = s.Articles.Update(archivedArticle(remote[entry.ID]))
+			}
+		}
+		if action.Err == nil {
+			if s.Delete {
+				delete(manifest.Articles, key)
+			} else {
+				entry.Archived = true
+				manifest.Articles[key] = entry
+			}
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	if !s.DryRun && s.ManifestPath != "" {
+		if err := manifest.Save(s.ManifestPath); err != nil {
+			return report, err
+		}
+	}
+	return report, report.Err()
+}
+
+// group groups Documents by key, sorted by key.
+func (s Syncer) group(docs []Document) ([]*article, error) {
+	byKey := map[string]*article{}
+	for _, doc := range docs {
+		a := byKey[doc.Key]
+		if a == nil {
+			a = &article{key: doc.Key, translations: map[string]Document{}}
+			byKey[doc.Key] = a
+		}
+		if doc.Locale == "" || doc.Locale == s.DefaultLocale {
+			if a.doc.Path != "" {
+				return nil, fmt.Errorf("helpsync: %s and %s have the same key %q", a.doc.Path, doc.Path, doc.Key)
+			}
+			a.doc = doc
+		} else {
+			if other, ok := a.translations[doc.Locale]; ok {
+				return nil, fmt.Errorf("helpsync: %s and %s have the same key %q and locale %s", other.Path, doc.Path, doc.Key, doc.Locale)
+			}
+			a.translations[doc.Locale] = doc
+		}
+	}
+	articles := make([]*article, 0, len(byKey))
+	for _, a := range byKey {
+		if a.doc.Path == "" {
+			return nil, fmt.Errorf("helpsync: %q has translations but no %s document", a.key, s.DefaultLocale)
+		}
+		articles = append(articles, a)
+	}
+	sort.Slice(articles, func(i, j int) bool { return articles[i].key < articles[j].key })
+	return articles, nil
+}
+
+func (s Syncer) remoteArticles() (map[string]intercom.Article, error) {
+	remote := map[string]intercom.Article{}
+	it := s.Articles.IterateAll(50)
+	for it.Next() {
+		remote[it.Article().ID] = it.Article()
+	}
+	return remote, it.Err()
+}
+
+// syncCollections resolves the Collections of the articles by name or ID, creating those missing.
+func (s Syncer) syncCollections(articles []*article, report *Report) (map[string]string, error) {
+	collections := map[string]string{}
+	it := s.HelpCenter.IterateCollections(50)
+	for it.Next() {
+		collection := it.Collection()
+		collections[collection.Name] = collection.ID
+		collections[collection.ID] = collection.ID
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	for _, a := range articles {
+		name := a.doc.Collection
+		if _, exists := collections[name]; name == "" || exists {
+			continue
+		}
+		action := Action{Op: CREATE_COLLECTION, Key: a.key, Title: name}
+		collections[name] = ""
+		if !s.DryRun {
+			var created intercom.Collection
+			if created, action.Err = s.HelpCenter.CreateCollection(&intercom.Collection{Name: name}); action.Err == nil {
+				action.ArticleID = created.ID
+				collections[name] = created.ID
+			}
+		}
+		report.Actions = append(report.Actions, action)
+	}
+	return collections, nil
+}
+
+// article builds the article of Documents, and the hash of their content.
+func (s Syncer) article(a *article, collections map[string]string) (intercom.Article, string) {
+	desired := intercom.Article{
+		Title:       a.doc.Title,
+		Description: a.doc.Description,
+		Body:        a.doc.HTML(),
+		AuthorID:    s.AuthorID,
+		State:       s.state(a.doc),
+	}
+	if id := collections[a.doc.Collection]; id != "" {
+		desired.ParentID, desired.ParentType = id, "collection"
+	}
+	if len(a.translations) > 0 {
+		desired.TranslatedContent = intercom.ArticleTranslations{}
+		for locale, doc := range a.translations {
+			desired.TranslatedContent[locale] = intercom.ArticleContent{
+				Title:       doc.Title,
+				Description: doc.Description,
+				Body:        doc.HTML(),
+				AuthorID:    s.AuthorID,
+				State:       s.state(doc),
+			}
+		}
+	}
+	content, _ := json.Marshal(struct {
+		Article    intercom.Article
+		Collection string
+	}{desired, a.doc.Collection})
+	sum := sha256.Sum256(content)
+	return desired, hex.EncodeToString(sum[:])
+}
+
+// changed reports whether the remote article differs from the desired one, as when it was edited in Intercom since the last sync.
+func changed(desired, remote intercom.Article) bool {
+	if desired.Title != remote.Title || desired.Description != remote.Description || desired.Body != remote.Body || desired.State != remote.State {
+		return true
+	}
+	if desired.ParentID != "" && desired.ParentID != remote.ParentID {
+		return true
+	}
+	for locale, content := range desired.TranslatedContent {
+		current, ok := remote.TranslatedContent[locale]
+		if !ok || content.Title != current.Title || content.Description != current.Description || content.Body != current.Body || content.State != current.State {
+			return true
+		}
+	}
+	return false
+}
+
+// archivedArticle returns the update making an article a draft, in its default locale and every translation.
+func archivedArticle(remote intercom.Article) *intercom.Article {
+	archived := &intercom.Article{ID: remote.ID, State: "draft"}
+	if len(remote.TranslatedContent) > 0 {
+		archived.TranslatedContent = intercom.ArticleTranslations{}
+		for locale := range remote.TranslatedContent {
+			archived.TranslatedContent[locale] = intercom.ArticleContent{State: "draft"}
+		}
+	}
+	return archived
+}
+
+// removed returns the keys of the Manifest without Documents, not yet archived.
+func (s Syncer) removed(articles []*article, manifest Manifest) []string {
+	current := map[string]bool{}
+	for _, a := range articles {
+		current[a.key] = true
+	}
+	keys := []string{}
+	for key, entry := range manifest.Articles {
+		if !current[key] && !entry.Archived {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s Syncer) state(doc Document) string {
+	if doc.State == "" {
+		return s.DefaultState
+	}
+	return doc.State
+}
+
+func sortedLocales(translations map[string]Document) []string {
+	locales := make([]string, 0, len(translations))
+	for locale := range translations {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+func (s Syncer) withDefaults() Syncer {
+	if s.DefaultLocale == "" {
+		s.DefaultLocale = "en"
+	}
+	if s.DefaultState == "" {
+		s.DefaultState = "draft"
+	}
+	return s
+}
@@ -0,0 +1,252 @@
+package helpsync
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	intercom "github.com/stefanoschrs/go-intercom"
+)
+
+// testHelpCenterServer stubs the article and collection endpoints, keeping what was saved.
+type testHelpCenterServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	nextID      int
+	articles    map[string]map[string]interface{}
+	collections []map[string]interface{}
+	writes      []string
+}
+
+func newTestHelpCenterServer() *testHelpCenterServer {
+	s := &testHelpCenterServer{
+		nextID: 100,
+		articles: map[string]map[string]interface{}{
+			"1": {"type": "article", "id": "1", "title": "Invoices", "state": "published"},
+			"2": {"type": "article", "id": "2", "title": "Written in Intercom", "state": "published"},
+		},
+		collections: []map[string]interface{}{{"id": "145", "name": "Onboarding", "help_center_id": 123}},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != http.MethodGet {
+			s.writes = append(s.writes, r.Method+" "+r.URL.Path)
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/articles/")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/articles":
+			ids := []string{}
+			for id := range s.articles {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			articles := []interface{}{}
+			for _, id := range ids {
+				articles = append(articles, s.articles[id])
+			}
+			writeTestList(w, articles)
+		case r.Method == http.MethodPost && r.URL.Path == "/articles":
+			s.nextID++
+			body["type"], body["id"] = "article", strconv.Itoa(s.nextID)
+			s.articles[body["id"].(string)] = body
+			json.NewEncoder(w).Encode(body)
+		case r.Method == http.MethodPut && s.articles[id] != nil:
+			for name, value := range body {
+				s.articles[id][name] = value
+			}
+			json.NewEncoder(w).Encode(s.articles[id])
+		case r.Method == http.MethodDelete && s.articles[id] != nil:
+			delete(s.articles, id)
+			w.Write([]byte(`{"id":"` + id + `","object":"article","deleted":true}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/help_center/collections":
+			collections := []interface{}{}
+			for _, collection := range s.collections {
+				collections = append(collections, collection)
+			}
+			writeTestList(w, collections)
+		case r.Method == http.MethodPost && r.URL.Path == "/help_center/collections":
+			s.nextID++
+			body["id"] = strconv.Itoa(s.nextID)
+			s.collections = append(s.collections, body)
+			json.NewEncoder(w).Encode(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type":"error.list","errors":[{"code":"not_found","message":"Resource Not Found"}]}`))
+		}
+	}))
+	return s
+}
+
+func writeTestList(w http.ResponseWriter, data []interface{}) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":        "list",
+		"data":        data,
+		"total_count": len(data),
+		"pages":       map[string]interface{}{"type": "pages", "page": 1, "per_page": 50, "total_pages": 1},
+	})
+}
+
+func (s *testHelpCenterServer) takeWrites() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writes := s.writes
+	s.writes = nil
+	return writes
+}
+
+func newTestSyncer(server *testHelpCenterServer, manifestPath string) Syncer {
+	ic := intercom.NewClient("appID", "apiKey")
+	ic.Option(intercom.BaseURI(server.URL))
+	return Syncer{Articles: &ic.Articles, HelpCenter: &ic.HelpCenter, AuthorID: 991267834, ManifestPath: manifestPath}
+}
+
+func TestSync(t *testing.T) {
+	server := newTestHelpCenterServer()
+	defer server.Close()
+	docs, err := LoadDirectory("../fixtures/helpdocs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := filepath.Join(t.TempDir(), ".intercom-sync.json")
+	syncer := newTestSyncer(server, manifestPath)
+
+	syncer.DryRun = true
+	report, err := syncer.Sync(docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `Dry run, nothing was changed.
+create collection billing/invoices "Billing"
+update    billing/invoices "Invoices" #1
+create    getting-started "Getting started" [fr]
+1 created, 1 updated, 0 archived, 0 deleted, 0 unchanged
+`
+	if report.String() != expected {
+		t.Errorf("Dry run report was\n%s", report)
+	}
+	if writes := server.takeWrites(); len(writes) != 0 {
+		t.Errorf("Dry run wrote %v", writes)
+	}
+	if _, err := os.Stat(manifestPath); err == nil {
+		t.Errorf("Dry run saved the manifest")
+	}
+
+	syncer.DryRun = false
+	report, err = syncer.Sync(docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Count(CREATE) != 1 || report.Count(UPDATE) != 1 {
+		t.Errorf("Report was\n%s", report)
+	}
+	if writes := strings.Join(server.takeWrites(), ", "); writes != "POST /help_center/collections, PUT /articles/1, POST /articles" {
+		t.Errorf("Sync wrote %s", writes)
+	}
+	created := server.articles["102"]
+	if created["title"] != "Getting started" || created["state"] != "published" || created["parent_id"] != 145.0 || created["author_id"] != 991267834.0 {
+		t.Errorf("Created article was %v", created)
+	}
+	if !strings.HasPrefix(created["body"].(string), "<h1>Welcome</h1>\n<p>Install the <strong>Example</strong> app, then:</p>\n<ol>") {
+		t.Errorf("Body was %s", created["body"])
+	}
+	if fr := created["translated_content"].(map[string]interface{})["fr"].(map[string]interface{}); fr["title"] != "Premiers pas" || fr["body"] != "<p>Installez l&#39;application <em>Example</em>.</p>" {
+		t.Errorf("Translation was %v", fr)
+	}
+	if invoices := server.articles["1"]; invoices["parent_id"] != 101.0 || invoices["state"] != "draft" {
+		t.Errorf("Updated article was %v", invoices)
+	}
+	manifest, _ := LoadManifest(manifestPath)
+	if manifest.Articles["getting-started"].ID != "102" || manifest.Articles["billing/invoices"].ID != "1" {
+		t.Errorf("Manifest was %+v", manifest)
+	}
+
+	report, _ = syncer.Sync(docs)
+	if report.Count(UNCHANGED) != 2 || len(server.takeWrites()) != 0 {
+		t.Errorf("Resync report was\n%s", report)
+	}
+
+	server.articles["1"]["title"] = "Edited in Intercom"
+	report, _ = syncer.Sync(docs)
+	if report.Count(UPDATE) != 1 || server.articles["1"]["title"] != "Invoices" {
+		t.Errorf("Articles edited in Intercom should be updated, report was\n%s", report)
+	}
+	server.takeWrites()
+
+	docs[0].Markdown = "Invoices are sent monthly."
+	report, _ = syncer.Sync(docs[:1])
+	expected = `update    billing/invoices "Invoices" #1
+archive   getting-started "Getting started" #102
+0 created, 1 updated, 1 archived, 0 deleted, 0 unchanged
+`
+	if report.String() != expected {
+		t.Errorf("Report was\n%s", report)
+	}
+	if server.articles["102"]["state"] != "draft" {
+		t.Errorf("Archived article was %v", server.articles["102"])
+	}
+	if fr := server.articles["102"]["translated_content"].(map[string]interface{})["fr"].(map[string]interface{}); len(fr) != 1 || fr["state"] != "draft" {
+		t.Errorf("Archived translation was %v", fr)
+	}
+	server.takeWrites()
+
+	report, _ = syncer.Sync(docs[:1])
+	if report.Count(UNCHANGED) != 1 || len(report.Actions) != 1 || len(server.takeWrites()) != 0 {
+		t.Errorf("Archived articles should be archived once, report was\n%s", report)
+	}
+
+	report, _ = syncer.Sync(docs)
+	if report.Count(UPDATE) != 1 || server.articles["102"]["state"] != "published" {
+		t.Errorf("Restoring a document should update its article, report was\n%s", report)
+	}
+	server.takeWrites()
+	if server.articles["2"] == nil {
+		t.Errorf("Articles not synced should be left alone")
+	}
+}
+
+func TestSyncDelete(t *testing.T) {
+	server := newTestHelpCenterServer()
+	defer server.Close()
+	manifestPath := filepath.Join(t.TempDir(), ".intercom-sync.json")
+	os.WriteFile(manifestPath, []byte(`{"articles":{"pricing":{"id":"2","hash":"x"},"gone":{"id":"404","hash":"x"}}}`), 0o600)
+	syncer := newTestSyncer(server, manifestPath)
+	syncer.Delete = true
+
+	report, err := syncer.Sync(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Actions) != 1 || report.Actions[0].Op != DELETE || server.articles["2"] != nil {
+		t.Errorf("Report was\n%s", report)
+	}
+	if manifest, _ := LoadManifest(manifestPath); len(manifest.Articles) != 0 {
+		t.Errorf("Manifest was %+v", manifest)
+	}
+}
+
+func TestSyncErrors(t *testing.T) {
+	server := newTestHelpCenterServer()
+	defer server.Close()
+	syncer := newTestSyncer(server, "")
+
+	if _, err := syncer.Sync([]Document{{Path: "a.fr.md", Key: "a", Title: "A", Locale: "fr"}}); err == nil || !strings.Contains(err.Error(), "no en document") {
+		t.Errorf("Translations without a default document returned %v", err)
+	}
+	if _, err := syncer.Sync([]Document{{Path: "a.md", Key: "a", Title: "A"}, {Path: "b.md", Key: "a", Title: "B"}}); err == nil || !strings.Contains(err.Error(), "same key") {
+		t.Errorf("Documents with the same key returned %v", err)
+	}
+	syncer.AuthorID = 0
+	if _, err := syncer.Sync(nil); err == nil {
+		t.Errorf("Syncing without an author should fail")
+	}
+}
@@ -0,0 +1,412 @@
+package intercom
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/stefanoschrs/go-intercom/internal/safeurl"
+)
+
+// htmlToken is a token of the HTML fragments Intercom uses for message bodies.
+type htmlToken struct {
+	text  string // unescaped text, for text tokens
+	tag   string // lower case tag name, for tags
+	end   bool
+	attrs map[string]string
+}
+
+// tokenizeHTML splits an HTML fragment into text and tag tokens.
+// It is lenient rather than complete: comments are dropped, as is the content of script and style elements.
+func tokenizeHTML(fragment string) []htmlToken {
+	tokens := []htmlToken{}
+	for len(fragment) > 0 {
+		start := strings.IndexByte(fragment, '<')
+		if start < 0 {
+			tokens = append(tokens, htmlToken{text: html.UnescapeString(fragment)})
+			break
+		}
+		if start > 0 {
+			tokens = append(tokens, htmlToken{text: html.UnescapeString(fragment[:start])})
+			fragment = fragment[start:]
+		}
+		if strings.HasPrefix(fragment, "<!--") {
+			end := strings.Index(fragment, "-->")
+			if end < 0 {
+				break
+			}
+			fragment = fragment[end+3:]
+			continue
+		}
+		end := htmlTagEnd(fragment)
+		if end < 0 {
+			tokens = append(tokens, htmlToken{text: html.UnescapeString(fragment)})
+			break
+		}
+		token, ok := parseHTMLTag(fragment[1:end])
+		fragment = fragment[end+1:]
+		if !ok {
+			continue
+		}
+		if !token.end && (token.tag == "script" || token.tag == "style") {
+			closing := strings.Index(strings.ToLower(fragment), "</"+token.tag)
+			if closing < 0 {
+				break
+			}
+			fragment = fragment[closing:]
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// htmlTagEnd returns the index of the '>' closing the tag at the start of fragment, skipping quoted attribute values.
+func htmlTagEnd(fragment string) int {
+	for i := 1; i < len(fragment); i++ {
+		switch fragment[i] {
+		case '>':
+			return i
+		case '"', '\'':
+			// Quotes only delimit values: after an '=', ignoring whitespace.
+			if before := strings.TrimRight(fragment[:i], " \t\n\r"); !strings.HasSuffix(before, "=") {
+				continue
+			}
+			end := strings.IndexByte(fragment[i+1:], fragment[i])
+			if end < 0 {
+				return -1
+			}
+			i += end + 1
+		}
+	}
+	return -1
+}
+
+func parseHTMLTag(raw string) (htmlToken, bool) {
+	token := htmlToken{attrs: map[string]string{}}
+	raw = strings.TrimSuffix(strings.TrimSpace(raw), "/")
+	if strings.HasPrefix(raw, "/") {
+		token.end = true
+		raw = raw[1:]
+	}
+	name := raw
+	if i := strings.IndexAny(raw, " \t\n\r"); i >= 0 {
+		name, raw = raw[:i], raw[i:]
+	} else {
+		raw = ""
+	}
+	token.tag = strings.ToLower(name)
+	if token.tag == "" || strings.HasPrefix(token.tag, "!") || strings.HasPrefix(token.tag, "?") {
+		return token, false
+	}
+	for {
+		raw = strings.TrimLeft(raw, " \t\n\r")
+		if raw == "" {
+			break
+		}
+		i := strings.IndexAny(raw, "= \t\n\r")
+		if i < 0 {
+			token.attrs[strings.ToLower(raw)] = ""
+			break
+		}
+		key := strings.ToLower(raw[:i])
+		raw = strings.TrimLeft(raw[i:], " \t\n\r")
+		if !strings.HasPrefix(raw, "=") {
+			token.attrs[key] = ""
+			continue
+		}
+		raw = strings.TrimLeft(raw[1:], " \t\n\r")
+		value := raw
+		if raw != "" && (raw[0] == '"' || raw[0] == '\'') {
+			if end := strings.IndexByte(raw[1:], raw[0]); end >= 0 {
+				value, raw = raw[1:end+1], raw[end+2:]
+			} else {
+				value, raw = raw[1:], ""
+			}
+		} else if end := strings.IndexAny(raw, " \t\n\r"); end >= 0 {
+			value, raw = raw[:end], raw[end:]
+		} else {
+			raw = ""
+		}
+		token.attrs[key] = html.UnescapeString(value)
+	}
+	return token, true
+}
+
+var (
+	htmlBlockTags = map[string]bool{
+		"p": true, "div": true, "ul": true, "ol": true, "li": true, "pre": true, "blockquote": true,
+		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	}
+	htmlAllowedTags = map[string]bool{
+		"p": true, "br": true, "b": true, "strong": true, "i": true, "em": true, "u": true, "a": true,
+		"ul": true, "ol": true, "li": true, "code": true, "pre": true, "blockquote": true, "img": true,
+		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	}
+	htmlVoidTags = map[string]bool{"br": true, "img": true}
+)
+
+// sanitizeHTML keeps the formatting tags of an HTML fragment, and only http(s) and mailto links and http(s) images.
+// Other tags are dropped keeping their text, all attributes but href, src and alt are dropped,
+// and the result is always balanced.
+func sanitizeHTML(fragment string) string {
+	out := &strings.Builder{}
+	open := []string{}
+	for _, token := range tokenizeHTML(fragment) {
+		switch {
+		case token.tag == "":
+			out.WriteString(html.EscapeString(token.text))
+		case !htmlAllowedTags[token.tag]:
+		case token.end:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.tag {
+					for j := len(open) - 1; j >= i; j-- {
+						out.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		case token.tag == "a":
+			out.WriteString("<a")
+			if href := token.attrs["href"]; safeurl.Allowed(href, true) {
+				out.WriteString(` href="` + html.EscapeString(href) + `" rel="nofollow noopener"`)
+			}
+			out.WriteString(">")
+			open = append(open, "a")
+		case token.tag == "img":
+			if src := token.attrs["src"]; safeurl.Allowed(src, false) {
+				out.WriteString(`<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(token.attrs["alt"]) + `">`)
+			}
+		case htmlVoidTags[token.tag]:
+			out.WriteString("<" + token.tag + ">")
+		default:
+			out.WriteString("<" + token.tag + ">")
+			open = append(open, token.tag)
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+	return out.String()
+}
+
+// htmlToText converts an HTML fragment to plain text, or to Markdown.
+func htmlToText(fragment string, markdown bool) string {
+	w := &textWriter{markdown: markdown, lineStart: true}
+	lists := []int{}
+	type link struct {
+		href  string
+		start int
+	}
+	links := []link{}
+	for _, token := range tokenizeHTML(fragment) {
+		if token.tag == "" {
+			w.text(token.text)
+			continue
+		}
+		if htmlBlockTags[token.tag] && token.tag != "li" && token.tag != "pre" {
+			w.block()
+		}
+		switch token.tag {
+		case "br":
+			w.line()
+		case "ul", "ol":
+			if !token.end {
+				number := 0
+				if token.tag == "ol" {
+					number = 1
+				}
+				lists = append(lists, number)
+			} else if len(lists) > 0 {
+				lists = lists[:len(lists)-1]
+			}
+		case "li":
+			if token.end {
+				continue
+			}
+			w.line()
+			marker := "- "
+			depth := 0
+			if n := len(lists); n > 0 {
+				depth = n - 1
+				if lists[n-1] > 0 {
+					marker = strconv.Itoa(lists[n-1]) + ". "
+					lists[n-1]++
+				}
+			}
+			w.raw(strings.Repeat("  ", depth) + marker)
+		case "pre":
+			if !token.end {
+				w.block()
+				if markdown {
+					w.raw("```")
+					w.line()
+				}
+				w.pre = true
+			} else {
+				w.pre = false
+				if markdown {
+					w.line()
+					w.raw("```")
+				}
+				w.block()
+			}
+		case "b", "strong":
+			w.markup("**")
+		case "i", "em":
+			w.markup("_")
+		case "code":
+			if !w.pre {
+				w.markup("`")
+			}
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			if markdown && !token.end {
+				w.raw(strings.Repeat("#", int(token.tag[1]-'0')) + " ")
+			}
+		case "a":
+			// Links to unsafe URLs, such as javascript:, keep only their label.
+			if !token.end {
+				href := strings.TrimSpace(token.attrs["href"])
+				if !safeurl.Allowed(href, true) {
+					href = ""
+				}
+				links = append(links, link{href: href, start: w.out.Len()})
+				if href != "" {
+					w.markup("[")
+				}
+			} else if len(links) > 0 {
+				l := links[len(links)-1]
+				links = links[:len(links)-1]
+				switch {
+				case l.href == "":
+				case markdown:
+					w.raw("](" + escapeMarkdownURL(l.href) + ")")
+				case strings.TrimSpace(w.out.String()[l.start:]) != l.href:
+					w.text(" (" + l.href + ")")
+				}
+			}
+		case "img":
+			src, alt := strings.TrimSpace(token.attrs["src"]), token.attrs["alt"]
+			switch {
+			case markdown && safeurl.Allowed(src, false):
+				w.raw("![" + escapeMarkdown(alt) + "](" + escapeMarkdownURL(src) + ")")
+			case markdown:
+				w.text(alt)
+			case alt != "":
+				w.text("[" + alt + "]")
+			}
+		}
+	}
+	return w.String()
+}
+
+// textWriter collapses whitespace and breaks the way a browser renders them.
+type textWriter struct {
+	out       strings.Builder
+	markdown  bool
+	pre       bool
+	pending   string // line or paragraph break to write before the next text
+	space     bool   // space to write before the next text
+	lineStart bool
+}
+
+func (w *textWriter) text(s string) {
+	if s == "" {
+		return
+	}
+	if w.pre {
+		w.flush()
+		w.out.WriteString(s)
+		w.lineStart = strings.HasSuffix(s, "\n")
+		return
+	}
+	if isHTMLSpace(s[0]) {
+		w.space = true
+	}
+	for i, field := range strings.Fields(s) {
+		if i > 0 {
+			w.space = true
+		}
+		w.flush()
+		if w.markdown {
+			field = escapeMarkdown(field)
+			if w.lineStart {
+				field = escapeMarkdownLineStart(field)
+			}
+		}
+		w.out.WriteString(field)
+		w.lineStart = false
+	}
+	if isHTMLSpace(s[len(s)-1]) {
+		w.space = true
+	}
+}
+
+func (w *textWriter) markup(s string) {
+	if w.markdown {
+		w.raw(s)
+	}
+}
+
+func (w *textWriter) raw(s string) {
+	w.flush()
+	w.out.WriteString(s)
+	w.lineStart = false
+}
+
+func (w *textWriter) block() {
+	if w.out.Len() > 0 {
+		w.pending = "\n\n"
+	}
+}
+
+func (w *textWriter) line() {
+	if w.out.Len() > 0 && w.pending == "" {
+		w.pending = "\n"
+	}
+}
+
+func (w *textWriter) flush() {
+	switch {
+	case w.pending != "":
+		w.out.WriteString(w.pending)
+		w.pending = ""
+		w.lineStart = true
+	case w.space && !w.lineStart:
+		w.out.WriteByte(' ')
+	}
+	w.space = false
+}
+
+func (w *textWriter) String() string {
+	return strings.TrimSpace(w.out.String())
+}
+
+func isHTMLSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var markdownURLEscaper = strings.NewReplacer(`\`, `%5C`, "(", `%28`, ")", `%29`, " ", `%20`, "\t", `%09`, "\n", `%0A`, "\r", `%0D`, "<", `%3C`, ">", `%3E`)
+
+// escapeMarkdownURL escapes a URL for a Markdown link destination, which ends at a ')' or whitespace.
+func escapeMarkdownURL(u string) string {
+	return markdownURLEscaper.Replace(u)
+}
+
+// escapeMarkdownLineStart escapes text which Markdown would read as a heading, quote or list at the start of a line.
+func escapeMarkdownLineStart(s string) string {
+	if s == "-" || s == "+" || strings.HasPrefix(s, "#") || strings.HasPrefix(s, ">") {
+		return `\` + s
+	}
+	if i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }); i > 0 && (s[i:] == "." || s[i:] == ")") {
+		return s[:i] + `\` + s[i:]
+	}
+	return s
+}
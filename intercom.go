@@ -8,29 +8,41 @@ import (
 type Client struct {
 	// Services for interacting with various resources in Intercom.
 	Admins        AdminService
+	Articles      ArticleService
 	Companies     CompanyService
 	Contacts      ContactService
 	Conversations ConversationService
 	Events        EventService
+	Exports       ExportService
 	Jobs          JobService
 	Messages      MessageService
+	News          NewsService
 	Segments      SegmentService
+	Subscriptions SubscriptionService
 	Tags          TagService
 	Users         UserService
+	Visitors      VisitorService
 	DataAttribute DataAttributeService
+	HelpCenter    HelpCenterService
 
 	// Mappings for resources to API constructs
 	AdminRepository         AdminRepository
+	ArticleRepository       ArticleRepository
 	CompanyRepository       CompanyRepository
 	ContactRepository       ContactRepository
 	ConversationRepository  ConversationRepository
 	EventRepository         EventRepository
+	ExportRepository        ExportRepository
 	JobRepository           JobRepository
 	MessageRepository       MessageRepository
+	NewsRepository          NewsRepository
 	SegmentRepository       SegmentRepository
+	SubscriptionRepository  SubscriptionRepository
 	TagRepository           TagRepository
 	UserRepository          UserRepository
+	VisitorRepository       VisitorRepository
 	DataAttributeRepository DataAttributeRepository
+	HelpCenterRepository    HelpCenterRepository
 
 	// AppID For Intercom.
 	AppID string
@@ -141,26 +153,38 @@ func SetHTTPClient(httpClient interfaces.HTTPClient) option {
 
 func (c *Client) setup() {
 	c.AdminRepository = AdminAPI{httpClient: c.HTTPClient}
+	c.ArticleRepository = ArticleAPI{httpClient: c.HTTPClient}
 	c.CompanyRepository = CompanyAPI{httpClient: c.HTTPClient}
 	c.ContactRepository = ContactAPI{httpClient: c.HTTPClient}
 	c.ConversationRepository = ConversationAPI{httpClient: c.HTTPClient}
 	c.EventRepository = EventAPI{httpClient: c.HTTPClient}
+	c.ExportRepository = ExportAPI{httpClient: c.HTTPClient}
 	c.JobRepository = JobAPI{httpClient: c.HTTPClient}
 	c.MessageRepository = MessageAPI{httpClient: c.HTTPClient}
+	c.NewsRepository = NewsAPI{httpClient: c.HTTPClient}
 	c.SegmentRepository = SegmentAPI{httpClient: c.HTTPClient}
+	c.SubscriptionRepository = SubscriptionAPI{httpClient: c.HTTPClient}
 	c.TagRepository = TagAPI{httpClient: c.HTTPClient}
 	c.UserRepository = UserAPI{httpClient: c.HTTPClient}
+	c.VisitorRepository = VisitorAPI{httpClient: c.HTTPClient}
 	c.DataAttributeRepository = DataAttributeAPI{httpClient: c.HTTPClient}
+	c.HelpCenterRepository = HelpCenterAPI{httpClient: c.HTTPClient}
 
 	c.Admins = AdminService{Repository: c.AdminRepository}
+	c.Articles = ArticleService{Repository: c.ArticleRepository}
 	c.Companies = CompanyService{Repository: c.CompanyRepository}
 	c.Contacts = ContactService{Repository: c.ContactRepository}
-	c.Conversations = ConversationService{Repository: c.ConversationRepository}
+	c.Conversations = ConversationService{Repository: c.ConversationRepository, AttachmentTypes: c.Conversations.AttachmentTypes}
 	c.Events = EventService{Repository: c.EventRepository}
+	c.Exports = ExportService{Repository: c.ExportRepository}
 	c.Jobs = JobService{Repository: c.JobRepository}
 	c.Messages = MessageService{Repository: c.MessageRepository}
+	c.News = NewsService{Repository: c.NewsRepository}
 	c.Segments = SegmentService{Repository: c.SegmentRepository}
+	c.Subscriptions = SubscriptionService{Repository: c.SubscriptionRepository}
 	c.Tags = TagService{Repository: c.TagRepository}
 	c.Users = UserService{Repository: c.UserRepository}
+	c.Visitors = VisitorService{Repository: c.VisitorRepository}
 	c.DataAttribute = DataAttributeService{Repository: c.DataAttributeRepository}
+	c.HelpCenter = HelpCenterService{Repository: c.HelpCenterRepository}
 }
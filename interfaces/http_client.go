@@ -16,6 +16,13 @@ type HTTPClient interface {
 	Put(string, interface{}) ([]byte, error)
 	Patch(string, interface{}) ([]byte, error)
 	Delete(string, interface{}) ([]byte, error)
+	DeleteWithBody(string, interface{}) ([]byte, error)
+}
+
+// An HTTPStreamer streams responses rather than reading them whole, such as file downloads.
+// HTTPClients may implement it; IntercomHTTPClient does.
+type HTTPStreamer interface {
+	GetStream(url string, accept string) (io.ReadCloser, error)
 }
 
 type IntercomHTTPClient struct {
@@ -78,6 +85,34 @@ func (c IntercomHTTPClient) Get(url string, queryParams interface{}) ([]byte, er
 	return data, err
 }
 
+// GetStream gets a response accepting the given media type, returning its body to be read and closed by the caller.
+func (c IntercomHTTPClient) GetStream(url string, accept string) (io.ReadCloser, error) {
+	// Setup request
+	req, _ := http.NewRequest(http.MethodGet, *c.BaseURI+url, nil)
+	req.Header.Add("Authorization", "Bearer "+c.APIKey)
+	req.Header.Add("Accept", accept)
+	req.Header.Add("User-Agent", c.UserAgentHeader())
+	req.Header.Add("Intercom-Version", *c.APIVersion)
+	if *c.Debug {
+		fmt.Printf("%s %s\n", req.Method, req.URL)
+	}
+
+	// Do request
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		data, err := c.readAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.parseResponseError(data, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
 func addQueryParams(req *http.Request, params interface{}) {
 	v, _ := query.Values(params)
 	req.URL.RawQuery = v.Encode()
@@ -95,6 +130,11 @@ func (c IntercomHTTPClient) Post(url string, body interface{}) ([]byte, error) {
 	return c.postOrPatchOrPut(http.MethodPost, url, body)
 }
 
+// DeleteWithBody sends a DELETE with a JSON body, for the endpoints reading their parameters from it.
+func (c IntercomHTTPClient) DeleteWithBody(url string, body interface{}) ([]byte, error) {
+	return c.postOrPatchOrPut(http.MethodDelete, url, body)
+}
+
 func (c IntercomHTTPClient) postOrPatchOrPut(method, url string, body interface{}) ([]byte, error) {
 	// Marshal our body
 	buffer := bytes.NewBuffer([]byte{})
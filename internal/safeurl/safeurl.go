@@ -0,0 +1,12 @@
+// Package safeurl decides which URLs may be linked to from generated HTML and Markdown.
+package safeurl
+
+import "strings"
+
+// Allowed reports whether u is an http(s) URL, or a mailto URL when allowMailto is set.
+// Other schemes, such as javascript: and data:, and relative URLs are not allowed.
+func Allowed(u string, allowMailto bool) bool {
+	lower := strings.ToLower(strings.TrimSpace(u))
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") ||
+		(allowMailto && strings.HasPrefix(lower, "mailto:"))
+}
@@ -1,6 +1,11 @@
 package intercom
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // JobService builds jobs to process
 type JobService struct {
@@ -15,6 +20,7 @@ const (
 	RUNNING
 	COMPLETED
 	FAILED
+	COMPLETED_WITH_ERRORS
 )
 
 var jobStates = [...]string{
@@ -22,6 +28,7 @@ var jobStates = [...]string{
 	"running",
 	"completed",
 	"failed",
+	"completed_with_errors",
 }
 
 // A JobRequest represents a new job to be sent to Intercom
@@ -45,6 +52,41 @@ type JobResponse struct {
 	Links       map[string]string `json:"links,omitempty"`
 }
 
+// JobErrorFeed lists the items of a Job which failed to process
+type JobErrorFeed struct {
+	ID    string         `json:"id,omitempty"`
+	AppID string         `json:"app_id,omitempty"`
+	Items []JobErrorItem `json:"items"`
+	Pages JobErrorPages  `json:"pages"`
+}
+
+// JobErrorPages holds the paging information of a JobErrorFeed
+type JobErrorPages struct {
+	Next string `json:"next,omitempty"`
+}
+
+// A JobErrorItem is an item of a Job which failed to process, echoing the data submitted
+type JobErrorItem struct {
+	Method   string          `json:"method,omitempty"`
+	DataType string          `json:"data_type,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Error    JobItemError    `json:"error"`
+}
+
+// JobItemError describes why a JobItem failed
+type JobItemError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// A JobItemFailure maps a JobErrorItem back to the submitted JobItem.
+// Item is nil, and Index -1, when the failure could not be matched.
+type JobItemFailure struct {
+	Index int
+	Item  *JobItem
+	JobErrorItem
+}
+
 // JobData is a payload that can be used to identify an existing Job to append to.
 type JobData struct {
 	ID string `json:"id,omitempty"`
@@ -79,6 +121,111 @@ func (js *JobService) Find(id string) (JobResponse, error) {
 	return js.Repository.find(id)
 }
 
+// WaitForCompletion polls a Job every pollInterval until it is completed or failed, or ctx is done.
+// A pollInterval of 0 or less polls every 5 seconds.
+// A failed Job is returned without an error; check its JobState.
+func (js *JobService) WaitForCompletion(ctx context.Context, id string, pollInterval time.Duration) (JobResponse, error) {
+	var job JobResponse
+	err := pollUntil(ctx, pollInterval, func() (bool, error) {
+		var err error
+		if job, err = js.Find(id); err != nil {
+			return false, err
+		}
+		state, err := job.JobState()
+		return state.Finished(), err
+	})
+	return job, err
+}
+
+const defaultPollInterval = 5 * time.Second
+
+// pollUntil calls check every interval, or defaultPollInterval, until it is done or fails, or ctx is done.
+func pollUntil(ctx context.Context, interval time.Duration, check func() (done bool, err error)) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if done, err := check(); done || err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Errors fetches every page of the error feed of a Job.
+func (js *JobService) Errors(id string) ([]JobErrorItem, error) {
+	feed, err := js.Repository.errors(id)
+	items := feed.Items
+	for err == nil && feed.Pages.Next != "" {
+		feed, err = js.Repository.errorsNext(feed.Pages.Next)
+		items = append(items, feed.Items...)
+	}
+	return items, err
+}
+
+// Failures fetches the error feed of a Job and maps each failure back to the JobItem submitted,
+// matching on the data type, method and identifiers (id, user_id, email, and for Events event_name and created_at).
+func (js *JobService) Failures(id string, items []*JobItem) ([]JobItemFailure, error) {
+	errorItems, err := js.Errors(id)
+	if err != nil {
+		return nil, err
+	}
+	return MatchJobFailures(errorItems, items), nil
+}
+
+// MatchJobFailures maps JobErrorItems back to the JobItems submitted.
+func MatchJobFailures(errorItems []JobErrorItem, items []*JobItem) []JobItemFailure {
+	submitted := map[string][]int{}
+	for i, item := range items {
+		data, err := json.Marshal(requestJobItemData(item.Data))
+		if err != nil {
+			continue
+		}
+		key := jobItemKey(item.DataType, item.Method, data)
+		submitted[key] = append(submitted[key], i)
+	}
+	failures := make([]JobItemFailure, 0, len(errorItems))
+	for _, errorItem := range errorItems {
+		failure := JobItemFailure{Index: -1, JobErrorItem: errorItem}
+		key := jobItemKey(errorItem.DataType, errorItem.Method, errorItem.Data)
+		if indexes := submitted[key]; len(indexes) > 0 {
+			failure.Index = indexes[0]
+			failure.Item = items[indexes[0]]
+			submitted[key] = indexes[1:]
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+func jobItemKey(dataType, method string, data []byte) string {
+	fields := map[string]interface{}{}
+	json.Unmarshal(data, &fields)
+	if method == "" {
+		method = JOB_POST.String()
+	}
+	key := dataType + "|" + method
+	names := []string{"id", "user_id", "email"}
+	if dataType == "event" {
+		names = append(names, "event_name", "created_at")
+	}
+	for _, name := range names {
+		key += fmt.Sprintf("|%v", fields[name])
+	}
+	return key
+}
+
+// JobState parses the State of the Job.
+func (j JobResponse) JobState() (JobState, error) {
+	return ParseJobState(j.State)
+}
+
 func (j JobResponse) String() string {
 	return fmt.Sprintf("[intercom] job { id: %s, name: %s}", j.ID, j.Name)
 }
@@ -86,3 +233,18 @@ func (j JobResponse) String() string {
 func (state JobState) String() string {
 	return jobStates[state]
 }
+
+// ParseJobState parses a job_state returned by the API.
+func ParseJobState(state string) (JobState, error) {
+	for i, s := range jobStates {
+		if s == state {
+			return JobState(i), nil
+		}
+	}
+	return PENDING, fmt.Errorf("Unknown Job State %q", state)
+}
+
+// Finished reports whether a Job in this state is done processing.
+func (state JobState) Finished() bool {
+	return state == COMPLETED || state == COMPLETED_WITH_ERRORS || state == FAILED
+}
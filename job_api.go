@@ -11,6 +11,8 @@ import (
 type JobRepository interface {
 	save(job *JobRequest) (JobResponse, error)
 	find(id string) (JobResponse, error)
+	errors(id string) (JobErrorFeed, error)
+	errorsNext(next string) (JobErrorFeed, error)
 }
 
 // JobAPI implements TagRepository
@@ -19,16 +21,12 @@ type JobAPI struct {
 }
 
 func (api JobAPI) save(job *JobRequest) (JobResponse, error) {
-	for i := range job.Items {
-		obj := job.Items[i].Data
-		switch obj.(type) {
-		case *User:
-			user := obj.(*User)
-			job.Items[i].Data = RequestUserMapper{}.ConvertUser(user)
-		}
+	request := JobRequest{JobData: job.JobData, Items: make([]*JobItem, len(job.Items)), bulkType: job.bulkType}
+	for i, item := range job.Items {
+		request.Items[i] = &JobItem{Method: item.Method, DataType: item.DataType, Data: requestJobItemData(item.Data)}
 	}
 	savedJob := JobResponse{}
-	data, err := api.httpClient.Post(fmt.Sprintf("/bulk/%s", job.bulkType), job)
+	data, err := api.httpClient.Post(fmt.Sprintf("/bulk/%s", job.bulkType), &request)
 	if err != nil {
 		return savedJob, err
 	}
@@ -45,3 +43,24 @@ func (api JobAPI) find(id string) (JobResponse, error) {
 	err = json.Unmarshal(data, &fetchedJob)
 	return fetchedJob, err
 }
+
+func (api JobAPI) errors(id string) (JobErrorFeed, error) {
+	return unmarshalToJobErrorFeed(api.httpClient.Get(fmt.Sprintf("/jobs/%s/error", id), nil))
+}
+
+func (api JobAPI) errorsNext(next string) (JobErrorFeed, error) {
+	path, params, err := parsePageURL(next)
+	if err != nil {
+		return JobErrorFeed{}, err
+	}
+	return unmarshalToJobErrorFeed(api.httpClient.Get(path, params))
+}
+
+func unmarshalToJobErrorFeed(data []byte, err error) (JobErrorFeed, error) {
+	feed := JobErrorFeed{}
+	if err != nil {
+		return feed, err
+	}
+	err = json.Unmarshal(data, &feed)
+	return feed, err
+}
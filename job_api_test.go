@@ -22,6 +22,9 @@ func TestJobAPISaveUser(t *testing.T) {
 	if savedJob.ID != "job_5ca1ab1eca11ab1e" {
 		t.Errorf("Did not respond with correct job")
 	}
+	if job.Items[0].Data != &user {
+		t.Errorf("job item data was modified")
+	}
 }
 
 func TestJobAPISaveEvent(t *testing.T) {
@@ -43,6 +46,33 @@ func TestJobAPISaveEvent(t *testing.T) {
 	}
 }
 
+func TestJobAPIErrors(t *testing.T) {
+	http := TestJobHTTPClient{t: t, expectedURI: "/jobs/job_5ca1ab1eca11ab1e/error", fixtureFilename: "fixtures/job_errors.json"}
+	api := JobAPI{httpClient: &http}
+	feed, err := api.errors("job_5ca1ab1eca11ab1e")
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if len(feed.Items) != 1 || feed.Items[0].Error.Code != "parameter_invalid" {
+		t.Errorf("Error items were %v", feed.Items)
+	}
+	if feed.Pages.Next != "https://api.intercom.io/jobs/job_5ca1ab1eca11ab1e/error?page=2" {
+		t.Errorf("Next page was %s", feed.Pages.Next)
+	}
+}
+
+func TestJobAPIErrorsNext(t *testing.T) {
+	http := TestJobHTTPClient{t: t, expectedURI: "/jobs/job_5ca1ab1eca11ab1e/error", fixtureFilename: "fixtures/job_errors_page_2.json"}
+	api := JobAPI{httpClient: &http}
+	feed, err := api.errorsNext("https://api.intercom.io/jobs/job_5ca1ab1eca11ab1e/error?page=2")
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if len(feed.Items) != 1 || feed.Items[0].DataType != "event" || feed.Pages.Next != "" {
+		t.Errorf("Last page was %v", feed)
+	}
+}
+
 type TestJobHTTPClient struct {
 	TestHTTPClient
 	t               *testing.T
@@ -60,3 +90,10 @@ func (t *TestJobHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
 	}
 	return ioutil.ReadFile(t.fixtureFilename)
 }
+
+func (t *TestJobHTTPClient) Get(uri string, queryParams interface{}) ([]byte, error) {
+	if t.expectedURI != uri {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
+}
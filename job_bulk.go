@@ -0,0 +1,171 @@
+package intercom
+
+import "time"
+
+// BulkWriterConfig configures a BulkWriter; the zero value sends the largest chunks the API accepts.
+type BulkWriterConfig struct {
+	ChunkSize     int           // Items sent per request. Defaults to 100, the most the API accepts.
+	ClosingMargin time.Duration // A Job closing within this margin is not appended to. Defaults to 1 minute.
+	now           func() time.Time
+}
+
+// A BulkReport aggregates what a BulkWriter sent.
+type BulkReport struct {
+	Jobs   []JobResponse // The Jobs written to, as last returned by the API.
+	Chunks int           // Requests sent successfully.
+	Items  int           // Items sent successfully.
+	Failed []*JobItem    // Items whose request failed; they are not retried.
+}
+
+// A BulkWriter splits a stream of JobItems into chunks the API accepts,
+// appending them to the same Job until it closes, and then opening a new one.
+//
+//	writer := ic.Jobs.NewUserBulkWriter(intercom.BulkWriterConfig{})
+//	for _, user := range users {
+//		if err := writer.Write(intercom.NewUserJobItem(user, intercom.JOB_POST)); err != nil {
+//			...
+//		}
+//	}
+//	report, err := writer.Close()
+//
+// A BulkWriter is not safe for concurrent use.
+type BulkWriter struct {
+	jobs     *JobService
+	bulkType string
+	config   BulkWriterConfig
+	buffer   []*JobItem
+	report   BulkReport
+}
+
+// NewUserBulkWriter creates a BulkWriter for User JobItems.
+func (js *JobService) NewUserBulkWriter(config BulkWriterConfig) *BulkWriter {
+	return &BulkWriter{jobs: js, bulkType: "users", config: config.withDefaults()}
+}
+
+// NewEventBulkWriter creates a BulkWriter for Event JobItems.
+func (js *JobService) NewEventBulkWriter(config BulkWriterConfig) *BulkWriter {
+	return &BulkWriter{jobs: js, bulkType: "events", config: config.withDefaults()}
+}
+
+// BulkUsers sends any number of User JobItems in chunks, returning the aggregate BulkReport.
+func (js *JobService) BulkUsers(items []*JobItem, config BulkWriterConfig) (BulkReport, error) {
+	return js.NewUserBulkWriter(config).writeAll(items)
+}
+
+// BulkEvents sends any number of Event JobItems in chunks, returning the aggregate BulkReport.
+func (js *JobService) BulkEvents(items []*JobItem, config BulkWriterConfig) (BulkReport, error) {
+	return js.NewEventBulkWriter(config).writeAll(items)
+}
+
+// Write buffers JobItems, sending every full chunk.
+// The JobItems are not modified. If a request fails, its chunk is added to the Failed items of the report
+// and the error returned; later writes carry on.
+func (w *BulkWriter) Write(items ...*JobItem) error {
+	w.buffer = append(w.buffer, items...)
+	for len(w.buffer) >= w.config.ChunkSize {
+		chunk := w.buffer[:w.config.ChunkSize:w.config.ChunkSize]
+		w.buffer = w.buffer[w.config.ChunkSize:]
+		if err := w.send(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush sends the buffered JobItems, even if they do not fill a chunk.
+func (w *BulkWriter) Flush() error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+	chunk := w.buffer
+	w.buffer = nil
+	return w.send(chunk)
+}
+
+// Close flushes the BulkWriter and returns the BulkReport.
+func (w *BulkWriter) Close() (BulkReport, error) {
+	err := w.Flush()
+	return w.Report(), err
+}
+
+// Report returns the BulkReport of what was sent so far.
+func (w *BulkWriter) Report() BulkReport {
+	report := w.report
+	report.Jobs = append([]JobResponse(nil), w.report.Jobs...)
+	report.Failed = append([]*JobItem(nil), w.report.Failed...)
+	return report
+}
+
+// JobIDs returns the IDs of the Jobs written to.
+func (r BulkReport) JobIDs() []string {
+	ids := make([]string, 0, len(r.Jobs))
+	for _, job := range r.Jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+func (w *BulkWriter) writeAll(items []*JobItem) (BulkReport, error) {
+	var firstErr error
+	for start := 0; start < len(items); start += w.config.ChunkSize {
+		end := start + w.config.ChunkSize
+		if end > len(items) {
+			end = len(items)
+		}
+		if err := w.Write(items[start:end]...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if _, err := w.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return w.Report(), firstErr
+}
+
+func (w *BulkWriter) send(chunk []*JobItem) error {
+	job := JobRequest{Items: chunk, bulkType: w.bulkType}
+	current := len(w.report.Jobs) - 1
+	if current >= 0 && w.open(w.report.Jobs[current]) {
+		job.JobData = &JobData{ID: w.report.Jobs[current].ID}
+	}
+	saved, err := w.jobs.Repository.save(&job)
+	if err != nil {
+		w.report.Failed = append(w.report.Failed, chunk...)
+		return err
+	}
+	if job.JobData != nil && (saved.ID == "" || saved.ID == job.JobData.ID) {
+		if saved.ID == "" {
+			saved.ID = job.JobData.ID
+		}
+		w.report.Jobs[current] = saved
+	} else {
+		w.report.Jobs = append(w.report.Jobs, saved)
+	}
+	w.report.Chunks++
+	w.report.Items += len(chunk)
+	return nil
+}
+
+// open reports whether a Job can still be appended to.
+func (w *BulkWriter) open(job JobResponse) bool {
+	if job.ID == "" {
+		return false
+	}
+	if job.ClosingAt == 0 {
+		return true
+	}
+	return w.config.now().Add(w.config.ClosingMargin).Before(time.Unix(job.ClosingAt, 0))
+}
+
+func (c BulkWriterConfig) withDefaults() BulkWriterConfig {
+	if c.ChunkSize <= 0 {
+		c.ChunkSize = 100
+	}
+	if c.ClosingMargin <= 0 {
+		c.ClosingMargin = time.Minute
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	return c
+}
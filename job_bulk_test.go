@@ -0,0 +1,111 @@
+package intercom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBulkWriterChunksAndAppends(t *testing.T) {
+	repo := &TestBulkJobRepository{closingAt: time.Unix(1000, 0)}
+	js := JobService{Repository: repo}
+	writer := js.NewUserBulkWriter(BulkWriterConfig{ChunkSize: 2, now: func() time.Time { return time.Unix(0, 0) }})
+	items := testBulkUserItems(5)
+	for _, item := range items {
+		if err := writer.Write(item); err != nil {
+			t.Fatalf("Error writing %s", err)
+		}
+	}
+	if len(repo.requests) != 2 {
+		t.Errorf("Sent %d requests before Close, expected 2", len(repo.requests))
+	}
+	report, err := writer.Close()
+	if err != nil {
+		t.Fatalf("Error closing %s", err)
+	}
+	if report.Chunks != 3 || report.Items != 5 || len(report.Failed) != 0 {
+		t.Errorf("Report was %+v", report)
+	}
+	if ids := report.JobIDs(); len(ids) != 1 || ids[0] != "job_1" {
+		t.Errorf("Jobs were %v, expected job_1", ids)
+	}
+	if repo.requests[0].JobData != nil || repo.requests[1].JobData.ID != "job_1" || repo.requests[2].JobData.ID != "job_1" {
+		t.Errorf("Chunks were not appended to the first job")
+	}
+	if len(repo.requests[2].Items) != 1 || repo.requests[2].bulkType != "users" {
+		t.Errorf("Last chunk was %d items for %s", len(repo.requests[2].Items), repo.requests[2].bulkType)
+	}
+	if items[0].Data.(*User).UserID != "0" {
+		t.Errorf("Job item data was modified")
+	}
+}
+
+func TestBulkWriterOpensNewJobWhenClosing(t *testing.T) {
+	repo := &TestBulkJobRepository{closingAt: time.Unix(1000, 0)}
+	js := JobService{Repository: repo}
+	now := time.Unix(0, 0)
+	writer := js.NewEventBulkWriter(BulkWriterConfig{ChunkSize: 1, ClosingMargin: time.Minute, now: func() time.Time { return now }})
+	writer.Write(NewEventJobItem(&Event{UserID: "1"}))
+	now = time.Unix(950, 0)
+	writer.Write(NewEventJobItem(&Event{UserID: "2"}))
+	report, _ := writer.Close()
+	if ids := report.JobIDs(); len(ids) != 2 || ids[0] != "job_1" || ids[1] != "job_2" {
+		t.Errorf("Jobs were %v, expected job_1 and job_2", ids)
+	}
+	if repo.requests[1].JobData != nil || repo.requests[1].bulkType != "events" {
+		t.Errorf("Did not open a new events job")
+	}
+}
+
+func TestBulkUsersReportsFailures(t *testing.T) {
+	repo := &TestBulkJobRepository{fail: map[int]bool{1: true}}
+	js := JobService{Repository: repo}
+	report, err := js.BulkUsers(testBulkUserItems(250), BulkWriterConfig{})
+	if err == nil {
+		t.Errorf("Expected the failed chunk to be returned")
+	}
+	if report.Chunks != 2 || report.Items != 150 || len(report.Failed) != 100 {
+		t.Errorf("Report was %d chunks, %d items, %d failed", report.Chunks, report.Items, len(report.Failed))
+	}
+	if ids := report.JobIDs(); len(ids) != 1 {
+		t.Errorf("Jobs were %v, expected a single job", ids)
+	}
+	if report.Failed[0].Data.(*User).UserID != "100" {
+		t.Errorf("Failed items started with %v", report.Failed[0].Data)
+	}
+}
+
+func testBulkUserItems(n int) []*JobItem {
+	items := make([]*JobItem, n)
+	for i := range items {
+		items[i] = NewUserJobItem(&User{UserID: fmt.Sprint(i)}, JOB_POST)
+	}
+	return items
+}
+
+type TestBulkJobRepository struct {
+	TestJobRepository
+	closingAt time.Time
+	fail      map[int]bool
+	requests  []JobRequest
+	jobs      int
+}
+
+func (api *TestBulkJobRepository) save(job *JobRequest) (JobResponse, error) {
+	api.requests = append(api.requests, *job)
+	if api.fail[len(api.requests)-1] {
+		return JobResponse{}, errors.New("bulk request failed")
+	}
+	saved := JobResponse{}
+	if !api.closingAt.IsZero() {
+		saved.ClosingAt = api.closingAt.Unix()
+	}
+	if job.JobData != nil {
+		saved.ID = job.JobData.ID
+		return saved, nil
+	}
+	api.jobs++
+	saved.ID = fmt.Sprintf("job_%d", api.jobs)
+	return saved, nil
+}
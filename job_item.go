@@ -33,3 +33,11 @@ var jobItemMethods = [...]string{
 func (state JobItemMethod) String() string {
 	return jobItemMethods[state]
 }
+
+// requestJobItemData converts the Data of a JobItem to what is sent to the API.
+func requestJobItemData(data interface{}) interface{} {
+	if user, ok := data.(*User); ok {
+		return RequestUserMapper{}.ConvertUser(user)
+	}
+	return data
+}
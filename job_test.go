@@ -1,6 +1,11 @@
 package intercom
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
 
 func TestNewJob(t *testing.T) {
 	repo := &TestJobRepository{t: t}
@@ -37,9 +42,83 @@ func TestAppendJob(t *testing.T) {
 	js.AppendUsers(newJob.ID, NewUserJobItem(&user, JOB_POST))
 }
 
+func TestParseJobState(t *testing.T) {
+	state, err := JobResponse{State: "completed_with_errors"}.JobState()
+	if err != nil || state != COMPLETED_WITH_ERRORS || !state.Finished() {
+		t.Errorf("State was %s, expected completed_with_errors", state)
+	}
+	if state, _ := ParseJobState("running"); state.Finished() {
+		t.Errorf("running should not be finished")
+	}
+	if _, err := ParseJobState("exploded"); err == nil {
+		t.Errorf("Unknown state should fail to parse")
+	}
+}
+
+func TestJobWaitForCompletion(t *testing.T) {
+	repo := &TestJobRepository{t: t, states: []string{"pending", "running", "completed"}}
+	js := JobService{Repository: repo}
+	job, err := js.WaitForCompletion(context.Background(), "job_1", time.Millisecond)
+	if err != nil || job.State != "completed" {
+		t.Errorf("Job was %s with %v, expected completed", job.State, err)
+	}
+}
+
+func TestJobWaitForCompletionCancelled(t *testing.T) {
+	repo := &TestJobRepository{t: t, states: []string{"running"}}
+	js := JobService{Repository: repo}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if _, err := js.WaitForCompletion(ctx, "job_1", time.Millisecond); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestJobWaitForCompletionDefaultInterval(t *testing.T) {
+	repo := &TestJobRepository{t: t, states: []string{"running"}}
+	js := JobService{Repository: repo}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if _, err := js.WaitForCompletion(ctx, "job_1", 0); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestJobFailures(t *testing.T) {
+	repo := &TestJobRepository{t: t, errorItems: []JobErrorItem{
+		{DataType: "user", Method: "post", Data: json.RawMessage(`{"user_id":"25"}`), Error: JobItemError{Code: "parameter_invalid"}},
+		{DataType: "event", Method: "post", Data: json.RawMessage(`{"event_name":"placed-order","user_id":"26","created_at":1438944980}`), Error: JobItemError{Code: "not_found"}},
+		{DataType: "user", Method: "post", Data: json.RawMessage(`{"user_id":"99"}`), Error: JobItemError{Code: "conflict"}},
+	}}
+	js := JobService{Repository: repo}
+	items := []*JobItem{
+		NewUserJobItem(&User{UserID: "24"}, JOB_POST),
+		NewUserJobItem(&User{UserID: "25"}, JOB_POST),
+		NewEventJobItem(&Event{UserID: "26", EventName: "placed-order", CreatedAt: 1438944980}),
+	}
+	failures, err := js.Failures("job_1", items)
+	if err != nil {
+		t.Fatalf("Error fetching failures %s", err)
+	}
+	if len(failures) != 3 {
+		t.Fatalf("Got %d failures, expected 3", len(failures))
+	}
+	if failures[0].Index != 1 || failures[0].Item != items[1] || failures[0].Error.Code != "parameter_invalid" {
+		t.Errorf("User failure mapped to %d", failures[0].Index)
+	}
+	if failures[1].Index != 2 || failures[1].Item != items[2] {
+		t.Errorf("Event failure mapped to %d", failures[1].Index)
+	}
+	if failures[2].Index != -1 || failures[2].Item != nil {
+		t.Errorf("Unknown failure mapped to %d", failures[2].Index)
+	}
+}
+
 type TestJobRepository struct {
-	t *testing.T
-	f func(job *JobRequest)
+	t          *testing.T
+	f          func(job *JobRequest)
+	states     []string
+	errorItems []JobErrorItem
 }
 
 func (api *TestJobRepository) save(job *JobRequest) (JobResponse, error) {
@@ -50,5 +129,23 @@ func (api *TestJobRepository) save(job *JobRequest) (JobResponse, error) {
 }
 
 func (api *TestJobRepository) find(id string) (JobResponse, error) {
-	return JobResponse{}, nil
+	if len(api.states) == 0 {
+		return JobResponse{ID: id, State: "completed"}, nil
+	}
+	state := api.states[0]
+	if len(api.states) > 1 {
+		api.states = api.states[1:]
+	}
+	return JobResponse{ID: id, State: state}, nil
+}
+
+func (api *TestJobRepository) errors(id string) (JobErrorFeed, error) {
+	if len(api.errorItems) == 0 {
+		return JobErrorFeed{ID: id}, nil
+	}
+	return JobErrorFeed{ID: id, Items: api.errorItems[:1], Pages: JobErrorPages{Next: "https://api.intercom.io/jobs/" + id + "/error?page=2"}}, nil
+}
+
+func (api *TestJobRepository) errorsNext(next string) (JobErrorFeed, error) {
+	return JobErrorFeed{Items: api.errorItems[1:]}, nil
 }
@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -45,35 +46,68 @@ func (template *MessageTemplate) UnmarshalJSON(b []byte) error {
 }
 
 // MessageRequest represents a Message to be sent through Intercom from/to an Admin, User, or Contact.
+// Setting CreateConversationWithoutContactReply creates the Conversation as soon as the Message is sent,
+// rather than when the Contact replies.
 type MessageRequest struct {
-	MessageType string         `json:"message_type,omitempty"`
-	Subject     string         `json:"subject,omitempty"`
-	Body        string         `json:"body,omitempty"`
-	Template    string         `json:"template,omitempty"`
-	From        MessageAddress `json:"from,omitempty"`
-	To          MessageAddress `json:"to,omitempty"`
+	MessageType                           string         `json:"message_type,omitempty"`
+	Subject                               string         `json:"subject,omitempty"`
+	Body                                  string         `json:"body,omitempty"`
+	Template                              string         `json:"template,omitempty"`
+	From                                  MessageAddress `json:"from,omitempty"`
+	To                                    MessageAddress `json:"to,omitempty"`
+	CreateConversationWithoutContactReply bool           `json:"create_conversation_without_contact_reply,omitempty"`
 }
 
+var messageTypes = []string{"email", "inapp", "sms"}
+
 // MessageResponse represents a Message to be sent through Intercom from/to an Admin, User, or Contact.
 type MessageResponse struct {
-	MessageType string          `json:"message_type,omitempty"`
-	ID          string          `json:"id"`
-	CreatedAt   int64           `json:"created_at,omitempty"`
-	Owner       MessageAddress  `json:"owner,omitempty"`
-	Subject     string          `json:"subject,omitempty"`
-	Body        string          `json:"body,omitempty"`
-	Template    MessageTemplate `json:"template,omitempty"`
+	Type           string          `json:"type,omitempty"`
+	MessageType    string          `json:"message_type,omitempty"`
+	ID             string          `json:"id"`
+	CreatedAt      int64           `json:"created_at,omitempty"`
+	Owner          MessageAddress  `json:"owner,omitempty"`
+	Subject        string          `json:"subject,omitempty"`
+	Body           string          `json:"body,omitempty"`
+	Template       MessageTemplate `json:"template,omitempty"`
+	ConversationID string          `json:"conversation_id,omitempty"`
 }
 
 func (m MessageResponse) String() string {
 	return fmt.Sprintf("[intercom] message { id: %s, message_type: %s, body: %s }", m.ID, m.MessageType, m.Body)
 }
 
-// Save (send) a Message
+// Save (send) a Message. The Message is validated before being sent.
+// The ConversationID of the response, when set, can be used to follow up with ConversationService.Reply.
 func (m *MessageService) Save(message *MessageRequest) (MessageResponse, error) {
+	if err := message.validate(); err != nil {
+		return MessageResponse{}, err
+	}
 	return m.Repository.save(message)
 }
 
+func (m MessageRequest) validate() error {
+	switch {
+	case !containsString(messageTypes, m.MessageType):
+		return errors.New("Invalid Message Type")
+	case m.Body == "":
+		return errors.New("Missing Message Body")
+	case m.MessageType == "email" && m.Subject == "":
+		return errors.New("Missing Message Subject")
+	case m.Template != "" && m.MessageType != "email":
+		return errors.New("Template Only Allowed For Email Messages")
+	case m.Template != "" && m.Template != PERSONAL_TEMPLATE.String() && m.Template != PLAIN_TEMPLATE.String():
+		return errors.New("Invalid Message Template")
+	case !m.From.identified():
+		return errors.New("Missing Message Sender")
+	case m.From.Type == "admin" && !m.To.identified():
+		return errors.New("Missing Message Recipient")
+	case m.From.Type != "admin" && m.MessageType != "inapp":
+		return errors.New("Only Admins Can Send Email Or SMS Messages")
+	}
+	return nil
+}
+
 // NewEmailMessage creates a new *Message of email type.
 func NewEmailMessage(template MessageTemplate, from, to MessagePerson, subject, body string) MessageRequest {
 	return MessageRequest{MessageType: "email", Template: template.String(), From: from.MessageAddress(), To: to.MessageAddress(), Subject: subject, Body: body}
@@ -84,6 +118,11 @@ func NewInAppMessage(from, to MessagePerson, body string) MessageRequest {
 	return MessageRequest{MessageType: "inapp", From: from.MessageAddress(), To: to.MessageAddress(), Body: body}
 }
 
+// NewSMSMessage creates a new *Message of SMS type, sent to the phone number of a Contact.
+func NewSMSMessage(from, to MessagePerson, body string) MessageRequest {
+	return MessageRequest{MessageType: "sms", From: from.MessageAddress(), To: to.MessageAddress(), Body: body}
+}
+
 // NewUserMessage creates a new *Message from a User.
 func NewUserMessage(from MessagePerson, body string) MessageRequest {
 	return MessageRequest{MessageType: "inapp", From: from.MessageAddress(), Body: body}
@@ -94,10 +133,36 @@ type MessagePerson interface {
 	MessageAddress() MessageAddress
 }
 
+// A MessageAddress identifies someone by ID, external ID (UserID for Users), or email.
 type MessageAddress struct {
-	Type   string `json:"type,omitempty"`
-	ID     string `json:"id,omitempty"`
-	Email  string `json:"email,omitempty"`
-	UserID string `json:"user_id,omitempty"`
-	Name   string `json:"name,omitempty"`
+	Type       string `json:"type,omitempty"`
+	ID         string `json:"id,omitempty"`
+	Email      string `json:"email,omitempty"`
+	UserID     string `json:"user_id,omitempty"`
+	ExternalID string `json:"external_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+// MessageAddress makes a MessageAddress a MessagePerson.
+func (a MessageAddress) MessageAddress() MessageAddress {
+	return a
+}
+
+// ContactByID addresses a Contact by its Intercom ID.
+func ContactByID(id string) MessageAddress {
+	return MessageAddress{Type: "user", ID: id}
+}
+
+// ContactByExternalID addresses a Contact by the ID given to it by your systems.
+func ContactByExternalID(externalID string) MessageAddress {
+	return MessageAddress{Type: "user", ExternalID: externalID}
+}
+
+// ContactByEmail addresses a Contact by email.
+func ContactByEmail(email string) MessageAddress {
+	return MessageAddress{Type: "user", Email: email}
+}
+
+func (a MessageAddress) identified() bool {
+	return a.Type != "" && (a.ID != "" || a.Email != "" || a.UserID != "" || a.ExternalID != "")
 }
@@ -24,6 +24,20 @@ func TestMessageAPISave(t *testing.T) {
 	}
 }
 
+func TestMessageAPISaveConversation(t *testing.T) {
+	http := TestMessageHTTPClient{t: t, expectedURI: "/messages", fixtureFilename: "fixtures/message_conversation.json"}
+	api := MessageAPI{httpClient: &http}
+	message := NewEmailMessage(NO_TEMPLATE, Admin{ID: "25"}, ContactByID("5ba682d2"), "Your order", "Your order has shipped")
+	message.CreateConversationWithoutContactReply = true
+	msg, err := api.save(&message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Type != "admin_message" || msg.ConversationID != "147" || msg.Owner.ID != "25" {
+		t.Errorf("Message was %v", msg)
+	}
+}
+
 type TestMessageHTTPClient struct {
 	TestHTTPClient
 	t               *testing.T
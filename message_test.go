@@ -75,18 +75,68 @@ func TestNewUserMessage(t *testing.T) {
 
 func TestSaveMessage(t *testing.T) {
 	messageService := MessageService{Repository: TestMessageAPI{t: t}}
-	message := NewInAppMessage(Admin{}, User{}, "hi there")
+	message := NewInAppMessage(Admin{ID: "25"}, User{UserID: "27"}, "hi there")
 	resp, _ := messageService.Save(&message)
 	if resp.Owner.Type != "admin" {
 		t.Errorf("Owner was not admin")
 	}
 }
 
+func TestNewSMSMessage(t *testing.T) {
+	message := NewSMSMessage(Admin{ID: "25"}, ContactByExternalID("27"), "body")
+	if message.MessageType != "sms" || message.To.ExternalID != "27" || message.To.Type != "user" {
+		t.Errorf("Message was %v", message)
+	}
+}
+
+func TestSaveMessageValidation(t *testing.T) {
+	admin := Admin{ID: "25"}
+	contact := ContactByEmail("jamie@example.com")
+	cases := []struct {
+		message  MessageRequest
+		expected string
+	}{
+		{MessageRequest{MessageType: "push", From: admin.MessageAddress(), To: contact, Body: "body"}, "Invalid Message Type"},
+		{NewInAppMessage(admin, contact, ""), "Missing Message Body"},
+		{NewEmailMessage(PLAIN_TEMPLATE, admin, contact, "", "body"), "Missing Message Subject"},
+		{MessageRequest{MessageType: "inapp", Template: "plain", From: admin.MessageAddress(), To: contact, Body: "body"}, "Template Only Allowed For Email Messages"},
+		{MessageRequest{MessageType: "email", Template: "fancy", Subject: "subject", From: admin.MessageAddress(), To: contact, Body: "body"}, "Invalid Message Template"},
+		{NewInAppMessage(Admin{}, contact, "body"), "Missing Message Sender"},
+		{NewSMSMessage(admin, ContactByID(""), "body"), "Missing Message Recipient"},
+		{MessageRequest{MessageType: "sms", From: contact, Body: "body"}, "Only Admins Can Send Email Or SMS Messages"},
+	}
+	messageService := MessageService{Repository: TestMessageAPI{t: t}}
+	for _, c := range cases {
+		if _, err := messageService.Save(&c.message); err == nil || err.Error() != c.expected {
+			t.Errorf("Expected %s, got %v", c.expected, err)
+		}
+	}
+}
+
+func TestSaveConversationMessage(t *testing.T) {
+	var sent *MessageRequest
+	messageService := MessageService{Repository: TestMessageAPI{t: t, f: func(message *MessageRequest) { sent = message }}}
+	message := NewEmailMessage(NO_TEMPLATE, Admin{ID: "25"}, ContactByID("5ba682d2"), "subject", "body")
+	message.CreateConversationWithoutContactReply = true
+	resp, err := messageService.Save(&message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent == nil || !sent.CreateConversationWithoutContactReply || resp.ConversationID != "147" {
+		t.Errorf("Sent %v, response %v", sent, resp)
+	}
+}
+
 type TestMessageAPI struct {
 	t *testing.T
+	f func(message *MessageRequest)
 }
 
 func (t TestMessageAPI) save(message *MessageRequest) (MessageResponse, error) {
+	if t.f != nil {
+		t.f(message)
+		return MessageResponse{MessageType: message.MessageType, ConversationID: "147"}, nil
+	}
 	if message.MessageType != "inapp" {
 		t.t.Errorf("Message not inapp")
 	}
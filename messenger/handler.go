@@ -0,0 +1,117 @@
+package messenger
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// A User is the logged-in user the Messenger is booted for.
+type User struct {
+	UserID     string
+	Email      string
+	Name       string
+	CreatedAt  time.Time
+	Attributes map[string]interface{} // Custom attributes, also signed into JWTs.
+}
+
+// Settings are the boot settings of the Messenger (window.intercomSettings).
+type Settings struct {
+	AppID     string                 `json:"app_id"`
+	UserID    string                 `json:"user_id,omitempty"`
+	Email     string                 `json:"email,omitempty"`
+	Name      string                 `json:"name,omitempty"`
+	CreatedAt int64                  `json:"created_at,omitempty"`
+	UserHash  string                 `json:"user_hash,omitempty"`
+	JWT       string                 `json:"intercom_user_jwt,omitempty"`
+	Custom    map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON encodes the Settings with the custom attributes alongside the standard ones.
+func (s Settings) MarshalJSON() ([]byte, error) {
+	type settings Settings
+	standard, err := json.Marshal(settings(s))
+	if err != nil || len(s.Custom) == 0 {
+		return standard, err
+	}
+	merged := map[string]interface{}{}
+	for name, value := range s.Custom {
+		merged[name] = value
+	}
+	if err := json.Unmarshal(standard, &merged); err != nil {
+		return nil, err
+	}
+	return json.Marshal(merged)
+}
+
+// BootHandler serves the Messenger boot Settings of the logged-in user as JSON,
+// and the anonymous Settings (the AppID alone) for visitors.
+//
+//	http.Handle("/intercom/settings", messenger.BootHandler{
+//		AppID:       "abc123",
+//		Signer:      messenger.Signer{Secret: os.Getenv("INTERCOM_SECRET")},
+//		CurrentUser: currentUser,
+//	})
+type BootHandler struct {
+	AppID       string
+	Signer      Signer                             // Its Secret computes user hashes, and signs JWTs when UseJWT is set.
+	UseJWT      bool                               // Send a signed JWT rather than the identity in plain with a user_hash.
+	CurrentUser func(r *http.Request) (User, bool) // Returns the logged-in user, or false for visitors.
+}
+
+// Settings builds the boot Settings of a user, or of a visitor when user is nil.
+func (h BootHandler) Settings(user *User) (Settings, error) {
+	settings := Settings{AppID: h.AppID}
+	if user == nil {
+		return settings, nil
+	}
+	if h.UseJWT {
+		token, err := h.Signer.Sign(Claims{UserID: user.UserID, Email: user.Email, Attributes: user.Attributes})
+		if err != nil {
+			return settings, err
+		}
+		settings.JWT = token
+	} else {
+		if h.Signer.Secret == "" {
+			return settings, ErrMissingSecret
+		}
+		identifier := user.UserID
+		if identifier == "" {
+			identifier = user.Email
+		}
+		if identifier == "" {
+			return settings, ErrMissingIdentity
+		}
+		settings.UserID, settings.Email = user.UserID, user.Email
+		settings.UserHash = UserHash(h.Signer.Secret, identifier)
+		settings.Custom = user.Attributes
+	}
+	settings.Name = user.Name
+	if !user.CreatedAt.IsZero() {
+		settings.CreatedAt = user.CreatedAt.Unix()
+	}
+	return settings, nil
+}
+
+// ServeHTTP serves the boot Settings to GET requests.
+func (h BootHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	var user *User
+	if h.CurrentUser != nil {
+		if current, ok := h.CurrentUser(r); ok {
+			user = &current
+		}
+	}
+	settings, err := h.Settings(user)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "private, no-store")
+	json.NewEncoder(w).Encode(settings)
+}
@@ -0,0 +1,82 @@
+package messenger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBootHandlerUserHash(t *testing.T) {
+	handler := BootHandler{AppID: "abc123", Signer: Signer{Secret: "secret"}, CurrentUser: func(r *http.Request) (User, bool) {
+		return User{UserID: "27", Email: "jamie@example.com", Name: "Jamie", CreatedAt: time.Unix(1700000000, 0),
+			Attributes: map[string]interface{}{"plan": "pro"}}, true
+	}}
+	settings := serveBoot(t, handler, http.StatusOK)
+	expected := map[string]interface{}{
+		"app_id": "abc123", "user_id": "27", "email": "jamie@example.com", "name": "Jamie", "created_at": 1700000000.0,
+		"user_hash": "7f3bbbd1181ce25650a4099c615465bbb8aa787c3da1012dae42e61b02705769", "plan": "pro",
+	}
+	for name, value := range expected {
+		if settings[name] != value {
+			t.Errorf("Setting %s was %v, expected %v", name, settings[name], value)
+		}
+	}
+	if len(settings) != len(expected) {
+		t.Errorf("Settings were %v", settings)
+	}
+}
+
+func TestBootHandlerJWT(t *testing.T) {
+	signer := Signer{Secret: "secret"}
+	handler := BootHandler{AppID: "abc123", Signer: signer, UseJWT: true, CurrentUser: func(r *http.Request) (User, bool) {
+		return User{Email: "jamie@example.com", Attributes: map[string]interface{}{"plan": "pro"}}, true
+	}}
+	settings := serveBoot(t, handler, http.StatusOK)
+	if settings["user_hash"] != nil || settings["email"] != nil {
+		t.Errorf("Expected the identity in the JWT only, settings were %v", settings)
+	}
+	token, _ := settings["intercom_user_jwt"].(string)
+	claims, err := signer.Verify(token)
+	if err != nil || claims.Email != "jamie@example.com" || claims.Attributes["plan"] != "pro" {
+		t.Errorf("Claims were %+v, error %v", claims, err)
+	}
+}
+
+func TestBootHandlerVisitor(t *testing.T) {
+	handler := BootHandler{AppID: "abc123", CurrentUser: func(r *http.Request) (User, bool) { return User{}, false }}
+	settings := serveBoot(t, handler, http.StatusOK)
+	if len(settings) != 1 || settings["app_id"] != "abc123" {
+		t.Errorf("Settings were %v", settings)
+	}
+}
+
+func TestBootHandlerErrors(t *testing.T) {
+	handler := BootHandler{AppID: "abc123", CurrentUser: func(r *http.Request) (User, bool) { return User{UserID: "27"}, true }}
+	serveBoot(t, handler, http.StatusInternalServerError)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/intercom/settings", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status was %d", recorder.Code)
+	}
+}
+
+func serveBoot(t *testing.T, handler http.Handler, status int) map[string]interface{} {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/intercom/settings", nil))
+	if recorder.Code != status {
+		t.Fatalf("Status was %d, expected %d", recorder.Code, status)
+	}
+	settings := map[string]interface{}{}
+	if status == http.StatusOK {
+		if recorder.Header().Get("Cache-Control") != "private, no-store" {
+			t.Errorf("Expected the settings not to be cached")
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &settings); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return settings
+}
@@ -0,0 +1,180 @@
+package messenger
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMissingSecret is returned when signing or verifying without a secret.
+	ErrMissingSecret = errors.New("messenger: missing secret")
+	// ErrMissingIdentity is returned when signing Claims with neither a UserID nor an Email.
+	ErrMissingIdentity = errors.New("messenger: missing user_id or email")
+	// ErrInvalidToken is returned for malformed tokens, and tokens with a wrong signature.
+	ErrInvalidToken = errors.New("messenger: invalid token")
+	// ErrTokenExpired is returned for tokens past their expiry.
+	ErrTokenExpired = errors.New("messenger: token expired")
+)
+
+// Claims are the claims of a Messenger JWT.
+type Claims struct {
+	UserID     string
+	Email      string
+	IssuedAt   time.Time
+	ExpiresAt  time.Time
+	Attributes map[string]interface{} // Other claims, such as name.
+}
+
+// MarshalJSON encodes the Claims as a flat JSON object, with times as Unix timestamps.
+func (c Claims) MarshalJSON() ([]byte, error) {
+	claims := map[string]interface{}{}
+	for name, value := range c.Attributes {
+		claims[name] = value
+	}
+	if c.UserID != "" {
+		claims["user_id"] = c.UserID
+	}
+	if c.Email != "" {
+		claims["email"] = c.Email
+	}
+	if !c.IssuedAt.IsZero() {
+		claims["iat"] = c.IssuedAt.Unix()
+	}
+	if !c.ExpiresAt.IsZero() {
+		claims["exp"] = c.ExpiresAt.Unix()
+	}
+	return json.Marshal(claims)
+}
+
+// UnmarshalJSON decodes the Claims, keeping unknown claims in Attributes.
+func (c *Claims) UnmarshalJSON(b []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	claims := map[string]interface{}{}
+	if err := decoder.Decode(&claims); err != nil {
+		return err
+	}
+	*c = Claims{Attributes: map[string]interface{}{}}
+	for name, value := range claims {
+		var ok bool
+		switch name {
+		case "user_id":
+			c.UserID, ok = value.(string)
+		case "email":
+			c.Email, ok = value.(string)
+		case "iat":
+			c.IssuedAt, ok = unixClaim(value)
+		case "exp":
+			c.ExpiresAt, ok = unixClaim(value)
+		default:
+			c.Attributes[name], ok = value, true
+		}
+		if !ok {
+			return errors.New("messenger: invalid claim " + name)
+		}
+	}
+	return nil
+}
+
+func unixClaim(value interface{}) (time.Time, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Int64()
+	return time.Unix(seconds, 0), err == nil
+}
+
+// A Signer signs and verifies Messenger JWTs (HS256) with the identity verification secret.
+type Signer struct {
+	Secret string
+	Expiry time.Duration // Lifetime of the tokens signed. Defaults to 1h.
+	Leeway time.Duration // Clock skew allowed when verifying expiry.
+	now    func() time.Time
+}
+
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Sign signs Claims identifying a user by UserID or Email.
+// IssuedAt and ExpiresAt are set from the Expiry of the Signer unless given.
+func (s Signer) Sign(claims Claims) (string, error) {
+	s = s.withDefaults()
+	if s.Secret == "" {
+		return "", ErrMissingSecret
+	}
+	if claims.UserID == "" && claims.Email == "" {
+		return "", ErrMissingIdentity
+	}
+	now := s.now()
+	if claims.IssuedAt.IsZero() {
+		claims.IssuedAt = now
+	}
+	if claims.ExpiresAt.IsZero() {
+		claims.ExpiresAt = now.Add(s.Expiry)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + s.signature(unsigned), nil
+}
+
+// Verify checks the signature and expiry of a token, and returns its Claims.
+func (s Signer) Verify(token string) (Claims, error) {
+	s = s.withDefaults()
+	if s.Secret == "" {
+		return Claims{}, ErrMissingSecret
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrInvalidToken
+	}
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	var h struct {
+		Alg string `json:"alg"`
+	}
+	if json.Unmarshal(header, &h) != nil || h.Alg != "HS256" {
+		return Claims{}, ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.signature(parts[0]+"."+parts[1]))) {
+		return Claims{}, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	if !claims.ExpiresAt.IsZero() && s.now().After(claims.ExpiresAt.Add(s.Leeway)) {
+		return claims, ErrTokenExpired
+	}
+	return claims, nil
+}
+
+func (s Signer) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s Signer) withDefaults() Signer {
+	if s.Expiry <= 0 {
+		s.Expiry = time.Hour
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package messenger
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signer := Signer{Secret: "secret", Expiry: 10 * time.Minute, now: func() time.Time { return now }}
+	token, err := signer.Sign(Claims{UserID: "27", Email: "jamie@example.com", Attributes: map[string]interface{}{"name": "Jamie"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Token was %s", token)
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	if string(payload) != `{"email":"jamie@example.com","exp":1700000600,"iat":1700000000,"name":"Jamie","user_id":"27"}` {
+		t.Errorf("Payload was %s", payload)
+	}
+
+	claims, err := signer.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != "27" || claims.Email != "jamie@example.com" || claims.Attributes["name"] != "Jamie" || !claims.ExpiresAt.Equal(now.Add(10*time.Minute)) {
+		t.Errorf("Claims were %+v", claims)
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signer := Signer{Secret: "secret", now: func() time.Time { return now }}
+	token, _ := signer.Sign(Claims{Email: "jamie@example.com"})
+
+	later := Signer{Secret: "secret", Leeway: time.Minute, now: func() time.Time { return now.Add(time.Hour + 30*time.Second) }}
+	if _, err := later.Verify(token); err != nil {
+		t.Errorf("Expected the token to be valid within the leeway, got %v", err)
+	}
+	later.Leeway = 0
+	if _, err := later.Verify(token); err != ErrTokenExpired {
+		t.Errorf("Expected an expired token, got %v", err)
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	signer := Signer{Secret: "secret"}
+	token, _ := signer.Sign(Claims{UserID: "27"})
+	parts := strings.Split(token, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"user_id":"28"}`))
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	for _, invalid := range []string{
+		"",
+		"a.b",
+		parts[0] + "." + forged + "." + parts[2],
+		none + "." + parts[1] + ".",
+	} {
+		if _, err := signer.Verify(invalid); err != ErrInvalidToken {
+			t.Errorf("Expected %q to be invalid, got %v", invalid, err)
+		}
+	}
+	if _, err := (Signer{Secret: "other"}).Verify(token); err != ErrInvalidToken {
+		t.Errorf("Expected a token signed with another secret to be invalid, got %v", err)
+	}
+}
+
+func TestSignErrors(t *testing.T) {
+	if _, err := (Signer{}).Sign(Claims{UserID: "27"}); err != ErrMissingSecret {
+		t.Errorf("Expected a missing secret, got %v", err)
+	}
+	if _, err := (Signer{Secret: "secret"}).Sign(Claims{}); err != ErrMissingIdentity {
+		t.Errorf("Expected a missing identity, got %v", err)
+	}
+}
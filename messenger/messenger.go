@@ -0,0 +1,17 @@
+// Package messenger helps boot the Intercom Messenger with identity verification,
+// computing user hashes and signing Messenger JWTs on the server.
+package messenger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// UserHash computes the user_hash of a user_id, or of an email for users without one,
+// with the identity verification secret of the workspace.
+func UserHash(secret, identifier string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(identifier))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,9 @@
+package messenger
+
+import "testing"
+
+func TestUserHash(t *testing.T) {
+	if hash := UserHash("secret", "27"); hash != "7f3bbbd1181ce25650a4099c615465bbb8aa787c3da1012dae42e61b02705769" {
+		t.Errorf("User hash was %s", hash)
+	}
+}
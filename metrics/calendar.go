@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+)
+
+// A Calendar measures the time elapsed between two instants.
+type Calendar interface {
+	Duration(start, end time.Time) time.Duration
+}
+
+// WallClock is a Calendar counting every hour of every day.
+type WallClock struct{}
+
+// Duration returns end - start, or 0 if end is before start.
+func (WallClock) Duration(start, end time.Time) time.Duration {
+	if end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// BusinessHours is a Calendar counting only the working hours of working days.
+type BusinessHours struct {
+	Location *time.Location // Time zone of the working hours, UTC if nil.
+	Open     time.Duration  // Start of the working day, since midnight.
+	Close    time.Duration  // End of the working day, since midnight.
+	Weekdays []time.Weekday // Working days.
+	Holidays []time.Time    // Days off, by their own date: time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC) is Dec 25 anywhere.
+}
+
+// NewBusinessHours creates BusinessHours open from open to close ("09:00" and "17:30") on weekdays, Monday to Friday.
+func NewBusinessHours(location *time.Location, open, close string) (BusinessHours, error) {
+	openAt, err := parseClock(open)
+	if err != nil {
+		return BusinessHours{}, err
+	}
+	closeAt, err := parseClock(close)
+	if err != nil {
+		return BusinessHours{}, err
+	}
+	if closeAt <= openAt {
+		return BusinessHours{}, fmt.Errorf("metrics: business hours close at %s, before opening at %s", close, open)
+	}
+	return BusinessHours{
+		Location: location,
+		Open:     openAt,
+		Close:    closeAt,
+		Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	}, nil
+}
+
+// Duration returns the working time between start and end.
+func (b BusinessHours) Duration(start, end time.Time) time.Duration {
+	if !end.After(start) {
+		return 0
+	}
+	location := b.Location
+	if location == nil {
+		location = time.UTC
+	}
+	start, end = start.In(location), end.In(location)
+	var total time.Duration
+	for day := atClock(start, 0); day.Before(end); day = nextDay(day) {
+		if !b.workingDay(day) {
+			continue
+		}
+		// Opening hours are wall clock times, which are not a fixed time since midnight on DST days.
+		from, to := atClock(day, b.Open), atClock(day, b.Close)
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			total += to.Sub(from)
+		}
+	}
+	return total
+}
+
+func (b BusinessHours) workingDay(day time.Time) bool {
+	for _, holiday := range b.Holidays {
+		y, m, d := holiday.Date()
+		if dy, dm, dd := day.Date(); y == dy && m == dm && d == dd {
+			return false
+		}
+	}
+	for _, weekday := range b.Weekdays {
+		if day.Weekday() == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func nextDay(day time.Time) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, day.Location())
+}
+
+// atClock returns the time of day clock on the date of day, in its location.
+func atClock(day time.Time, clock time.Duration) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d, int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+func parseClock(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("metrics: invalid time of day %q", clock)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
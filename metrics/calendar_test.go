@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestWallClock(t *testing.T) {
+	start := time.Date(2014, 5, 23, 15, 0, 0, 0, time.UTC)
+	if d := (WallClock{}).Duration(start, start.Add(90*time.Minute)); d != 90*time.Minute {
+		t.Errorf("Duration was %s", d)
+	}
+	if d := (WallClock{}).Duration(start, start.Add(-time.Minute)); d != 0 {
+		t.Errorf("Negative duration was %s", d)
+	}
+}
+
+func TestBusinessHours(t *testing.T) {
+	hours, err := NewBusinessHours(time.UTC, "09:00", "17:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	friday := time.Date(2014, 5, 23, 16, 0, 0, 0, time.UTC)
+	tuesday := time.Date(2014, 5, 27, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		start, end time.Time
+		holidays   []time.Time
+		expected   time.Duration
+	}{
+		{"within a day", friday, friday.Add(30 * time.Minute), nil, 30 * time.Minute},
+		{"after hours", friday.Add(2 * time.Hour), friday.Add(3 * time.Hour), nil, 0},
+		{"over the weekend", friday, tuesday, nil, 10 * time.Hour},
+		{"over a holiday", friday, tuesday, []time.Time{time.Date(2014, 5, 26, 0, 0, 0, 0, time.UTC)}, 2 * time.Hour},
+	}
+	for _, c := range cases {
+		hours.Holidays = c.holidays
+		if d := hours.Duration(c.start, c.end); d != c.expected {
+			t.Errorf("%s: duration was %s, expected %s", c.name, d, c.expected)
+		}
+	}
+}
+
+func TestBusinessHoursLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	hours, _ := NewBusinessHours(tokyo, "09:00", "18:00")
+	// 08:00 to 10:00 UTC is 17:00 to 19:00 in Tokyo.
+	start := time.Date(2014, 5, 23, 8, 0, 0, 0, time.UTC)
+	if d := hours.Duration(start, start.Add(2*time.Hour)); d != time.Hour {
+		t.Errorf("Duration was %s", d)
+	}
+}
+
+func TestBusinessHoursDaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hours, _ := NewBusinessHours(newYork, "09:00", "17:00")
+	hours.Weekdays = append(hours.Weekdays, time.Saturday, time.Sunday)
+	day := func(m time.Month, d, hour int) time.Time { return time.Date(2024, m, d, hour, 0, 0, 0, newYork) }
+	cases := []struct {
+		name       string
+		start, end time.Time
+		expected   time.Duration
+	}{
+		// Clocks go forward at 02:00, so 09:00 is 8 hours after midnight.
+		{"spring forward", day(time.March, 10, 0), day(time.March, 10, 10), time.Hour},
+		{"spring forward day", day(time.March, 10, 0), day(time.March, 11, 0), 8 * time.Hour},
+		// Clocks go back at 02:00, so 09:00 is 10 hours after midnight.
+		{"fall back", day(time.November, 3, 0), day(time.November, 3, 10), time.Hour},
+		{"fall back day", day(time.November, 3, 0), day(time.November, 4, 0), 8 * time.Hour},
+	}
+	for _, c := range cases {
+		if d := hours.Duration(c.start, c.end); d != c.expected {
+			t.Errorf("%s: duration was %s, expected %s", c.name, d, c.expected)
+		}
+	}
+
+	// A holiday is its own date, even at midnight UTC, which is still Dec 24 in New York.
+	hours.Holidays = []time.Time{time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)}
+	if d := hours.Duration(day(time.December, 24, 0), day(time.December, 25, 0)); d != 8*time.Hour {
+		t.Errorf("Dec 24 duration was %s, expected 8h", d)
+	}
+	if d := hours.Duration(day(time.December, 25, 0), day(time.December, 26, 0)); d != 0 {
+		t.Errorf("Dec 25 duration was %s, expected 0s", d)
+	}
+}
+
+func TestNewBusinessHoursInvalid(t *testing.T) {
+	if _, err := NewBusinessHours(time.UTC, "9am", "17:00"); err == nil {
+		t.Errorf("Expected an invalid opening time")
+	}
+	if _, err := NewBusinessHours(time.UTC, "17:00", "09:00"); err == nil {
+		t.Errorf("Expected closing before opening to fail")
+	}
+}
@@ -0,0 +1,303 @@
+// Package metrics computes support metrics, such as first response and close times, from Intercom conversations.
+//
+//	engine := metrics.Engine{Calendar: businessHours}
+//	report, err := engine.Run(metrics.WithParts(ic.Conversations.IterateAll(50), func(id string) (intercom.Conversation, error) {
+//		return ic.Conversations.Find(id, intercom.ConversationFindParams{})
+//	}))
+//	...
+//	report.WriteCSV(os.Stdout)
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	intercom "github.com/stefanoschrs/go-intercom"
+)
+
+// Engine computes metrics of conversations.
+type Engine struct {
+	Calendar        Calendar  // Measures durations, WallClock if nil.
+	Percentiles     []float64 // Percentiles reported in summaries, 50, 90 and 95 if empty.
+	CountBotReplies bool      // Count replies of bots as responses.
+}
+
+// ConversationMetrics are the metrics of a single conversation.
+type ConversationMetrics struct {
+	ConversationID      string
+	CreatedAt           time.Time
+	Open                bool
+	FirstContactReplyAt time.Time     // Zero if the contact never wrote.
+	FirstResponse       time.Duration // From the first contact message to the first admin reply, when Responded.
+	Responded           bool
+	TimeToClose         time.Duration // From the first contact message (or creation) to the first close, when Closed.
+	Closed              bool
+	Reopens             int
+	AdminReplies        map[string]int // Replies by admin ID.
+}
+
+// Conversation computes the metrics of a conversation from its parts.
+// A conversation without parts, as listed rather than found, falls back on its statistics,
+// which do not include reply counts.
+func (e Engine) Conversation(c intercom.Conversation) ConversationMetrics {
+	m := ConversationMetrics{
+		ConversationID: c.Id,
+		CreatedAt:      unix(int64(c.CreatedAt)),
+		Open:           c.Open,
+		AdminReplies:   map[string]int{},
+	}
+	if len(c.ConversationParts.ConversationParts) == 0 {
+		return e.fromStatistics(c, m)
+	}
+	if customer(c.Source.Author.Type) {
+		m.FirstContactReplyAt = m.CreatedAt
+	}
+	closed := false
+	for _, part := range c.ConversationParts.ConversationParts {
+		at := unix(int64(part.CreatedAt))
+		switch {
+		case part.PartType == "close":
+			if !m.Closed {
+				m.TimeToClose = e.duration(m.start(), at)
+				m.Closed = true
+			}
+			closed = true
+		case part.PartType == "open" && closed:
+			m.Reopens++
+			closed = false
+		case part.PartType == "comment" && customer(part.Author.Type):
+			if m.FirstContactReplyAt.IsZero() {
+				m.FirstContactReplyAt = at
+			}
+			if closed {
+				m.Reopens++
+				closed = false
+			}
+		case e.reply(part.PartType, part.Author.Type):
+			m.AdminReplies[part.Author.Id]++
+			if !m.Responded && !m.FirstContactReplyAt.IsZero() {
+				m.FirstResponse = e.duration(m.FirstContactReplyAt, at)
+				m.Responded = true
+			}
+		}
+	}
+	return m
+}
+
+func (e Engine) fromStatistics(c intercom.Conversation, m ConversationMetrics) ConversationMetrics {
+	stats := c.Statistics
+	if stats.FirstContactReplyAt > 0 {
+		m.FirstContactReplyAt = unix(int64(stats.FirstContactReplyAt))
+	}
+	if at, ok := timestamp(stats.FirstAdminReplyAt); ok && !m.FirstContactReplyAt.IsZero() {
+		m.FirstResponse = e.duration(m.FirstContactReplyAt, at)
+		m.Responded = true
+	}
+	if stats.FirstCloseAt > 0 {
+		m.TimeToClose = e.duration(m.start(), unix(int64(stats.FirstCloseAt)))
+		m.Closed = true
+	}
+	m.Reopens = stats.CountReopens
+	return m
+}
+
+func (e Engine) reply(partType, authorType string) bool {
+	if partType != "comment" && partType != "assign_and_reply" {
+		return false
+	}
+	return authorType == "admin" || (e.CountBotReplies && authorType == "bot")
+}
+
+func (e Engine) duration(start, end time.Time) time.Duration {
+	if e.Calendar == nil {
+		return WallClock{}.Duration(start, end)
+	}
+	return e.Calendar.Duration(start, end)
+}
+
+func (m ConversationMetrics) start() time.Time {
+	if m.FirstContactReplyAt.IsZero() {
+		return m.CreatedAt
+	}
+	return m.FirstContactReplyAt
+}
+
+// Summary aggregates the metrics of many conversations.
+type Summary struct {
+	Conversations int
+	Open          int
+	FirstResponse Distribution // Of the conversations responded to.
+	TimeToClose   Distribution // Of the conversations closed.
+	Reopens       int
+	Reopened      int            // Conversations reopened at least once.
+	AdminReplies  map[string]int // Replies by admin ID.
+	Percentiles   []float64      // Percentiles to report.
+}
+
+// Add aggregates the metrics of a conversation.
+func (s *Summary) Add(m ConversationMetrics) {
+	if s.AdminReplies == nil {
+		s.AdminReplies = map[string]int{}
+	}
+	s.Conversations++
+	if m.Open {
+		s.Open++
+	}
+	if m.Responded {
+		s.FirstResponse = append(s.FirstResponse, m.FirstResponse)
+	}
+	if m.Closed {
+		s.TimeToClose = append(s.TimeToClose, m.TimeToClose)
+	}
+	s.Reopens += m.Reopens
+	if m.Reopens > 0 {
+		s.Reopened++
+	}
+	for adminID, replies := range m.AdminReplies {
+		s.AdminReplies[adminID] += replies
+	}
+}
+
+// A Distribution of durations, in no particular order.
+type Distribution []time.Duration
+
+// Percentile returns the nearest-rank percentile p, between 0 and 100, or 0 for an empty Distribution.
+func (d Distribution) Percentile(p float64) time.Duration {
+	if len(d) == 0 {
+		return 0
+	}
+	sorted := append(Distribution(nil), d...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	} else if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// Mean returns the mean duration, or 0 for an empty Distribution.
+func (d Distribution) Mean() time.Duration {
+	if len(d) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, duration := range d {
+		total += duration
+	}
+	return total / time.Duration(len(d))
+}
+
+// Max returns the longest duration, or 0 for an empty Distribution.
+func (d Distribution) Max() time.Duration {
+	var max time.Duration
+	for _, duration := range d {
+		if duration > max {
+			max = duration
+		}
+	}
+	return max
+}
+
+// A Report holds the metrics of each conversation and their Summary.
+type Report struct {
+	Conversations []ConversationMetrics
+	Summary       Summary
+}
+
+// A Source streams conversations, as a *intercom.ConversationIterator does.
+type Source interface {
+	Next() bool
+	Conversation() intercom.Conversation
+	Err() error
+}
+
+// Run computes the metrics of every conversation of a Source.
+// The Report holds the conversations read before any error.
+func (e Engine) Run(source Source) (Report, error) {
+	report := Report{Conversations: []ConversationMetrics{}, Summary: Summary{
+		AdminReplies: map[string]int{},
+		Percentiles:  e.Percentiles,
+	}}
+	if len(report.Summary.Percentiles) == 0 {
+		report.Summary.Percentiles = []float64{50, 90, 95}
+	}
+	for source.Next() {
+		m := e.Conversation(source.Conversation())
+		report.Conversations = append(report.Conversations, m)
+		report.Summary.Add(m)
+	}
+	return report, source.Err()
+}
+
+// Conversations returns a Source of the conversations given, such as search results.
+func Conversations(conversations ...intercom.Conversation) Source {
+	return &sliceSource{conversations: conversations, index: -1}
+}
+
+type sliceSource struct {
+	conversations []intercom.Conversation
+	index         int
+}
+
+func (s *sliceSource) Next() bool {
+	s.index++
+	return s.index < len(s.conversations)
+}
+
+func (s *sliceSource) Conversation() intercom.Conversation {
+	return s.conversations[s.index]
+}
+
+func (s *sliceSource) Err() error {
+	return nil
+}
+
+// WithParts returns a Source finding each conversation of source with find, since listed conversations come without their parts.
+func WithParts(source Source, find func(id string) (intercom.Conversation, error)) Source {
+	return &partsSource{Source: source, find: find}
+}
+
+type partsSource struct {
+	Source
+	find         func(id string) (intercom.Conversation, error)
+	conversation intercom.Conversation
+	err          error
+}
+
+func (s *partsSource) Next() bool {
+	if s.err != nil || !s.Source.Next() {
+		return false
+	}
+	s.conversation, s.err = s.find(s.Source.Conversation().Id)
+	return s.err == nil
+}
+
+func (s *partsSource) Conversation() intercom.Conversation {
+	return s.conversation
+}
+
+func (s *partsSource) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.Source.Err()
+}
+
+func customer(authorType string) bool {
+	return authorType == "user" || authorType == "lead" || authorType == "contact"
+}
+
+func unix(seconds int64) time.Time {
+	return time.Unix(seconds, 0).UTC()
+}
+
+// timestamp reads the untyped timestamps of conversation statistics.
+func timestamp(value interface{}) (time.Time, bool) {
+	if seconds, ok := value.(float64); ok && seconds > 0 {
+		return unix(int64(seconds)), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,217 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	intercom "github.com/stefanoschrs/go-intercom"
+)
+
+func TestConversationMetrics(t *testing.T) {
+	conversation := testConversation(t, "../fixtures/conversation.json")
+	m := Engine{}.Conversation(conversation)
+	if m.ConversationID != "147" || m.Open {
+		t.Errorf("Metrics were %+v", m)
+	}
+	// The statistics of the fixture agree with its parts.
+	if !m.Responded || m.FirstResponse != 93*time.Second {
+		t.Errorf("First response was %s", m.FirstResponse)
+	}
+	if !m.Closed || m.TimeToClose != 1200*time.Second {
+		t.Errorf("Time to close was %s", m.TimeToClose)
+	}
+	if m.Reopens != 0 || len(m.AdminReplies) != 1 || m.AdminReplies["25"] != 1 {
+		t.Errorf("Reopens were %d, replies %v", m.Reopens, m.AdminReplies)
+	}
+}
+
+func TestConversationMetricsBusinessHours(t *testing.T) {
+	conversation := testConversation(t, "../fixtures/conversation.json")
+	// The contact wrote at 15:04:54 UTC, a Friday, and was answered at 15:06:27.
+	hours, _ := NewBusinessHours(time.UTC, "09:00", "15:05")
+	m := Engine{Calendar: hours}.Conversation(conversation)
+	if m.FirstResponse != 6*time.Second {
+		t.Errorf("First response was %s", m.FirstResponse)
+	}
+}
+
+func TestConversationMetricsReopens(t *testing.T) {
+	conversation := testConversationJSON(t, `{"id": "9", "created_at": 1000, "source": {"author": {"type": "user", "id": "u1"}},
+		"conversation_parts": {"conversation_parts": [
+			{"part_type": "comment", "created_at": 1060, "author": {"type": "bot", "id": "b1"}},
+			{"part_type": "comment", "created_at": 1100, "author": {"type": "admin", "id": "1"}},
+			{"part_type": "close", "created_at": 1200, "author": {"type": "admin", "id": "1"}},
+			{"part_type": "comment", "created_at": 1300, "author": {"type": "user", "id": "u1"}},
+			{"part_type": "comment", "created_at": 1400, "author": {"type": "admin", "id": "2"}},
+			{"part_type": "close", "created_at": 1500, "author": {"type": "admin", "id": "2"}},
+			{"part_type": "open", "created_at": 1600, "author": {"type": "admin", "id": "1"}}
+		]}}`)
+	m := Engine{}.Conversation(conversation)
+	if m.FirstResponse != 100*time.Second || m.TimeToClose != 200*time.Second {
+		t.Errorf("First response was %s, time to close %s", m.FirstResponse, m.TimeToClose)
+	}
+	if m.Reopens != 2 || m.AdminReplies["1"] != 1 || m.AdminReplies["2"] != 1 || len(m.AdminReplies) != 2 {
+		t.Errorf("Reopens were %d, replies %v", m.Reopens, m.AdminReplies)
+	}
+	if m = (Engine{CountBotReplies: true}).Conversation(conversation); m.FirstResponse != time.Minute || m.AdminReplies["b1"] != 1 {
+		t.Errorf("First response counting bots was %s", m.FirstResponse)
+	}
+}
+
+func TestConversationMetricsFromStatistics(t *testing.T) {
+	conversation := testConversationJSON(t, `{"id": "7", "created_at": 1000, "statistics": {
+		"first_contact_reply_at": 1000, "first_admin_reply_at": 1030, "first_close_at": 1600, "count_reopens": 3}}`)
+	m := Engine{}.Conversation(conversation)
+	if m.FirstResponse != 30*time.Second || m.TimeToClose != 10*time.Minute || m.Reopens != 3 {
+		t.Errorf("Metrics were %+v", m)
+	}
+}
+
+func TestRun(t *testing.T) {
+	conversations := []intercom.Conversation{
+		testConversation(t, "../fixtures/conversation.json"),
+		testConversationJSON(t, `{"id": "8", "created_at": 2000, "source": {"author": {"type": "lead", "id": "l1"}},
+			"conversation_parts": {"conversation_parts": [
+				{"part_type": "comment", "created_at": 2010, "author": {"type": "admin", "id": "25"}}
+			]}}`),
+	}
+	report, err := Engine{Percentiles: []float64{50, 99}}.Run(Conversations(conversations...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := report.Summary
+	if s.Conversations != 2 || s.Open != 0 || len(s.FirstResponse) != 2 || len(s.TimeToClose) != 1 || s.AdminReplies["25"] != 2 {
+		t.Errorf("Summary was %+v", s)
+	}
+	if p := s.FirstResponse.Percentile(50); p != 10*time.Second {
+		t.Errorf("Median first response was %s", p)
+	}
+	if p := s.FirstResponse.Percentile(99); p != 93*time.Second {
+		t.Errorf("99th percentile first response was %s", p)
+	}
+}
+
+func TestRunWithParts(t *testing.T) {
+	listed := testConversationJSON(t, `{"id": "147"}`)
+	found := testConversation(t, "../fixtures/conversation.json")
+	report, err := Engine{}.Run(WithParts(Conversations(listed, listed), func(id string) (intercom.Conversation, error) {
+		if len(id) == 0 {
+			t.Errorf("Expected the id of the conversation to find")
+		}
+		return found, nil
+	}))
+	if err != nil || len(report.Conversations) != 2 || report.Conversations[1].AdminReplies["25"] != 1 {
+		t.Errorf("Report was %+v, error %v", report, err)
+	}
+
+	report, err = Engine{}.Run(WithParts(Conversations(listed, listed), func(id string) (intercom.Conversation, error) {
+		return intercom.Conversation{}, errors.New("not found")
+	}))
+	if err == nil || len(report.Conversations) != 0 {
+		t.Errorf("Expected the find error, report was %+v", report)
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	d := Distribution{4 * time.Second, time.Second, 3 * time.Second, 2 * time.Second}
+	if d.Percentile(50) != 2*time.Second || d.Percentile(90) != 4*time.Second || d.Percentile(0) != time.Second {
+		t.Errorf("Percentiles were %s, %s, %s", d.Percentile(50), d.Percentile(90), d.Percentile(0))
+	}
+	if d.Mean() != 2500*time.Millisecond || d.Max() != 4*time.Second {
+		t.Errorf("Mean was %s, max %s", d.Mean(), d.Max())
+	}
+	if (Distribution{}).Percentile(50) != 0 || (Distribution{}).Mean() != 0 {
+		t.Errorf("Expected an empty distribution to be 0")
+	}
+}
+
+func TestReportCSV(t *testing.T) {
+	report := testReport(t)
+	out := &bytes.Buffer{}
+	if err := report.WriteCSV(out); err != nil {
+		t.Fatal(err)
+	}
+	expected := "conversation_id,created_at,open,first_contact_reply_at,first_response_seconds,time_to_close_seconds,reopens,admin_replies\n" +
+		"147,2014-05-23T13:16:13Z,false,2014-05-23T15:04:54Z,93,1200,0,1\n" +
+		"7,1970-01-01T00:16:40Z,false,,,,0,0\n"
+	if out.String() != expected {
+		t.Errorf("CSV was\n%s", out)
+	}
+
+	out.Reset()
+	if err := report.WriteSummaryCSV(out); err != nil {
+		t.Fatal(err)
+	}
+	expected = "metric,count,mean_seconds,p50_seconds,p90_seconds,p95_seconds,max_seconds\n" +
+		"first_response,1,93,93,93,93,93\n" +
+		"time_to_close,1,1200,1200,1200,1200,1200\n"
+	if out.String() != expected {
+		t.Errorf("Summary CSV was\n%s", out)
+	}
+
+	out.Reset()
+	if err := report.WriteAdminRepliesCSV(out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "admin_id,replies\n25,1\n" {
+		t.Errorf("Admin replies CSV was\n%s", out)
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	out := &bytes.Buffer{}
+	if err := testReport(t).WriteJSON(out); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Conversations []map[string]interface{}
+		Summary       struct {
+			Conversations int
+			FirstResponse map[string]float64 `json:"first_response_seconds"`
+		}
+	}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Conversations[0]["first_response_seconds"] != 93.0 || decoded.Conversations[1]["time_to_close_seconds"] != nil {
+		t.Errorf("Conversations were %v", decoded.Conversations)
+	}
+	if decoded.Summary.Conversations != 2 || decoded.Summary.FirstResponse["p95"] != 93 || decoded.Summary.FirstResponse["count"] != 1 {
+		t.Errorf("Summary was %+v", decoded.Summary)
+	}
+	if !strings.Contains(out.String(), `"admin_replies": {`) {
+		t.Errorf("Expected admin replies in\n%s", out)
+	}
+}
+
+func testReport(t *testing.T) Report {
+	report, err := Engine{}.Run(Conversations(
+		testConversation(t, "../fixtures/conversation.json"),
+		testConversationJSON(t, `{"id": "7", "created_at": 1000}`),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func testConversation(t *testing.T, filename string) intercom.Conversation {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testConversationJSON(t, string(data))
+}
+
+func testConversationJSON(t *testing.T, data string) intercom.Conversation {
+	var conversation intercom.Conversation
+	if err := json.Unmarshal([]byte(data), &conversation); err != nil {
+		t.Fatal(err)
+	}
+	return conversation
+}
@@ -0,0 +1,183 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// WriteCSV writes a row of metrics for each conversation, with durations in seconds.
+// Durations are left empty for conversations not responded to or not closed.
+func (r Report) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"conversation_id", "created_at", "open", "first_contact_reply_at",
+		"first_response_seconds", "time_to_close_seconds", "reopens", "admin_replies"})
+	for _, m := range r.Conversations {
+		replies := 0
+		for _, n := range m.AdminReplies {
+			replies += n
+		}
+		out.Write([]string{
+			m.ConversationID,
+			formatTime(m.CreatedAt),
+			strconv.FormatBool(m.Open),
+			formatTime(m.FirstContactReplyAt),
+			formatSeconds(m.FirstResponse, m.Responded),
+			formatSeconds(m.TimeToClose, m.Closed),
+			strconv.Itoa(m.Reopens),
+			strconv.Itoa(replies),
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// WriteSummaryCSV writes a row for each duration metric, with its count, mean, percentiles and max in seconds.
+func (r Report) WriteSummaryCSV(w io.Writer) error {
+	s := r.Summary
+	out := csv.NewWriter(w)
+	header := []string{"metric", "count", "mean_seconds"}
+	for _, p := range s.Percentiles {
+		header = append(header, "p"+formatPercentile(p)+"_seconds")
+	}
+	out.Write(append(header, "max_seconds"))
+	for _, metric := range []struct {
+		name string
+		d    Distribution
+	}{{"first_response", s.FirstResponse}, {"time_to_close", s.TimeToClose}} {
+		row := []string{metric.name, strconv.Itoa(len(metric.d)), formatSeconds(metric.d.Mean(), true)}
+		for _, p := range s.Percentiles {
+			row = append(row, formatSeconds(metric.d.Percentile(p), true))
+		}
+		out.Write(append(row, formatSeconds(metric.d.Max(), true)))
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// WriteAdminRepliesCSV writes the reply count of each admin, most replies first.
+func (r Report) WriteAdminRepliesCSV(w io.Writer) error {
+	adminIDs := make([]string, 0, len(r.Summary.AdminReplies))
+	for adminID := range r.Summary.AdminReplies {
+		adminIDs = append(adminIDs, adminID)
+	}
+	sort.Slice(adminIDs, func(i, j int) bool {
+		a, b := r.Summary.AdminReplies[adminIDs[i]], r.Summary.AdminReplies[adminIDs[j]]
+		return a > b || (a == b && adminIDs[i] < adminIDs[j])
+	})
+	out := csv.NewWriter(w)
+	out.Write([]string{"admin_id", "replies"})
+	for _, adminID := range adminIDs {
+		out.Write([]string{adminID, strconv.Itoa(r.Summary.AdminReplies[adminID])})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// WriteJSON writes the Report as JSON, with durations in seconds.
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// MarshalJSON encodes the Report with its conversations and summary.
+func (r Report) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Conversations []ConversationMetrics `json:"conversations"`
+		Summary       Summary               `json:"summary"`
+	}{r.Conversations, r.Summary})
+}
+
+// MarshalJSON encodes durations in seconds, and null for conversations not responded to or not closed.
+func (m ConversationMetrics) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ConversationID      string         `json:"conversation_id"`
+		CreatedAt           int64          `json:"created_at"`
+		Open                bool           `json:"open"`
+		FirstContactReplyAt *int64         `json:"first_contact_reply_at"`
+		FirstResponse       *float64       `json:"first_response_seconds"`
+		TimeToClose         *float64       `json:"time_to_close_seconds"`
+		Reopens             int            `json:"reopens"`
+		AdminReplies        map[string]int `json:"admin_replies"`
+	}{
+		ConversationID:      m.ConversationID,
+		CreatedAt:           m.CreatedAt.Unix(),
+		Open:                m.Open,
+		FirstContactReplyAt: unixOrNil(m.FirstContactReplyAt),
+		FirstResponse:       secondsOrNil(m.FirstResponse, m.Responded),
+		TimeToClose:         secondsOrNil(m.TimeToClose, m.Closed),
+		Reopens:             m.Reopens,
+		AdminReplies:        m.AdminReplies,
+	})
+}
+
+// MarshalJSON encodes the Summary with the statistics of its Distributions.
+func (s Summary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Conversations int                `json:"conversations"`
+		Open          int                `json:"open"`
+		FirstResponse map[string]float64 `json:"first_response_seconds"`
+		TimeToClose   map[string]float64 `json:"time_to_close_seconds"`
+		Reopens       int                `json:"reopens"`
+		Reopened      int                `json:"reopened"`
+		AdminReplies  map[string]int     `json:"admin_replies"`
+	}{
+		Conversations: s.Conversations,
+		Open:          s.Open,
+		FirstResponse: s.distributionJSON(s.FirstResponse),
+		TimeToClose:   s.distributionJSON(s.TimeToClose),
+		Reopens:       s.Reopens,
+		Reopened:      s.Reopened,
+		AdminReplies:  s.AdminReplies,
+	})
+}
+
+func (s Summary) distributionJSON(d Distribution) map[string]float64 {
+	stats := map[string]float64{
+		"count": float64(len(d)),
+		"mean":  d.Mean().Seconds(),
+		"max":   d.Max().Seconds(),
+	}
+	for _, p := range s.Percentiles {
+		stats["p"+formatPercentile(p)] = d.Percentile(p).Seconds()
+	}
+	return stats
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatSeconds(d time.Duration, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+func formatPercentile(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+func unixOrNil(t time.Time) *int64 {
+	if t.IsZero() {
+		return nil
+	}
+	seconds := t.Unix()
+	return &seconds
+}
+
+func secondsOrNil(d time.Duration, ok bool) *float64 {
+	if !ok {
+		return nil
+	}
+	seconds := d.Seconds()
+	return &seconds
+}
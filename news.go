@@ -0,0 +1,199 @@
+package intercom
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NewsService handles interactions with the API through a NewsRepository.
+// The News API is only available from API version 2.10, see ApiVersion.
+type NewsService struct {
+	Repository NewsRepository
+}
+
+// NewsItemList holds a page of NewsItems
+type NewsItemList struct {
+	Type       string     `json:"type,omitempty"`
+	Pages      PageParams `json:"pages"`
+	TotalCount int64      `json:"total_count"`
+	NewsItems  []NewsItem `json:"data"`
+}
+
+// A NewsItem is a post of Intercom News, such as release notes. Its Body is HTML.
+// Not all of the fields are writeable to the API, non-writeable fields are
+// stripped out from the request. Please see the API documentation for details.
+type NewsItem struct {
+	Type                string               `json:"type,omitempty"`
+	ID                  string               `json:"id,omitempty"`
+	WorkspaceID         string               `json:"workspace_id,omitempty"`
+	Title               string               `json:"title"`
+	Body                string               `json:"body,omitempty"`
+	SenderID            int64                `json:"sender_id"`       // The ID of the Admin sending the NewsItem.
+	State               string               `json:"state,omitempty"` // "draft" or "live"
+	NewsfeedAssignments []NewsfeedAssignment `json:"newsfeed_assignments,omitempty"`
+	Labels              []string             `json:"labels,omitempty"`
+	CoverImageURL       string               `json:"cover_image_url,omitempty"`
+	Reactions           []string             `json:"reactions,omitempty"`        // Up to 4 emoji readers can react with.
+	DeliverSilently     bool                 `json:"deliver_silently,omitempty"` // Publish without notifying contacts.
+	CreatedAt           int64                `json:"created_at,omitempty"`
+	UpdatedAt           int64                `json:"updated_at,omitempty"`
+}
+
+// A NewsfeedAssignment publishes a NewsItem in a Newsfeed.
+type NewsfeedAssignment struct {
+	NewsfeedID  int64 `json:"newsfeed_id"`
+	PublishedAt int64 `json:"published_at,omitempty"` // Defaults to when the NewsItem goes live.
+}
+
+// NewsfeedList holds a list of Newsfeeds
+type NewsfeedList struct {
+	Type       string     `json:"type,omitempty"`
+	Pages      PageParams `json:"pages"`
+	TotalCount int64      `json:"total_count"`
+	Newsfeeds  []Newsfeed `json:"data"`
+}
+
+// A Newsfeed is a feed of NewsItems shown in the Messenger.
+type Newsfeed struct {
+	Type      string `json:"type,omitempty"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at,omitempty"`
+	UpdatedAt int64  `json:"updated_at,omitempty"`
+}
+
+var newsItemStates = [...]string{
+	"draft",
+	"live",
+}
+
+const maxNewsItemReactions = 4
+
+// ListItems lists NewsItems
+func (n *NewsService) ListItems(params PageParams) (NewsItemList, error) {
+	return n.Repository.listItems(params)
+}
+
+// IterateItems returns a NewsItemIterator over all NewsItems, fetching pages of perPage as needed.
+// A perPage of 0 uses the API default.
+func (n *NewsService) IterateItems(perPage int64) *NewsItemIterator {
+	return &NewsItemIterator{pageIterator[NewsItem]{fetch: func(page int64) ([]NewsItem, PageParams, error) {
+		list, err := n.ListItems(PageParams{Page: page, PerPage: perPage})
+		return list.NewsItems, list.Pages, err
+	}}}
+}
+
+// FindItem finds a NewsItem by its ID
+func (n *NewsService) FindItem(id string) (NewsItem, error) {
+	if id == "" {
+		return NewsItem{}, errors.New("Missing News Item ID")
+	}
+	return n.Repository.findItem(id)
+}
+
+// CreateItem creates a NewsItem. A Title and SenderID are required; NewsItems are drafts unless State is "live".
+func (n *NewsService) CreateItem(item *NewsItem) (NewsItem, error) {
+	if err := validateNewsItem(item); err != nil {
+		return NewsItem{}, err
+	}
+	return n.Repository.createItem(item)
+}
+
+// UpdateItem updates a NewsItem. As with CreateItem, a Title and SenderID are required.
+func (n *NewsService) UpdateItem(item *NewsItem) (NewsItem, error) {
+	if item.ID == "" {
+		return NewsItem{}, errors.New("Missing News Item ID")
+	}
+	if err := validateNewsItem(item); err != nil {
+		return NewsItem{}, err
+	}
+	return n.Repository.updateItem(item)
+}
+
+// PublishItem makes a NewsItem live in its Newsfeeds.
+func (n *NewsService) PublishItem(id string) (NewsItem, error) {
+	return n.setItemState(id, "live")
+}
+
+// UnpublishItem makes a NewsItem a draft again.
+func (n *NewsService) UnpublishItem(id string) (NewsItem, error) {
+	return n.setItemState(id, "draft")
+}
+
+// setItemState finds the NewsItem to update, as updates require its Title and SenderID.
+func (n *NewsService) setItemState(id, state string) (NewsItem, error) {
+	item, err := n.FindItem(id)
+	if err != nil {
+		return NewsItem{}, err
+	}
+	item.State = state
+	return n.UpdateItem(&item)
+}
+
+// DeleteItem deletes a NewsItem by its ID
+func (n *NewsService) DeleteItem(id string) (DeletedObject, error) {
+	if id == "" {
+		return DeletedObject{}, errors.New("Missing News Item ID")
+	}
+	return n.Repository.deleteItem(id)
+}
+
+// ListNewsfeeds lists Newsfeeds
+func (n *NewsService) ListNewsfeeds() (NewsfeedList, error) {
+	return n.Repository.listNewsfeeds()
+}
+
+// FindNewsfeed finds a Newsfeed by its ID
+func (n *NewsService) FindNewsfeed(id string) (Newsfeed, error) {
+	if id == "" {
+		return Newsfeed{}, errors.New("Missing Newsfeed ID")
+	}
+	return n.Repository.findNewsfeed(id)
+}
+
+// ListNewsfeedItems lists the live NewsItems of a Newsfeed
+func (n *NewsService) ListNewsfeedItems(newsfeedID string) (NewsItemList, error) {
+	if newsfeedID == "" {
+		return NewsItemList{}, errors.New("Missing Newsfeed ID")
+	}
+	return n.Repository.listNewsfeedItems(newsfeedID)
+}
+
+func validateNewsItem(item *NewsItem) error {
+	if item.Title == "" {
+		return errors.New("Missing News Item Title")
+	}
+	if item.SenderID == 0 {
+		return errors.New("Missing News Item Sender")
+	}
+	if item.State != "" && !containsString(newsItemStates[:], item.State) {
+		return fmt.Errorf("Invalid News Item State %q", item.State)
+	}
+	if len(item.Reactions) > maxNewsItemReactions {
+		return fmt.Errorf("Too Many News Item Reactions, at most %d are allowed", maxNewsItemReactions)
+	}
+	for _, assignment := range item.NewsfeedAssignments {
+		if assignment.NewsfeedID == 0 {
+			return errors.New("Missing Newsfeed ID")
+		}
+	}
+	return nil
+}
+
+// NewsItemIterator iterates over NewsItems across pages.
+type NewsItemIterator struct {
+	pageIterator[NewsItem]
+}
+
+// NewsItem returns the current NewsItem.
+func (it *NewsItemIterator) NewsItem() NewsItem {
+	return it.current()
+}
+
+func (n NewsItem) String() string {
+	return fmt.Sprintf("[intercom] news_item { id: %s, title: %s, state: %s }", n.ID, n.Title, n.State)
+}
+
+func (n Newsfeed) String() string {
+	return fmt.Sprintf("[intercom] newsfeed { id: %s, name: %s }", n.ID, n.Name)
+}
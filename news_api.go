@@ -0,0 +1,111 @@
+package intercom
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
+
+// NewsRepository defines the interface for working with NewsItems and Newsfeeds through the API.
+type NewsRepository interface {
+	listItems(PageParams) (NewsItemList, error)
+	findItem(id string) (NewsItem, error)
+	createItem(*NewsItem) (NewsItem, error)
+	updateItem(*NewsItem) (NewsItem, error)
+	deleteItem(id string) (DeletedObject, error)
+	listNewsfeeds() (NewsfeedList, error)
+	findNewsfeed(id string) (Newsfeed, error)
+	listNewsfeedItems(newsfeedID string) (NewsItemList, error)
+}
+
+// NewsAPI implements NewsRepository
+type NewsAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+type requestNewsItem struct {
+	Title               string               `json:"title"`
+	Body                string               `json:"body,omitempty"`
+	SenderID            int64                `json:"sender_id"`
+	State               string               `json:"state,omitempty"`
+	DeliverSilently     bool                 `json:"deliver_silently,omitempty"`
+	Labels              []string             `json:"labels,omitempty"`
+	Reactions           []string             `json:"reactions,omitempty"`
+	NewsfeedAssignments []NewsfeedAssignment `json:"newsfeed_assignments,omitempty"`
+}
+
+func (api NewsAPI) listItems(params PageParams) (NewsItemList, error) {
+	return unmarshalToNewsItemList(api.httpClient.Get("/news/news_items", params))
+}
+
+func (api NewsAPI) findItem(id string) (NewsItem, error) {
+	return unmarshalToNewsItem(api.httpClient.Get(fmt.Sprintf("/news/news_items/%s", id), nil))
+}
+
+func (api NewsAPI) createItem(item *NewsItem) (NewsItem, error) {
+	return unmarshalToNewsItem(api.httpClient.Post("/news/news_items", newRequestNewsItem(item)))
+}
+
+func (api NewsAPI) updateItem(item *NewsItem) (NewsItem, error) {
+	return unmarshalToNewsItem(api.httpClient.Put(fmt.Sprintf("/news/news_items/%s", item.ID), newRequestNewsItem(item)))
+}
+
+func (api NewsAPI) deleteItem(id string) (DeletedObject, error) {
+	return unmarshalToDeletedObject(api.httpClient.Delete(fmt.Sprintf("/news/news_items/%s", id), nil))
+}
+
+func (api NewsAPI) listNewsfeeds() (NewsfeedList, error) {
+	list := NewsfeedList{}
+	data, err := api.httpClient.Get("/news/newsfeeds", nil)
+	if err != nil {
+		return list, err
+	}
+	err = json.Unmarshal(data, &list)
+	return list, err
+}
+
+func (api NewsAPI) findNewsfeed(id string) (Newsfeed, error) {
+	newsfeed := Newsfeed{}
+	data, err := api.httpClient.Get(fmt.Sprintf("/news/newsfeeds/%s", id), nil)
+	if err != nil {
+		return newsfeed, err
+	}
+	err = json.Unmarshal(data, &newsfeed)
+	return newsfeed, err
+}
+
+func (api NewsAPI) listNewsfeedItems(newsfeedID string) (NewsItemList, error) {
+	return unmarshalToNewsItemList(api.httpClient.Get(fmt.Sprintf("/news/newsfeeds/%s/items", newsfeedID), nil))
+}
+
+func newRequestNewsItem(item *NewsItem) *requestNewsItem {
+	return &requestNewsItem{
+		Title:               item.Title,
+		Body:                item.Body,
+		SenderID:            item.SenderID,
+		State:               item.State,
+		DeliverSilently:     item.DeliverSilently,
+		Labels:              item.Labels,
+		Reactions:           item.Reactions,
+		NewsfeedAssignments: item.NewsfeedAssignments,
+	}
+}
+
+func unmarshalToNewsItem(data []byte, err error) (NewsItem, error) {
+	savedItem := NewsItem{}
+	if err != nil {
+		return savedItem, err
+	}
+	err = json.Unmarshal(data, &savedItem)
+	return savedItem, err
+}
+
+func unmarshalToNewsItemList(data []byte, err error) (NewsItemList, error) {
+	list := NewsItemList{}
+	if err != nil {
+		return list, err
+	}
+	err = json.Unmarshal(data, &list)
+	return list, err
+}
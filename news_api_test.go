@@ -0,0 +1,93 @@
+package intercom
+
+import "testing"
+
+func TestNewsAPIListItems(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/news_items.json", expectedURI: "/news/news_items"}
+	api := NewsAPI{httpClient: &http}
+	list, err := api.listItems(PageParams{PerPage: 10})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if list.TotalCount != 2 || len(list.NewsItems) != 2 {
+		t.Errorf("List was %v", list)
+	}
+	if draft := list.NewsItems[1]; draft.State != "draft" || !draft.DeliverSilently || len(draft.NewsfeedAssignments) != 0 {
+		t.Errorf("Second news item was %v", draft)
+	}
+}
+
+func TestNewsAPIFindItem(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/news_item.json", expectedURI: "/news/news_items/33"}
+	api := NewsAPI{httpClient: &http}
+	item, err := api.findItem("33")
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if item.Title != "We have news" || item.SenderID != 991267834 || item.State != "live" || item.CoverImageURL != "" {
+		t.Errorf("News item was %v", item)
+	}
+	if len(item.NewsfeedAssignments) != 1 || item.NewsfeedAssignments[0].NewsfeedID != 53 || item.NewsfeedAssignments[0].PublishedAt != 1718870456 {
+		t.Errorf("Newsfeed assignments were %v", item.NewsfeedAssignments)
+	}
+	if len(item.Labels) != 2 || item.Labels[1] != "Product" || len(item.Reactions) != 2 || item.Reactions[0] != "😆" {
+		t.Errorf("Labels were %v, reactions %v", item.Labels, item.Reactions)
+	}
+}
+
+func TestNewsAPICreateItem(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/news_item.json", expectedURI: "/news/news_items"}
+	http.f = func(body interface{}) {
+		request := body.(*requestNewsItem)
+		if request.Title != "We have news" || request.SenderID != 991267834 || request.NewsfeedAssignments[0].NewsfeedID != 53 || request.Labels[0] != "New" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := NewsAPI{httpClient: &http}
+	item, err := api.createItem(&NewsItem{ID: "ignored", Title: "We have news", SenderID: 991267834, Labels: []string{"New"},
+		NewsfeedAssignments: []NewsfeedAssignment{{NewsfeedID: 53}}})
+	if err != nil || item.ID != "33" {
+		t.Errorf("Created %v, error %v", item, err)
+	}
+}
+
+func TestNewsAPIUpdateItem(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/news_item.json", expectedURI: "/news/news_items/33"}
+	http.f = func(body interface{}) {
+		if request := body.(*requestNewsItem); request.State != "live" || request.Title != "We have news" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := NewsAPI{httpClient: &http}
+	api.updateItem(&NewsItem{ID: "33", Title: "We have news", SenderID: 991267834, State: "live"})
+}
+
+func TestNewsAPIDeleteItem(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/news_item_deleted.json", expectedURI: "/news/news_items/33"}
+	api := NewsAPI{httpClient: &http}
+	deleted, err := api.deleteItem("33")
+	if err != nil || deleted.ID != "33" || deleted.Object != "news-item" || !deleted.Deleted {
+		t.Errorf("Deleted %+v, error %v", deleted, err)
+	}
+}
+
+func TestNewsAPINewsfeeds(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/newsfeeds.json", expectedURI: "/news/newsfeeds"}
+	api := NewsAPI{httpClient: &http}
+	list, err := api.listNewsfeeds()
+	if err != nil || len(list.Newsfeeds) != 2 || list.Newsfeeds[1].Name != "Customer Feed" {
+		t.Errorf("List was %v, error %v", list, err)
+	}
+
+	http = TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/newsfeed.json", expectedURI: "/news/newsfeeds/53"}
+	api = NewsAPI{httpClient: &http}
+	if newsfeed, err := api.findNewsfeed("53"); err != nil || newsfeed.Name != "Visitor Feed" {
+		t.Errorf("Newsfeed was %v, error %v", newsfeed, err)
+	}
+
+	http = TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/news_items.json", expectedURI: "/news/newsfeeds/53/items"}
+	api = NewsAPI{httpClient: &http}
+	if items, err := api.listNewsfeedItems("53"); err != nil || len(items.NewsItems) != 2 {
+		t.Errorf("Items were %v, error %v", items, err)
+	}
+}
@@ -0,0 +1,102 @@
+package intercom
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestNewsCreateItem(t *testing.T) {
+	news := NewsService{Repository: &TestNewsAPI{t: t}}
+	invalid := []struct {
+		item     NewsItem
+		expected string
+	}{
+		{NewsItem{SenderID: 1}, "Missing News Item Title"},
+		{NewsItem{Title: "Release notes"}, "Missing News Item Sender"},
+		{NewsItem{Title: "Release notes", SenderID: 1, State: "published"}, `Invalid News Item State "published"`},
+		{NewsItem{Title: "Release notes", SenderID: 1, Reactions: []string{"👍", "👎", "🎉", "❤️", "🚀"}}, "Too Many News Item Reactions, at most 4 are allowed"},
+		{NewsItem{Title: "Release notes", SenderID: 1, NewsfeedAssignments: []NewsfeedAssignment{{PublishedAt: 1718870456}}}, "Missing Newsfeed ID"},
+	}
+	for _, c := range invalid {
+		if _, err := news.CreateItem(&c.item); err == nil || err.Error() != c.expected {
+			t.Errorf("Expected %s, got %v", c.expected, err)
+		}
+	}
+	item, err := news.CreateItem(&NewsItem{Title: "Release notes", SenderID: 1, State: "live"})
+	if err != nil || item.ID != "1" {
+		t.Errorf("Created %v, error %v", item, err)
+	}
+}
+
+func TestNewsPublishing(t *testing.T) {
+	repo := &TestNewsAPI{t: t}
+	news := NewsService{Repository: repo}
+	news.PublishItem("33")
+	if repo.updated.ID != "33" || repo.updated.State != "live" || repo.updated.Title != "Release notes" || repo.updated.SenderID != 1 {
+		t.Errorf("Published %v", repo.updated)
+	}
+	news.UnpublishItem("33")
+	if repo.updated.State != "draft" {
+		t.Errorf("Unpublished %v", repo.updated)
+	}
+	if _, err := news.PublishItem(""); err == nil {
+		t.Errorf("Expected publishing without ID to fail")
+	}
+	if _, err := news.UpdateItem(&NewsItem{Title: "Release notes", SenderID: 1}); err == nil {
+		t.Errorf("Expected an update without ID to fail")
+	}
+}
+
+func TestNewsIterateItems(t *testing.T) {
+	news := NewsService{Repository: &TestNewsAPI{t: t}}
+	it := news.IterateItems(1)
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.NewsItem().ID)
+	}
+	if it.Err() != nil || len(ids) != 2 || ids[1] != "2" {
+		t.Errorf("Iterated %v, error %v", ids, it.Err())
+	}
+}
+
+type TestNewsAPI struct {
+	t       *testing.T
+	updated *NewsItem
+}
+
+func (t *TestNewsAPI) listItems(params PageParams) (NewsItemList, error) {
+	list := NewsItemList{Pages: PageParams{Page: params.Page, PerPage: params.PerPage, TotalPages: 2}}
+	if params.Page <= 2 {
+		list.NewsItems = []NewsItem{{ID: strconv.FormatInt(params.Page, 10)}}
+	}
+	return list, nil
+}
+
+func (t *TestNewsAPI) findItem(id string) (NewsItem, error) {
+	return NewsItem{ID: id, Title: "Release notes", SenderID: 1, State: "draft"}, nil
+}
+
+func (t *TestNewsAPI) createItem(item *NewsItem) (NewsItem, error) {
+	return NewsItem{ID: "1", Title: item.Title}, nil
+}
+
+func (t *TestNewsAPI) updateItem(item *NewsItem) (NewsItem, error) {
+	t.updated = item
+	return *item, nil
+}
+
+func (t *TestNewsAPI) deleteItem(id string) (DeletedObject, error) {
+	return DeletedObject{ID: id, Object: "news-item", Deleted: true}, nil
+}
+
+func (t *TestNewsAPI) listNewsfeeds() (NewsfeedList, error) {
+	return NewsfeedList{}, nil
+}
+
+func (t *TestNewsAPI) findNewsfeed(id string) (Newsfeed, error) {
+	return Newsfeed{ID: id}, nil
+}
+
+func (t *TestNewsAPI) listNewsfeedItems(newsfeedID string) (NewsItemList, error) {
+	return NewsItemList{}, nil
+}
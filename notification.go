@@ -1,10 +1,19 @@
 package intercom
 
 import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"hash"
 	"io"
+	"strings"
 )
 
+// NotificationSignatureHeader is the header holding the signature of webhook notifications.
+const NotificationSignatureHeader = "X-Hub-Signature"
+
 // Notification is the object delivered to a webhook.
 type Notification struct {
 	ID               string        `json:"id,omitempty"`
@@ -18,6 +27,7 @@ type Notification struct {
 	Tag              *Tag          `json:"-"`
 	Company          *Company      `json:"-"`
 	Event            *Event        `json:"-"`
+	Visitor          *Visitor      `json:"-"`
 }
 
 // Data is the data node of the notification.
@@ -68,6 +78,32 @@ func NewNotification(r io.Reader) (*Notification, error) {
 		e := &Event{}
 		json.Unmarshal(notification.RawData.Item, e)
 		notification.Event = e
+	case "visitor.signed_up":
+		v := &Visitor{}
+		json.Unmarshal(notification.RawData.Item, v)
+		notification.Visitor = v
 	}
 	return notification, nil
 }
+
+// VerifyNotificationSignature reports whether signature is the HMAC of body with the client secret of the app.
+// The signature is the hex digest prefixed with "sha1=" as in the X-Hub-Signature header of notifications,
+// "sha256=", or unprefixed for a SHA-256 digest as in the X-Body-Signature header of Canvas Kit requests.
+func VerifyNotificationSignature(clientSecret string, body []byte, signature string) bool {
+	var h func() hash.Hash
+	switch {
+	case strings.HasPrefix(signature, "sha1="):
+		h, signature = sha1.New, strings.TrimPrefix(signature, "sha1=")
+	case strings.HasPrefix(signature, "sha256="):
+		h, signature = sha256.New, strings.TrimPrefix(signature, "sha256=")
+	default:
+		h = sha256.New
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || clientSecret == "" {
+		return false
+	}
+	mac := hmac.New(h, []byte(clientSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -150,3 +150,43 @@ func TestParsingEventFromReader(t *testing.T) {
 		}
 	}
 }
+
+func TestParsingVisitorFromReader(t *testing.T) {
+	payload, _ := ioutil.ReadFile("fixtures/visitor.json")
+	r := strings.NewReader(fmt.Sprintf(`{
+		"topic": "visitor.signed_up",
+		"data": {
+			"item": %s
+		}
+	}`, string(payload)))
+	n, _ := NewNotification(r)
+	if n.Visitor == nil || n.Visitor.UserID != "8a88a590-e1c3-41e2-a502-e0649dbf721c" {
+		t.Errorf("Notification did not have Visitor")
+	}
+	if n.User != nil {
+		t.Errorf("Notification should not have User")
+	}
+}
+
+func TestVerifyNotificationSignature(t *testing.T) {
+	body := []byte(`{"topic":"ping"}`)
+	valid := []string{
+		"sha1=07c1f97674ea1af81d933c17af167b9300d3ec6e",
+		"sha256=62788ec8674b5a392b45e9dfcb997ed0b66db24526269af9ea6bf76b5860414f",
+		"62788ec8674b5a392b45e9dfcb997ed0b66db24526269af9ea6bf76b5860414f",
+	}
+	for _, signature := range valid {
+		if !VerifyNotificationSignature("secret", body, signature) {
+			t.Errorf("Expected %s to be valid", signature)
+		}
+	}
+	invalid := []string{"", "sha1=", "sha1=07c1f97674ea1af81d933c17af167b9300d3ec6f", "sha1=not hex", valid[2][:10]}
+	for _, signature := range invalid {
+		if VerifyNotificationSignature("secret", body, signature) {
+			t.Errorf("Expected %s to be invalid", signature)
+		}
+	}
+	if VerifyNotificationSignature("other", body, valid[0]) || VerifyNotificationSignature("", body, valid[0]) {
+		t.Errorf("Expected a signature with another secret to be invalid")
+	}
+}
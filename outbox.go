@@ -0,0 +1,385 @@
+package intercom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+)
+
+// Outbox kinds of OutboxEntry
+const (
+	OUTBOX_EVENT = "event"
+	OUTBOX_USER  = "user"
+)
+
+// An Outbox durably queues Event and User writes in an append-only file while the API is unavailable,
+// and replays them in order once it recovers. Entries are deduplicated by an idempotency key,
+// and survive process restarts.
+type Outbox struct {
+	// OnDiscard is called for queued entries which retrying cannot send, such as those the API rejected.
+	// They are dropped rather than blocking the entries queued after them.
+	OnDiscard func(OutboxEntry, error)
+	// OnError is called with the errors of the replays and compactions Run makes.
+	OnError func(error)
+	// KeyRetention is how long the idempotency key of a sent write is remembered. Defaults to 7 days.
+	KeyRetention time.Duration
+
+	events *EventService
+	users  *UserService
+	path   string
+
+	replay  sync.Mutex // Serializes Replay, which sends without holding mu.
+	mu      sync.Mutex
+	file    *os.File
+	seq     int64
+	pending []OutboxEntry    // Ordered by Seq.
+	sending bool             // A save is sending directly; later writes queue behind it.
+	keys    map[string]int64 // When each key was sent, in Unix seconds, or 0 while it is pending.
+	records int              // Records appended since the file was compacted.
+	now     func() time.Time
+}
+
+// An OutboxEntry is a queued Event or User write.
+type OutboxEntry struct {
+	Seq       int64  `json:"seq"`
+	Key       string `json:"key"`
+	Kind      string `json:"kind"`
+	Event     *Event `json:"event,omitempty"`
+	User      *User  `json:"user,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type outboxRecord struct {
+	Op    string       `json:"op"`
+	Entry *OutboxEntry `json:"entry,omitempty"`
+	Seq   int64        `json:"seq,omitempty"`
+	Key   string       `json:"key,omitempty"`
+	At    int64        `json:"at,omitempty"`
+}
+
+const (
+	outboxEnqueue = "enqueue"
+	outboxAck     = "ack"
+
+	outboxKeyRetention   = 7 * 24 * time.Hour
+	outboxCompactRecords = 1000 // Records appended before Run compacts the file.
+)
+
+// OpenOutbox opens (or creates) the Outbox stored at path, restoring the entries still pending.
+func OpenOutbox(path string, events *EventService, users *UserService) (*Outbox, error) {
+	o := &Outbox{events: events, users: users, path: path, keys: map[string]int64{}, now: time.Now}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.load(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	o.file = file
+	return o, nil
+}
+
+// SaveEvent saves an Event, queueing it if the API is unavailable or earlier writes are still queued.
+// An empty key generates one. Writes whose key was already seen are ignored.
+// Only errors which retrying cannot fix are returned.
+func (o *Outbox) SaveEvent(key string, event *Event) error {
+	return o.save(OutboxEntry{Key: key, Kind: OUTBOX_EVENT, Event: event})
+}
+
+// SaveUser saves a User, queueing it if the API is unavailable or earlier writes are still queued.
+// An empty key generates one. Writes whose key was already seen are ignored.
+// Only errors which retrying cannot fix are returned.
+func (o *Outbox) SaveUser(key string, user *User) error {
+	return o.save(OutboxEntry{Key: key, Kind: OUTBOX_USER, User: user})
+}
+
+// Replay sends the queued entries in order, stopping at the first one the API is still unavailable for.
+// Entries which retrying cannot send are discarded, see OnDiscard. It returns the number of entries sent.
+// Writes can be saved, and are queued, while Replay is sending.
+func (o *Outbox) Replay(ctx context.Context) (int, error) {
+	o.replay.Lock()
+	defer o.replay.Unlock()
+	sent := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+		o.mu.Lock()
+		if len(o.pending) == 0 || o.sending {
+			o.mu.Unlock()
+			return sent, nil // A failed direct send would be queued first: leave the rest to the next Replay.
+		}
+		entry := o.pending[0] // Only Replay removes entries, so it stays first while sending.
+		o.mu.Unlock()
+
+		err := o.deliver(entry)
+		if err != nil && retryableError(err) {
+			return sent, err
+		}
+		o.mu.Lock()
+		at := o.now().Unix()
+		ackErr := o.append(outboxRecord{Op: outboxAck, Seq: entry.Seq, Key: entry.Key, At: at})
+		if ackErr == nil {
+			o.pending = o.pending[1:]
+			o.keys[entry.Key] = at
+		}
+		o.mu.Unlock()
+		if ackErr != nil {
+			return sent, ackErr
+		}
+		if err != nil {
+			if o.OnDiscard != nil {
+				o.OnDiscard(entry, err)
+			}
+			continue
+		}
+		sent++
+	}
+}
+
+// Run replays the Outbox every interval until ctx is done, compacting its file as it grows.
+// Errors are passed to OnError.
+func (o *Outbox) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := o.Replay(ctx); err != nil && ctx.Err() == nil {
+			o.report(err)
+		}
+		o.mu.Lock()
+		compact := o.records >= outboxCompactRecords
+		o.mu.Unlock()
+		if compact {
+			if err := o.Compact(); err != nil {
+				o.report(fmt.Errorf("compacting outbox: %w", err))
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Pending returns the queued entries, oldest first.
+func (o *Outbox) Pending() []OutboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]OutboxEntry(nil), o.pending...)
+}
+
+// Len returns the number of queued entries.
+func (o *Outbox) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.pending)
+}
+
+// Compact rewrites the Outbox file with only the pending entries and the keys sent within KeyRetention,
+// forgetting older keys. Every write leaves a record in the file; Run compacts it as it grows.
+func (o *Outbox) Compact() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	for i := range o.pending {
+		if err := encoder.Encode(outboxRecord{Op: outboxEnqueue, Entry: &o.pending[i]}); err != nil {
+			return err
+		}
+	}
+	keys := map[string]int64{}
+	for key, at := range o.keys {
+		if at == 0 {
+			keys[key] = at // Pending, and already written with its entry.
+		} else if !o.expired(at) {
+			keys[key] = at
+			if err := encoder.Encode(outboxRecord{Op: outboxAck, Key: key, At: at}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The new file is opened before it replaces the old one, so that a failure leaves the Outbox writing to the old one.
+	tmp := o.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(buffer.Bytes())
+	if err == nil {
+		err = file.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp, o.path)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return err
+	}
+	o.file.Close()
+	o.file = file
+	o.keys = keys
+	o.records = 0
+	return nil
+}
+
+// Close closes the Outbox file. Pending entries are kept for the next OpenOutbox.
+func (o *Outbox) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.file.Close()
+}
+
+// save sends an entry directly when nothing is queued or being sent, without holding mu while sending,
+// and queues it otherwise. An entry which could not be sent directly is queued before those saved meanwhile.
+func (o *Outbox) save(entry OutboxEntry) error {
+	o.mu.Lock()
+	if entry.Key == "" {
+		entry.Key = uuid.New()
+	}
+	if at, seen := o.keys[entry.Key]; seen && !o.expired(at) {
+		o.mu.Unlock()
+		return nil
+	}
+	o.seq++
+	entry.Seq = o.seq
+	entry.CreatedAt = o.now().Unix()
+	o.keys[entry.Key] = 0
+	if len(o.pending) > 0 || o.sending {
+		defer o.mu.Unlock()
+		return o.enqueue(entry)
+	}
+	o.sending = true
+	o.mu.Unlock()
+
+	err := o.deliver(entry)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sending = false
+	switch {
+	case err == nil:
+		at := o.now().Unix()
+		o.keys[entry.Key] = at
+		return o.append(outboxRecord{Op: outboxAck, Key: entry.Key, At: at})
+	case retryableError(err):
+		return o.enqueue(entry)
+	default:
+		delete(o.keys, entry.Key)
+		return err
+	}
+}
+
+// enqueue appends an entry to the file and queues it in Seq order.
+func (o *Outbox) enqueue(entry OutboxEntry) error {
+	if err := o.append(outboxRecord{Op: outboxEnqueue, Entry: &entry}); err != nil {
+		delete(o.keys, entry.Key)
+		return err
+	}
+	i := sort.Search(len(o.pending), func(i int) bool { return o.pending[i].Seq > entry.Seq })
+	o.pending = append(o.pending[:i], append([]OutboxEntry{entry}, o.pending[i:]...)...)
+	return nil
+}
+
+func (o *Outbox) deliver(entry OutboxEntry) error {
+	switch entry.Kind {
+	case OUTBOX_EVENT:
+		return o.events.Save(entry.Event)
+	case OUTBOX_USER:
+		_, err := o.users.Save(entry.User)
+		return err
+	}
+	return fmt.Errorf("unknown outbox entry kind %q", entry.Kind)
+}
+
+func (o *Outbox) append(record outboxRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err := o.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	o.records++
+	return o.file.Sync()
+}
+
+// expired reports whether a key sent at has outlived KeyRetention. Pending keys never expire.
+func (o *Outbox) expired(at int64) bool {
+	retention := o.KeyRetention
+	if retention <= 0 {
+		retention = outboxKeyRetention
+	}
+	return at != 0 && o.now().Sub(time.Unix(at, 0)) > retention
+}
+
+func (o *Outbox) report(err error) {
+	if o.OnError != nil {
+		o.OnError(err)
+	}
+}
+
+// load replays the Outbox file, truncating a final record torn by a crash.
+func (o *Outbox) load(file *os.File) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	var offset int64
+	for len(data) > 0 {
+		end := bytes.IndexByte(data, '\n')
+		if end < 0 {
+			break
+		}
+		record := outboxRecord{}
+		if err := json.Unmarshal(data[:end], &record); err != nil {
+			if bytes.IndexByte(data[end+1:], '\n') >= 0 {
+				return fmt.Errorf("corrupt outbox record at offset %d: %v", offset, err)
+			}
+			break
+		}
+		o.apply(record)
+		o.records++
+		offset += int64(end + 1)
+		data = data[end+1:]
+	}
+	// An entry which failed to send directly is written after those queued while it was sent.
+	sort.SliceStable(o.pending, func(i, j int) bool { return o.pending[i].Seq < o.pending[j].Seq })
+	return file.Truncate(offset)
+}
+
+func (o *Outbox) apply(record outboxRecord) {
+	switch record.Op {
+	case outboxEnqueue:
+		if record.Entry == nil {
+			return
+		}
+		o.pending = append(o.pending, *record.Entry)
+		o.keys[record.Entry.Key] = 0
+		if record.Entry.Seq > o.seq {
+			o.seq = record.Entry.Seq
+		}
+	case outboxAck:
+		if record.At == 0 {
+			record.At = o.now().Unix() // Written before keys expired: keep it for a whole retention.
+		}
+		o.keys[record.Key] = record.At
+		for i := range o.pending {
+			if (record.Seq != 0 && o.pending[i].Seq == record.Seq) || (record.Seq == 0 && o.pending[i].Key == record.Key) {
+				o.pending = append(o.pending[:i], o.pending[i+1:]...)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testOutboxServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	down     bool
+	received []string
+	gate     chan struct{} // When set, each request waits for a value from it.
+}
+
+func newTestOutboxServer() *testOutboxServer {
+	s := &testOutboxServer{down: true}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.gate != nil {
+			<-s.gate
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["event_name"] == "rejected" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"type":"error.list","errors":[{"code":"parameter_invalid","message":"Invalid event"}]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/events":
+			s.received = append(s.received, "event:"+body["event_name"].(string))
+			w.WriteHeader(http.StatusAccepted)
+		case "/users":
+			s.received = append(s.received, "user:"+body["user_id"].(string))
+			if body["user_id"] == "garbled" {
+				w.Write([]byte(`{"type":"user",`))
+				return
+			}
+			w.Write([]byte(`{"type":"user","user_id":"` + body["user_id"].(string) + `"}`))
+		}
+	}))
+	return s
+}
+
+func (s *testOutboxServer) setDown(down bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.down = down
+}
+
+func TestOutboxReplaysAfterRestart(t *testing.T) {
+	server := newTestOutboxServer()
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	path := filepath.Join(t.TempDir(), "outbox.log")
+
+	outbox, err := OpenOutbox(path, &ic.Events, &ic.Users)
+	if err != nil {
+		t.Fatalf("Error opening outbox %s", err)
+	}
+	if err := outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"}); err != nil {
+		t.Errorf("Queued event returned %s", err)
+	}
+	outbox.SaveUser("u1", &User{UserID: "27", Name: "Jamie"})
+	outbox.SaveEvent("e2", &Event{UserID: "27", EventName: "second"})
+	outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"})
+	if outbox.Len() != 3 {
+		t.Errorf("Outbox had %d entries, expected 3", outbox.Len())
+	}
+	if _, err := outbox.Replay(context.Background()); err == nil {
+		t.Errorf("Replay should fail while the API is down")
+	}
+	outbox.Close()
+
+	server.setDown(false)
+	outbox, err = OpenOutbox(path, &ic.Events, &ic.Users)
+	if err != nil {
+		t.Fatalf("Error reopening outbox %s", err)
+	}
+	defer outbox.Close()
+	if outbox.Len() != 3 {
+		t.Fatalf("Reopened outbox had %d entries, expected 3", outbox.Len())
+	}
+	outbox.SaveEvent("e3", &Event{UserID: "27", EventName: "third"})
+	sent, err := outbox.Replay(context.Background())
+	if err != nil || sent != 4 {
+		t.Errorf("Replay sent %d with %v, expected 4", sent, err)
+	}
+	outbox.SaveEvent("e2", &Event{UserID: "27", EventName: "second"})
+	outbox.SaveEvent("e4", &Event{UserID: "27", EventName: "fourth"})
+
+	expected := []string{"event:first", "user:27", "event:second", "event:third", "event:fourth"}
+	if len(server.received) != len(expected) {
+		t.Fatalf("Server received %v, expected %v", server.received, expected)
+	}
+	for i := range expected {
+		if server.received[i] != expected[i] {
+			t.Errorf("Server received %v, expected %v", server.received, expected)
+			break
+		}
+	}
+}
+
+func TestOutboxDiscardsRejectedEntries(t *testing.T) {
+	server := newTestOutboxServer()
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	outbox, _ := OpenOutbox(filepath.Join(t.TempDir(), "outbox.log"), &ic.Events, &ic.Users)
+	defer outbox.Close()
+	var discarded []OutboxEntry
+	outbox.OnDiscard = func(entry OutboxEntry, err error) { discarded = append(discarded, entry) }
+
+	outbox.SaveEvent("", &Event{UserID: "27", EventName: "rejected"})
+	outbox.SaveEvent("", &Event{UserID: "27", EventName: "accepted"})
+	server.setDown(false)
+	sent, _ := outbox.Replay(context.Background())
+	if sent != 1 || len(discarded) != 1 || discarded[0].Event.EventName != "rejected" {
+		t.Errorf("Sent %d and discarded %v", sent, discarded)
+	}
+	if err := outbox.SaveEvent("", &Event{UserID: "27", EventName: "rejected"}); err == nil {
+		t.Errorf("Rejected event should return an error when sent directly")
+	}
+}
+
+func TestOutboxCompactAndTornRecord(t *testing.T) {
+	server := newTestOutboxServer()
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	outbox, _ := OpenOutbox(path, &ic.Events, &ic.Users)
+	outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"})
+	server.setDown(false)
+	outbox.Replay(context.Background())
+	outbox.SaveEvent("e2", &Event{UserID: "27", EventName: "second"})
+	if err := outbox.Compact(); err != nil {
+		t.Fatalf("Error compacting %s", err)
+	}
+	outbox.Close()
+
+	file, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	file.Write([]byte(`{"op":"enqueue","entry":{"seq":9,"key":"e9"`))
+	file.Close()
+
+	outbox, err := OpenOutbox(path, &ic.Events, &ic.Users)
+	if err != nil {
+		t.Fatalf("Error reopening outbox with torn record %s", err)
+	}
+	defer outbox.Close()
+	if outbox.Len() != 0 {
+		t.Errorf("Outbox had %d entries, expected 0", outbox.Len())
+	}
+	outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"})
+	if len(server.received) != 2 {
+		t.Errorf("Server received %v, e1 should have been deduplicated", server.received)
+	}
+}
+
+func TestOutboxDiscardsUndecodableEntries(t *testing.T) {
+	server := newTestOutboxServer()
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	outbox, _ := OpenOutbox(filepath.Join(t.TempDir(), "outbox.log"), &ic.Events, &ic.Users)
+	defer outbox.Close()
+	var discarded []OutboxEntry
+	outbox.OnDiscard = func(entry OutboxEntry, err error) { discarded = append(discarded, entry) }
+
+	outbox.SaveUser("", &User{UserID: "garbled"})
+	outbox.SaveEvent("", &Event{UserID: "27", EventName: "accepted"})
+	server.setDown(false)
+	sent, err := outbox.Replay(context.Background())
+	if err != nil || sent != 1 || len(discarded) != 1 || outbox.Len() != 0 {
+		t.Errorf("Sent %d with %v, discarded %v, %d pending", sent, err, discarded, outbox.Len())
+	}
+}
+
+func TestOutboxExpiresKeys(t *testing.T) {
+	server := newTestOutboxServer()
+	defer server.Close()
+	server.setDown(false)
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	outbox, _ := OpenOutbox(path, &ic.Events, &ic.Users)
+	now := time.Unix(1700000000, 0)
+	outbox.now = func() time.Time { return now }
+	outbox.KeyRetention = 24 * time.Hour
+
+	outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"})
+	now = now.Add(12 * time.Hour)
+	outbox.SaveEvent("e2", &Event{UserID: "27", EventName: "second"})
+	outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"})
+	now = now.Add(13 * time.Hour)
+	if err := outbox.Compact(); err != nil {
+		t.Fatalf("Error compacting %s", err)
+	}
+	outbox.Close()
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), `"e1"`) || !strings.Contains(string(data), `"e2"`) {
+		t.Errorf("Compacted outbox was %s, expected only e2", data)
+	}
+
+	outbox, _ = OpenOutbox(path, &ic.Events, &ic.Users)
+	defer outbox.Close()
+	outbox.now = func() time.Time { return now }
+	outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"})
+	outbox.SaveEvent("e2", &Event{UserID: "27", EventName: "second"})
+	if strings.Join(server.received, ",") != "event:first,event:second,event:first" {
+		t.Errorf("Server received %v, e1 should have expired and e2 not", server.received)
+	}
+}
+
+func TestOutboxRunReportsErrors(t *testing.T) {
+	server := newTestOutboxServer()
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	outbox, _ := OpenOutbox(filepath.Join(t.TempDir(), "outbox.log"), &ic.Events, &ic.Users)
+	defer outbox.Close()
+	errs := make(chan error, 10)
+	outbox.OnError = func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+	outbox.SaveEvent("", &Event{UserID: "27", EventName: "first"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go outbox.Run(ctx, time.Millisecond)
+	select {
+	case err := <-errs:
+		if herr, ok := err.(IntercomError); !ok || herr.GetStatusCode() != 503 {
+			t.Errorf("Reported %v, expected the API to be unavailable", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Run did not report the failed replay")
+	}
+}
+
+func TestOutboxSavesWhileReplaying(t *testing.T) {
+	server := newTestOutboxServer()
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	outbox, _ := OpenOutbox(filepath.Join(t.TempDir(), "outbox.log"), &ic.Events, &ic.Users)
+	defer outbox.Close()
+	outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"})
+	server.setDown(false)
+	server.gate = make(chan struct{})
+
+	replayed := make(chan int)
+	go func() {
+		sent, _ := outbox.Replay(context.Background())
+		replayed <- sent
+	}()
+	saved := make(chan error)
+	go func() { saved <- outbox.SaveEvent("e2", &Event{UserID: "27", EventName: "second"}) }()
+	select {
+	case err := <-saved:
+		if err != nil || outbox.Len() != 2 {
+			t.Errorf("Save while replaying returned %v with %d pending, expected it queued", err, outbox.Len())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Save blocked while Replay was sending")
+	}
+	close(server.gate)
+	if sent := <-replayed; sent != 2 {
+		t.Errorf("Replay sent %d, expected 2", sent)
+	}
+}
+
+func TestOutboxSendsWithoutLocking(t *testing.T) {
+	server := newTestOutboxServer()
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	outbox, _ := OpenOutbox(path, &ic.Events, &ic.Users)
+	server.gate = make(chan struct{})
+
+	saved := make(chan error)
+	go func() { saved <- outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"}) }()
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		outbox.mu.Lock()
+		sending := outbox.sending
+		outbox.mu.Unlock()
+		if sending {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("First save was not sent")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := outbox.SaveEvent("e2", &Event{UserID: "27", EventName: "second"}); err != nil || outbox.Len() != 1 {
+		t.Errorf("Save while sending returned %v with %d pending, expected it queued", err, outbox.Len())
+	}
+	server.gate <- struct{}{} // The server is down: e1 is queued, ahead of e2.
+	if err := <-saved; err != nil {
+		t.Errorf("Queued event returned %s", err)
+	}
+	outbox.Close()
+
+	outbox, _ = OpenOutbox(path, &ic.Events, &ic.Users)
+	defer outbox.Close()
+	if pending := outbox.Pending(); len(pending) != 2 || pending[0].Key != "e1" || pending[1].Key != "e2" {
+		t.Errorf("Pending was %+v, expected e1 then e2", pending)
+	}
+}
+
+func TestOutboxCompactFailureKeepsWriting(t *testing.T) {
+	server := newTestOutboxServer()
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	outbox, _ := OpenOutbox(path, &ic.Events, &ic.Users)
+	outbox.SaveEvent("e1", &Event{UserID: "27", EventName: "first"})
+	if err := os.Mkdir(path+".tmp", 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := outbox.Compact(); err == nil {
+		t.Errorf("Expected compacting to fail")
+	}
+	outbox.SaveEvent("e2", &Event{UserID: "27", EventName: "second"})
+	outbox.Close()
+
+	outbox, err := OpenOutbox(path, &ic.Events, &ic.Users)
+	if err != nil {
+		t.Fatalf("Error reopening outbox %s", err)
+	}
+	defer outbox.Close()
+	if outbox.Len() != 2 {
+		t.Errorf("Outbox had %d entries after a failed compaction, expected 2", outbox.Len())
+	}
+}
@@ -1,8 +1,78 @@
 package intercom
 
+import "net/url"
+
 // PageParams determine paging information to and from the API
 type PageParams struct {
 	Page       int64 `json:"page" url:"page,omitempty"`
 	PerPage    int64 `json:"per_page" url:"per_page,omitempty"`
 	TotalPages int64 `json:"total_pages" url:"-"`
 }
+
+// pageURLParams carries the query of a page URL returned by the API (such as pages.next),
+// so it can be requested again through the HTTPClient.
+type pageURLParams struct {
+	Query pageURLQuery `url:"query"`
+}
+
+type pageURLQuery url.Values
+
+func (q pageURLQuery) EncodeValues(_ string, v *url.Values) error {
+	for key, values := range q {
+		for _, value := range values {
+			v.Add(key, value)
+		}
+	}
+	return nil
+}
+
+// parsePageURL splits a page URL into the path and query params to request it with.
+func parsePageURL(pageURL string) (string, pageURLParams, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return "", pageURLParams{}, err
+	}
+	return u.Path, pageURLParams{Query: pageURLQuery(u.Query())}, nil
+}
+
+// pageIterator walks numbered pages until the last page reported by PageParams.
+type pageIterator[T any] struct {
+	fetch func(page int64) ([]T, PageParams, error)
+	items []T
+	pages PageParams
+	index int
+	page  int64
+	err   error
+}
+
+// Next advances to the next item, returning false when there are no more items or an error occurred.
+func (it *pageIterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.index >= len(it.items) {
+		if it.page > 0 && (len(it.items) == 0 || it.page >= it.pages.TotalPages) {
+			return false
+		}
+		it.page++
+		it.items, it.pages, it.err = it.fetch(it.page)
+		it.index = 0
+		if it.err != nil {
+			return false
+		}
+		if it.pages.Page > 0 {
+			it.page = it.pages.Page
+		}
+	}
+	it.index++
+	return true
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *pageIterator[T]) Err() error {
+	return it.err
+}
+
+func (it *pageIterator[T]) current() T {
+	return it.items[it.index-1]
+}
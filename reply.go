@@ -2,15 +2,24 @@ package intercom
 
 // A Reply to an Intercom conversation
 type Reply struct {
-	Type           string   `json:"type"`
-	ReplyType      string   `json:"message_type"`
-	Body           string   `json:"body,omitempty"`
-	AssigneeID     string   `json:"assignee_id,omitempty"`
-	AdminID        string   `json:"admin_id,omitempty"`
-	IntercomID     string   `json:"intercom_user_id,omitempty"`
-	Email          string   `json:"email,omitempty"`
-	UserID         string   `json:"user_id,omitempty"`
-	AttachmentURLs []string `json:"attachment_urls,omitempty"`
+	Type            string                `json:"type"`
+	ReplyType       string                `json:"message_type"`
+	Body            string                `json:"body,omitempty"`
+	AssigneeID      string                `json:"assignee_id,omitempty"`
+	AdminID         string                `json:"admin_id,omitempty"`
+	IntercomID      string                `json:"intercom_user_id,omitempty"`
+	Email           string                `json:"email,omitempty"`
+	UserID          string                `json:"user_id,omitempty"`
+	AttachmentURLs  []string              `json:"attachment_urls,omitempty"`
+	AttachmentFiles []ReplyAttachmentFile `json:"attachment_files,omitempty"`
+	SnoozedUntil    int64                 `json:"snoozed_until,omitempty"`
+}
+
+// A ReplyAttachmentFile is a file uploaded with a Reply, its Data base64 encoded.
+type ReplyAttachmentFile struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Data        string `json:"data"`
 }
 
 // ReplyType determines the type of Reply
@@ -22,6 +31,7 @@ const (
 	CONVERSATION_ASSIGN
 	CONVERSATION_OPEN
 	CONVERSATION_CLOSE
+	CONVERSATION_SNOOZED
 )
 
 var replyTypes = [...]string{
@@ -30,6 +40,7 @@ var replyTypes = [...]string{
 	"assignment",
 	"open",
 	"close",
+	"snoozed",
 }
 
 func (reply ReplyType) String() string {
@@ -0,0 +1,128 @@
+package intercom
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Limits on the files uploaded with a Reply.
+const (
+	MaxReplyAttachments     = 10       // Files per Reply.
+	MaxReplyAttachmentSize  = 10 << 20 // Bytes per file, before encoding.
+	MaxReplyAttachmentsSize = 25 << 20 // Bytes of all the files of a Reply, before encoding.
+)
+
+// DefaultReplyAttachmentTypes are the media types ReplyWithAttachments uploads unless
+// ConversationService.AttachmentTypes is set: images, PDFs, text and office documents.
+var DefaultReplyAttachmentTypes = [...]string{
+	"image/*",
+	"application/pdf",
+	"text/plain",
+	"text/csv",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"application/vnd.ms-powerpoint",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// A ReplyAttachment is a file to upload with a Reply.
+// An empty ContentType is detected from the data.
+type ReplyAttachment struct {
+	Name        string
+	ContentType string
+	Data        io.Reader
+}
+
+// ReplyWithAttachments replies to a Conversation uploading files, rather than linking to hosted ones.
+// The attachments are read and validated before anything is sent.
+func (c *ConversationService) ReplyWithAttachments(id string, author MessagePerson, replyType ReplyType, body string, attachments []ReplyAttachment) (Conversation, error) {
+	allowed := c.AttachmentTypes
+	if len(allowed) == 0 {
+		allowed = DefaultReplyAttachmentTypes[:]
+	}
+	files, err := newReplyAttachmentFiles(attachments, allowed)
+	if err != nil {
+		return Conversation{}, err
+	}
+	reply := c.buildReply(author, replyType, body, nil)
+	reply.AttachmentFiles = files
+	return c.Repository.reply(id, &reply)
+}
+
+func newReplyAttachmentFiles(attachments []ReplyAttachment, allowed []string) ([]ReplyAttachmentFile, error) {
+	if len(attachments) > MaxReplyAttachments {
+		return nil, fmt.Errorf("Too Many Attachments: %d, at most %d", len(attachments), MaxReplyAttachments)
+	}
+	files := make([]ReplyAttachmentFile, 0, len(attachments))
+	remaining := MaxReplyAttachmentsSize
+	for _, attachment := range attachments {
+		file, size, err := attachment.file(allowed, remaining)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+		remaining -= size
+	}
+	return files, nil
+}
+
+// file reads and encodes a ReplyAttachment, which must fit in the remaining bytes of the Reply.
+func (a ReplyAttachment) file(allowed []string, remaining int) (ReplyAttachmentFile, int, error) {
+	if a.Name == "" {
+		return ReplyAttachmentFile{}, 0, errors.New("Missing Attachment Name")
+	}
+	if a.Data == nil {
+		return ReplyAttachmentFile{}, 0, fmt.Errorf("Missing Attachment Data for %s", a.Name)
+	}
+	limit := MaxReplyAttachmentSize
+	if remaining < limit {
+		limit = remaining
+	}
+	data, err := io.ReadAll(io.LimitReader(a.Data, int64(limit)+1))
+	if err != nil {
+		return ReplyAttachmentFile{}, 0, err
+	}
+	if len(data) == 0 {
+		return ReplyAttachmentFile{}, 0, fmt.Errorf("Empty Attachment %s", a.Name)
+	}
+	if len(data) > MaxReplyAttachmentSize {
+		return ReplyAttachmentFile{}, 0, fmt.Errorf("Attachment %s is larger than %d bytes", a.Name, MaxReplyAttachmentSize)
+	}
+	if len(data) > remaining {
+		return ReplyAttachmentFile{}, 0, fmt.Errorf("Attachments are larger than %d bytes in total", MaxReplyAttachmentsSize)
+	}
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.Contains(mediaType, "/") {
+		return ReplyAttachmentFile{}, 0, fmt.Errorf("Invalid Content Type %q for %s", contentType, a.Name)
+	}
+	if !mediaTypeAllowed(allowed, mediaType) {
+		return ReplyAttachmentFile{}, 0, fmt.Errorf("Content Type %s of %s is not allowed", mediaType, a.Name)
+	}
+	return ReplyAttachmentFile{
+		Name:        a.Name,
+		ContentType: mime.FormatMediaType(mediaType, params),
+		Data:        base64.StdEncoding.EncodeToString(data),
+	}, len(data), nil
+}
+
+// mediaTypeAllowed reports whether a media type is listed, exactly or by a "type/*" wildcard.
+func mediaTypeAllowed(allowed []string, mediaType string) bool {
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(pattern)
+		if pattern == mediaType || (strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*"))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package intercom
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReplyWithAttachments(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, reply interface{}) {
+		files := reply.(*Reply).AttachmentFiles
+		if len(files) != 2 {
+			t.Fatalf("Sent %d files, expected 2", len(files))
+		}
+		if files[0] != (ReplyAttachmentFile{Name: "invoice.pdf", ContentType: "application/pdf", Data: "JVBERi0xLjQ="}) {
+			t.Errorf("First file was %v", files[0])
+		}
+		if files[1].ContentType != "text/plain; charset=utf-8" {
+			t.Errorf("Detected content type was %s", files[1].ContentType)
+		}
+		if reply.(*Reply).AdminID != "abc123" || reply.(*Reply).ReplyType != "comment" {
+			t.Errorf("Reply was %v", reply)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	_, err := conversationService.ReplyWithAttachments("123", &Admin{ID: "abc123"}, CONVERSATION_COMMENT, "Your invoice", []ReplyAttachment{
+		{Name: "invoice.pdf", ContentType: "application/pdf", Data: strings.NewReader("%PDF-1.4")},
+		{Name: "notes.txt", Data: strings.NewReader("Thanks for your order")},
+	})
+	if err != nil {
+		t.Errorf("Reply failed %s", err)
+	}
+}
+
+func TestReplyWithAttachmentsValidation(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, reply interface{}) {
+		t.Errorf("Invalid attachments should not be sent")
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	invalid := map[string][]ReplyAttachment{
+		"too large":    {{Name: "big.bin", ContentType: "application/octet-stream", Data: bytes.NewReader(make([]byte, MaxReplyAttachmentSize+1))}},
+		"empty":        {{Name: "empty.txt", Data: strings.NewReader("")}},
+		"no name":      {{Data: strings.NewReader("data")}},
+		"content type": {{Name: "a.txt", ContentType: "not a type", Data: strings.NewReader("data")}},
+		"read error":   {{Name: "a.txt", Data: &errorReader{}}},
+		"too many":     make([]ReplyAttachment, MaxReplyAttachments+1),
+		"not allowed":  {{Name: "setup.exe", ContentType: "application/x-msdownload", Data: strings.NewReader("MZ")}},
+		"undetected":   {{Name: "blob", Data: bytes.NewReader([]byte{0, 1, 2})}},
+		"too large in total": {
+			{Name: "a.pdf", ContentType: "application/pdf", Data: bytes.NewReader(make([]byte, MaxReplyAttachmentSize))},
+			{Name: "b.pdf", ContentType: "application/pdf", Data: bytes.NewReader(make([]byte, MaxReplyAttachmentSize))},
+			{Name: "c.pdf", ContentType: "application/pdf", Data: bytes.NewReader(make([]byte, MaxReplyAttachmentsSize-2*MaxReplyAttachmentSize+1))},
+		},
+	}
+	for name, attachments := range invalid {
+		if _, err := conversationService.ReplyWithAttachments("123", &Admin{ID: "abc123"}, CONVERSATION_COMMENT, "", attachments); err == nil {
+			t.Errorf("Expected %s to fail validation", name)
+		}
+	}
+}
+
+func TestReplyWithAttachmentsTypes(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, reply interface{}) {
+		if files := reply.(*Reply).AttachmentFiles; len(files) != 1 || files[0].ContentType != "application/zip" {
+			t.Errorf("Files were %v", files)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI, AttachmentTypes: []string{"application/zip"}}
+	_, err := conversationService.ReplyWithAttachments("123", &Admin{ID: "abc123"}, CONVERSATION_COMMENT, "", []ReplyAttachment{
+		{Name: "logs.zip", ContentType: "application/zip", Data: strings.NewReader("PK")},
+	})
+	if err != nil {
+		t.Errorf("Reply failed %s", err)
+	}
+	_, err = conversationService.ReplyWithAttachments("123", &Admin{ID: "abc123"}, CONVERSATION_COMMENT, "", []ReplyAttachment{
+		{Name: "invoice.pdf", ContentType: "application/pdf", Data: strings.NewReader("%PDF-1.4")},
+	})
+	if err == nil || err.Error() != "Content Type application/pdf of invoice.pdf is not allowed" {
+		t.Errorf("Expected the PDF not to be allowed, got %v", err)
+	}
+}
+
+type errorReader struct{}
+
+func (r *errorReader) Read(p []byte) (int, error) {
+	return 0, errors.New("disk failure")
+}
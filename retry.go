@@ -0,0 +1,120 @@
+package intercom
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// retrier sends the requests of the bulk helpers: it waits for its rate limiter before each attempt,
+// retries the requests which may succeed if retried with exponential backoff,
+// and pauses every request while the API is rate limiting.
+type retrier struct {
+	ctx        context.Context
+	maxRetries int
+	backoff    time.Duration
+	sleep      func(context.Context, time.Duration) error
+	limiter    *time.Ticker
+	retried    atomic.Int64
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// newRetrier returns a retrier sending at most requestsPerSecond requests, when positive. It must be stopped.
+func newRetrier(ctx context.Context, maxRetries int, backoff time.Duration, requestsPerSecond float64, sleep func(context.Context, time.Duration) error) *retrier {
+	r := &retrier{ctx: ctx, maxRetries: maxRetries, backoff: backoff, sleep: sleep}
+	if requestsPerSecond > 0 {
+		r.limiter = time.NewTicker(time.Duration(float64(time.Second) / requestsPerSecond))
+	}
+	return r
+}
+
+// do sends a request until it succeeds, fails with an error which is not retryable, or runs out of retries.
+func (r *retrier) do(request func() error) error {
+	backoff := r.backoff
+	for attempt := 0; ; attempt++ {
+		if err := r.wait(); err != nil {
+			return err
+		}
+		err := request()
+		if err == nil || attempt >= r.maxRetries || !retryableError(err) {
+			return err
+		}
+		r.retried.Add(1)
+		if rateLimitedError(err) {
+			r.mu.Lock()
+			if until := time.Now().Add(backoff); until.After(r.pausedUntil) {
+				r.pausedUntil = until
+			}
+			r.mu.Unlock()
+		}
+		if err := r.sleep(r.ctx, backoff); err != nil {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// wait blocks while requests are paused by a rate limited one, and for the limiter.
+func (r *retrier) wait() error {
+	r.mu.Lock()
+	pause := time.Until(r.pausedUntil)
+	r.mu.Unlock()
+	if pause > 0 {
+		if err := r.sleep(r.ctx, pause); err != nil {
+			return err
+		}
+	}
+	if r.ctx.Err() != nil {
+		return r.ctx.Err()
+	}
+	if r.limiter == nil {
+		return nil
+	}
+	select {
+	case <-r.limiter.C:
+		return nil
+	case <-r.ctx.Done():
+		return r.ctx.Err()
+	}
+}
+
+func (r *retrier) stop() {
+	if r.limiter != nil {
+		r.limiter.Stop()
+	}
+}
+
+// retryableError reports whether a request may succeed if retried: network errors, rate limiting and server errors.
+// Other errors, such as other API errors or failing to encode a request or decode a response, are not.
+func retryableError(err error) bool {
+	var herr IntercomError
+	if errors.As(err, &herr) {
+		code := herr.GetStatusCode()
+		return code == 429 || code >= 500
+	}
+	var nerr net.Error
+	return errors.As(err, &nerr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// rateLimitedError reports whether the API rejected a request for exceeding the rate limit.
+func rateLimitedError(err error) bool {
+	var herr IntercomError
+	return errors.As(err, &herr) && herr.GetStatusCode() == 429
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
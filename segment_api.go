@@ -9,7 +9,7 @@ import (
 
 // SegmentRepository defines the interface for working with Segments through the API.
 type SegmentRepository interface {
-	list() (SegmentList, error)
+	list(params segmentListParams) (SegmentList, error)
 	find(id string) (Segment, error)
 }
 
@@ -18,9 +18,9 @@ type SegmentAPI struct {
 	httpClient interfaces.HTTPClient
 }
 
-func (api SegmentAPI) list() (SegmentList, error) {
+func (api SegmentAPI) list(params segmentListParams) (SegmentList, error) {
 	segmentList := SegmentList{}
-	data, err := api.httpClient.Get("/segments", nil)
+	data, err := api.httpClient.Get("/segments", params)
 	if err != nil {
 		return segmentList, err
 	}
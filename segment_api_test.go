@@ -7,8 +7,13 @@ import (
 
 func TestAPIListSegments(t *testing.T) {
 	http := TestSegmentHTTPClient{t: t, fixtureFilename: "fixtures/segments.json", expectedURI: "/segments"}
+	http.f = func(params interface{}) {
+		if params.(segmentListParams) != (segmentListParams{IncludeCount: true, Type: "contact"}) {
+			t.Errorf("Params were %v", params)
+		}
+	}
 	api := SegmentAPI{httpClient: &http}
-	segmentList, err := api.list()
+	segmentList, err := api.list(segmentListParams{IncludeCount: true, Type: "contact"})
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
@@ -18,6 +23,12 @@ func TestAPIListSegments(t *testing.T) {
 	if segmentList.Segments[0].PersonType != "user" {
 		t.Errorf("Segment list should generate person types from strings %s", segmentList.Segments[0].PersonType)
 	}
+	if segmentList.Segments[0].Count == nil || *segmentList.Segments[0].Count != 42 {
+		t.Errorf("Segment list should include counts")
+	}
+	if segmentList.Segments[1].Count != nil {
+		t.Errorf("Segment without count should have a nil Count")
+	}
 }
 
 func TestAPIFindSegment(t *testing.T) {
@@ -38,6 +49,7 @@ func TestAPIFindSegment(t *testing.T) {
 type TestSegmentHTTPClient struct {
 	TestHTTPClient
 	t               *testing.T
+	f               func(params interface{})
 	fixtureFilename string
 	expectedURI     string
 }
@@ -46,5 +58,8 @@ func (t TestSegmentHTTPClient) Get(uri string, params interface{}) ([]byte, erro
 	if uri != t.expectedURI {
 		t.t.Errorf("Wrong endpoint called")
 	}
+	if t.f != nil {
+		t.f(params)
+	}
 	return ioutil.ReadFile(t.fixtureFilename)
 }
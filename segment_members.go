@@ -0,0 +1,47 @@
+package intercom
+
+// IterateBySegment returns a ContactIterator over all the Contacts in a Segment, fetching pages of perPage as needed.
+// A perPage of 0 uses the API default.
+//
+//	it := ic.Contacts.IterateBySegment("5443ac9b316c12246c000005", 50)
+//	for it.Next() {
+//		fmt.Println(it.Contact())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+func (c *ContactService) IterateBySegment(segmentID string, perPage int64) *ContactIterator {
+	return &ContactIterator{pageIterator[Contact]{fetch: func(page int64) ([]Contact, PageParams, error) {
+		list, err := c.ListBySegment(segmentID, PageParams{Page: page, PerPage: perPage})
+		return list.Contacts, list.Pages, err
+	}}}
+}
+
+// IterateBySegment returns a CompanyIterator over all the Companies in a Segment, fetching pages of perPage as needed.
+// A perPage of 0 uses the API default.
+func (c *CompanyService) IterateBySegment(segmentID string, perPage int64) *CompanyIterator {
+	return &CompanyIterator{pageIterator[Company]{fetch: func(page int64) ([]Company, PageParams, error) {
+		list, err := c.ListBySegment(segmentID, PageParams{Page: page, PerPage: perPage})
+		return list.Companies, list.Pages, err
+	}}}
+}
+
+// ContactIterator iterates over Contacts across pages.
+type ContactIterator struct {
+	pageIterator[Contact]
+}
+
+// Contact returns the current Contact.
+func (it *ContactIterator) Contact() Contact {
+	return it.current()
+}
+
+// CompanyIterator iterates over Companies across pages.
+type CompanyIterator struct {
+	pageIterator[Company]
+}
+
+// Company returns the current Company.
+func (it *CompanyIterator) Company() Company {
+	return it.current()
+}
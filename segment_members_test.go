@@ -0,0 +1,68 @@
+package intercom
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContactIterateBySegment(t *testing.T) {
+	repo := &TestSegmentContactAPI{TestContactAPI: TestContactAPI{t: t}, pages: [][]Contact{
+		{{ID: "c1"}, {ID: "c2"}},
+		{{ID: "c3"}},
+	}}
+	it := (&ContactService{Repository: repo}).IterateBySegment("seg1", 2)
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.Contact().ID)
+	}
+	if it.Err() != nil {
+		t.Errorf("Iteration failed %s", it.Err())
+	}
+	if len(ids) != 3 || ids[0] != "c1" || ids[2] != "c3" {
+		t.Errorf("Iterated %v, expected c1, c2, c3", ids)
+	}
+	if len(repo.requests) != 2 || repo.requests[1].Page != 2 || repo.requests[1].PerPage != 2 || repo.requests[1].SegmentID != "seg1" {
+		t.Errorf("Requests were %v", repo.requests)
+	}
+}
+
+func TestCompanyIterateBySegment(t *testing.T) {
+	repo := &TestSegmentCompanyAPI{TestCompanyAPI: TestCompanyAPI{t: t}, err: errors.New("page failed")}
+	it := (&CompanyService{Repository: repo}).IterateBySegment("seg1", 0)
+	if !it.Next() || it.Company().ID != "co1" {
+		t.Errorf("Expected the first company")
+	}
+	if it.Next() {
+		t.Errorf("Expected the iteration to stop on error")
+	}
+	if it.Err() == nil {
+		t.Errorf("Expected the error to be returned")
+	}
+}
+
+type TestSegmentContactAPI struct {
+	TestContactAPI
+	pages    [][]Contact
+	requests []contactListParams
+}
+
+func (t *TestSegmentContactAPI) list(params contactListParams) (ContactList, error) {
+	t.requests = append(t.requests, params)
+	list := ContactList{Pages: PageParams{Page: params.Page, TotalPages: int64(len(t.pages))}}
+	if params.Page <= int64(len(t.pages)) {
+		list.Contacts = t.pages[params.Page-1]
+	}
+	return list, nil
+}
+
+type TestSegmentCompanyAPI struct {
+	TestCompanyAPI
+	err error
+}
+
+func (t *TestSegmentCompanyAPI) list(params companyListParams) (CompanyList, error) {
+	if params.Page > 1 {
+		return CompanyList{}, t.err
+	}
+	return CompanyList{Companies: []Company{{ID: "co1"}}, Pages: PageParams{Page: 1, TotalPages: 2}}, nil
+}
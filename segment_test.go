@@ -10,6 +10,19 @@ func TestListSegments(t *testing.T) {
 	}
 }
 
+func TestListSegmentsWithOptions(t *testing.T) {
+	segmentList, _ := (&SegmentService{Repository: TestSegmentAPI{t: t}}).List(WithCount(true), Type("company"))
+	if segmentList.Segments[0].Count == nil || *segmentList.Segments[0].Count != 3 {
+		t.Errorf("Segment count was not requested")
+	}
+	if segmentList.Segments[0].PersonType != "company" {
+		t.Errorf("Got segment of type %s, expected company", segmentList.Segments[0].PersonType)
+	}
+	if _, err := (&SegmentService{Repository: TestSegmentAPI{t: t}}).List(Type("conversation")); err == nil {
+		t.Errorf("Expected an error for an unknown type")
+	}
+}
+
 func TestFindSegment(t *testing.T) {
 	segment, _ := (&SegmentService{Repository: TestSegmentAPI{t: t}}).Find("de412cad4")
 	if segment.ID != "de412cad4" {
@@ -21,8 +34,13 @@ type TestSegmentAPI struct {
 	t *testing.T
 }
 
-func (t TestSegmentAPI) list() (SegmentList, error) {
-	return SegmentList{Segments: []Segment{Segment{ID: "de412cad4", Name: "My Tag"}}}, nil
+func (t TestSegmentAPI) list(params segmentListParams) (SegmentList, error) {
+	segment := Segment{ID: "de412cad4", Name: "My Tag", PersonType: params.Type}
+	if params.IncludeCount {
+		count := int64(3)
+		segment.Count = &count
+	}
+	return SegmentList{Segments: []Segment{segment}}, nil
 }
 
 func (t TestSegmentAPI) find(id string) (Segment, error) {
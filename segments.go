@@ -1,6 +1,9 @@
 package intercom
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // SegmentService handles interactions with the API through a SegmentRepository.
 type SegmentService struct {
@@ -14,6 +17,7 @@ type Segment struct {
 	CreatedAt  int64  `json:"created_at,omitempty"`
 	UpdatedAt  int64  `json:"updated_at,omitempty"`
 	PersonType string `json:"person_type,omitempty"`
+	Count      *int64 `json:"count,omitempty"`
 }
 
 // SegmentList, an object holding a list of Segments
@@ -21,9 +25,41 @@ type SegmentList struct {
 	Segments []Segment `json:"segments,omitempty"`
 }
 
+// A SegmentListOption filters or extends the Segments listed
+type SegmentListOption func(*segmentListParams)
+
+type segmentListParams struct {
+	IncludeCount bool   `url:"include_count,omitempty"`
+	Type         string `url:"type,omitempty"`
+}
+
+var segmentTypes = [...]string{"user", "contact", "company"}
+
+// WithCount includes the number of members in each listed Segment (see Segment.Count).
+// Counting can make the request noticeably slower.
+func WithCount(includeCount bool) SegmentListOption {
+	return func(p *segmentListParams) {
+		p.IncludeCount = includeCount
+	}
+}
+
+// Type lists only the Segments of a model: "contact" (the default, also "user") or "company".
+func Type(segmentType string) SegmentListOption {
+	return func(p *segmentListParams) {
+		p.Type = segmentType
+	}
+}
+
 // List all Segments for the App
-func (t *SegmentService) List() (SegmentList, error) {
-	return t.Repository.list()
+func (t *SegmentService) List(options ...SegmentListOption) (SegmentList, error) {
+	params := segmentListParams{}
+	for _, option := range options {
+		option(&params)
+	}
+	if params.Type != "" && !containsString(segmentTypes[:], params.Type) {
+		return SegmentList{}, errors.New("Unknown Segment Type")
+	}
+	return t.Repository.list(params)
 }
 
 // Find a particular Segment in the App
@@ -0,0 +1,142 @@
+package intercom
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SubscriptionService handles interactions with the API through a SubscriptionRepository.
+type SubscriptionService struct {
+	Repository SubscriptionRepository
+}
+
+// SubscriptionTypeList holds a list of SubscriptionTypes
+type SubscriptionTypeList struct {
+	Type              string             `json:"type,omitempty"`
+	SubscriptionTypes []SubscriptionType `json:"data"`
+}
+
+// A SubscriptionType is a kind of message contacts consent to, such as a newsletter.
+// Contacts receive opt-out types until they opt out, and opt-in types only once they opt in.
+type SubscriptionType struct {
+	Type               string                    `json:"type,omitempty"`
+	ID                 string                    `json:"id"`
+	State              string                    `json:"state,omitempty"`        // "live", "draft" or "archived"
+	ConsentType        string                    `json:"consent_type,omitempty"` // "opt_out" or "opt_in"
+	DefaultTranslation SubscriptionTranslation   `json:"default_translation"`
+	Translations       []SubscriptionTranslation `json:"translations,omitempty"`
+	ContentTypes       []string                  `json:"content_types,omitempty"` // "email" and "sms_message"
+}
+
+// SubscriptionTranslation is the name and description of a SubscriptionType in one locale.
+type SubscriptionTranslation struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+}
+
+// SubscriptionTypeReferences list the SubscriptionTypes a contact opted in to or out of.
+type SubscriptionTypeReferences struct {
+	Type       string                      `json:"type"`
+	Data       []SubscriptionTypeReference `json:"data"`
+	Url        string                      `json:"url"`
+	TotalCount int                         `json:"total_count"`
+	HasMore    bool                        `json:"has_more"`
+}
+
+// SubscriptionTypeReference identifies a SubscriptionType.
+type SubscriptionTypeReference struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	URL  string `json:"url,omitempty"`
+}
+
+var subscriptionConsentTypes = [...]string{
+	"opt_out",
+	"opt_in",
+}
+
+// ListTypes lists the SubscriptionTypes of the workspace.
+func (s *SubscriptionService) ListTypes() (SubscriptionTypeList, error) {
+	return s.Repository.listTypes()
+}
+
+// ListContactSubscriptions lists the SubscriptionTypes a Contact opted in to or out of.
+func (s *SubscriptionService) ListContactSubscriptions(contactID string) (SubscriptionTypeList, error) {
+	if contactID == "" {
+		return SubscriptionTypeList{}, errors.New("Missing Contact ID")
+	}
+	return s.Repository.listContactSubscriptions(contactID)
+}
+
+// ContactOptedIn reports whether a SearchContact receives the messages of a SubscriptionType,
+// listing the subscriptions of the Contact when those it was returned with are truncated.
+func (s *SubscriptionService) ContactOptedIn(contact SearchContact, subscriptionType SubscriptionType) (bool, error) {
+	references := contact.OptedOutSubscriptionTypes
+	if subscriptionType.ConsentType == "opt_in" {
+		references = contact.OptedInSubscriptionTypes
+	}
+	if !references.HasMore {
+		return contact.OptedIn(subscriptionType), nil
+	}
+	subscriptions, err := s.ListContactSubscriptions(contact.Id)
+	if err != nil {
+		return false, err
+	}
+	// Contacts are listed with the opt-in types they opted in to, and the opt-out types they opted out of.
+	listed := false
+	for _, subscription := range subscriptions.SubscriptionTypes {
+		listed = listed || subscription.ID == subscriptionType.ID
+	}
+	return listed == (subscriptionType.ConsentType == "opt_in"), nil
+}
+
+// OptIn opts a Contact in to receiving the messages of a SubscriptionType.
+func (s *SubscriptionService) OptIn(contactID string, subscriptionType SubscriptionType) (SubscriptionType, error) {
+	return s.SetConsent(contactID, subscriptionType, true)
+}
+
+// OptOut opts a Contact out of receiving the messages of a SubscriptionType.
+func (s *SubscriptionService) OptOut(contactID string, subscriptionType SubscriptionType) (SubscriptionType, error) {
+	return s.SetConsent(contactID, subscriptionType, false)
+}
+
+// SetConsent opts a Contact in to or out of a SubscriptionType, whose ID and ConsentType are required.
+// Contacts are added to opt-out types to opt out and to opt-in types to opt in, and removed to do the opposite.
+func (s *SubscriptionService) SetConsent(contactID string, subscriptionType SubscriptionType, optedIn bool) (SubscriptionType, error) {
+	if contactID == "" {
+		return SubscriptionType{}, errors.New("Missing Contact ID")
+	}
+	if subscriptionType.ID == "" {
+		return SubscriptionType{}, errors.New("Missing Subscription Type ID")
+	}
+	if !containsString(subscriptionConsentTypes[:], subscriptionType.ConsentType) {
+		return SubscriptionType{}, fmt.Errorf("Invalid Subscription Consent Type %q", subscriptionType.ConsentType)
+	}
+	if optedIn == (subscriptionType.ConsentType == "opt_in") {
+		return s.Repository.attach(contactID, subscriptionType.ID, subscriptionType.ConsentType)
+	}
+	return s.Repository.detach(contactID, subscriptionType.ID)
+}
+
+// OptedIn reports whether the SearchContact receives the messages of a SubscriptionType.
+// Its SubscriptionTypeReferences may be truncated, see HasMore; SubscriptionService.ContactOptedIn handles that.
+func (c SearchContact) OptedIn(subscriptionType SubscriptionType) bool {
+	if subscriptionType.ConsentType == "opt_in" {
+		return c.OptedInSubscriptionTypes.contains(subscriptionType.ID)
+	}
+	return !c.OptedOutSubscriptionTypes.contains(subscriptionType.ID)
+}
+
+func (r SubscriptionTypeReferences) contains(id string) bool {
+	for _, reference := range r.Data {
+		if reference.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s SubscriptionType) String() string {
+	return fmt.Sprintf("[intercom] subscription_type { id: %s, name: %s, consent_type: %s }", s.ID, s.DefaultTranslation.Name, s.ConsentType)
+}
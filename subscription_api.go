@@ -0,0 +1,61 @@
+package intercom
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
+
+// SubscriptionRepository defines the interface for working with SubscriptionTypes through the API.
+type SubscriptionRepository interface {
+	listTypes() (SubscriptionTypeList, error)
+	listContactSubscriptions(contactID string) (SubscriptionTypeList, error)
+	attach(contactID, subscriptionTypeID, consentType string) (SubscriptionType, error)
+	detach(contactID, subscriptionTypeID string) (SubscriptionType, error)
+}
+
+// SubscriptionAPI implements SubscriptionRepository
+type SubscriptionAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+type requestContactSubscription struct {
+	ID          string `json:"id"`
+	ConsentType string `json:"consent_type"`
+}
+
+func (api SubscriptionAPI) listTypes() (SubscriptionTypeList, error) {
+	return unmarshalToSubscriptionTypeList(api.httpClient.Get("/subscription_types", nil))
+}
+
+func (api SubscriptionAPI) listContactSubscriptions(contactID string) (SubscriptionTypeList, error) {
+	return unmarshalToSubscriptionTypeList(api.httpClient.Get(fmt.Sprintf("/contacts/%s/subscriptions", contactID), nil))
+}
+
+func (api SubscriptionAPI) attach(contactID, subscriptionTypeID, consentType string) (SubscriptionType, error) {
+	return unmarshalToSubscriptionType(api.httpClient.Post(fmt.Sprintf("/contacts/%s/subscriptions", contactID),
+		requestContactSubscription{ID: subscriptionTypeID, ConsentType: consentType}))
+}
+
+func (api SubscriptionAPI) detach(contactID, subscriptionTypeID string) (SubscriptionType, error) {
+	return unmarshalToSubscriptionType(api.httpClient.Delete(fmt.Sprintf("/contacts/%s/subscriptions/%s", contactID, subscriptionTypeID), nil))
+}
+
+func unmarshalToSubscriptionType(data []byte, err error) (SubscriptionType, error) {
+	subscriptionType := SubscriptionType{}
+	if err != nil {
+		return subscriptionType, err
+	}
+	err = json.Unmarshal(data, &subscriptionType)
+	return subscriptionType, err
+}
+
+func unmarshalToSubscriptionTypeList(data []byte, err error) (SubscriptionTypeList, error) {
+	list := SubscriptionTypeList{}
+	if err != nil {
+		return list, err
+	}
+	err = json.Unmarshal(data, &list)
+	return list, err
+}
@@ -0,0 +1,50 @@
+package intercom
+
+import "testing"
+
+func TestSubscriptionAPIListTypes(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/subscription_types.json", expectedURI: "/subscription_types"}
+	api := SubscriptionAPI{httpClient: &http}
+	list, err := api.listTypes()
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if len(list.SubscriptionTypes) != 2 {
+		t.Fatalf("List was %v", list)
+	}
+	if newsletters := list.SubscriptionTypes[0]; newsletters.ID != "37846" || newsletters.ConsentType != "opt_out" || newsletters.DefaultTranslation.Name != "Newsletters" {
+		t.Errorf("First subscription type was %v", newsletters)
+	}
+	if updates := list.SubscriptionTypes[1]; updates.Translations[1].Locale != "fr" || updates.ContentTypes[1] != "sms_message" {
+		t.Errorf("Second subscription type was %v", updates)
+	}
+}
+
+func TestSubscriptionAPIListContactSubscriptions(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/subscription_types.json", expectedURI: "/contacts/46adad3f09126dca/subscriptions"}
+	api := SubscriptionAPI{httpClient: &http}
+	if list, err := api.listContactSubscriptions("46adad3f09126dca"); err != nil || len(list.SubscriptionTypes) != 2 {
+		t.Errorf("List was %v, error %v", list, err)
+	}
+}
+
+func TestSubscriptionAPIAttach(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/subscription_type.json", expectedURI: "/contacts/46adad3f09126dca/subscriptions"}
+	http.f = func(body interface{}) {
+		if request := body.(requestContactSubscription); request.ID != "37847" || request.ConsentType != "opt_in" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := SubscriptionAPI{httpClient: &http}
+	if subscriptionType, err := api.attach("46adad3f09126dca", "37847", "opt_in"); err != nil || subscriptionType.ID != "37847" {
+		t.Errorf("Attached %v, error %v", subscriptionType, err)
+	}
+}
+
+func TestSubscriptionAPIDetach(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/subscription_type.json", expectedURI: "/contacts/46adad3f09126dca/subscriptions/37847"}
+	api := SubscriptionAPI{httpClient: &http}
+	if _, err := api.detach("46adad3f09126dca", "37847"); err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+}
@@ -0,0 +1,158 @@
+package intercom
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A ConsentRow is a row of a consent CSV, and the outcome of applying it.
+type ConsentRow struct {
+	Line         int
+	Email        string
+	Subscription string // The ID or name of the SubscriptionType.
+	OptedIn      bool
+	ContactIDs   []string // Contacts with the Email.
+	Changed      []string // Contacts whose consent changed, the others had consented already.
+	Err          error
+}
+
+// A ConsentReport lists the rows ApplyConsentCSV applied, and those it could not.
+type ConsentReport struct {
+	Applied []ConsentRow
+	Failed  []ConsentRow
+}
+
+var consentStates = map[string]bool{
+	"opted_in":  true,
+	"opt_in":    true,
+	"opted_out": false,
+	"opt_out":   false,
+}
+
+// ApplyConsentCSV opts contacts in to or out of SubscriptionTypes as listed by a CSV whose header names
+// its email, subscription and state columns, in any order. Subscriptions are SubscriptionType IDs or names,
+// and states "opted_in" or "opted_out". Every Contact with the email is updated, unless it consented already.
+// Rows which could not be applied are listed in the ConsentReport; an error is only returned if the CSV
+// or the SubscriptionTypes could not be read, or if ctx is done first.
+func (s *SubscriptionService) ApplyConsentCSV(ctx context.Context, contacts *ContactService, r io.Reader) (ConsentReport, error) {
+	report := ConsentReport{}
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1 // Short rows are reported rather than ending the import.
+	header, err := reader.Read()
+	if err != nil {
+		return report, fmt.Errorf("reading consent CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"email", "subscription", "state"} {
+		if _, ok := columns[name]; !ok {
+			return report, fmt.Errorf("consent CSV has no %s column", name)
+		}
+	}
+
+	types, err := s.ListTypes()
+	if err != nil {
+		return report, err
+	}
+	resolve := subscriptionTypeResolver(types.SubscriptionTypes)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		record, err := reader.Read()
+		if err == io.EOF {
+			return report, nil
+		}
+		if err != nil {
+			return report, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) < len(header) {
+			report.Failed = append(report.Failed, ConsentRow{
+				Line: line,
+				Err:  fmt.Errorf("Invalid Consent Row: %d of %d columns", len(record), len(header)),
+			})
+			continue
+		}
+		row := ConsentRow{
+			Line:         line,
+			Email:        strings.TrimSpace(record[columns["email"]]),
+			Subscription: strings.TrimSpace(record[columns["subscription"]]),
+		}
+		row.Err = s.applyConsent(contacts, resolve, &row, record[columns["state"]])
+		if row.Err != nil {
+			report.Failed = append(report.Failed, row)
+		} else {
+			report.Applied = append(report.Applied, row)
+		}
+	}
+}
+
+func (s *SubscriptionService) applyConsent(contacts *ContactService, resolve func(string) (SubscriptionType, error), row *ConsentRow, state string) error {
+	optedIn, ok := consentStates[strings.ToLower(strings.TrimSpace(state))]
+	if !ok {
+		return fmt.Errorf("Invalid Consent State %q", state)
+	}
+	row.OptedIn = optedIn
+	if row.Email == "" {
+		return errors.New("Missing Contact Email")
+	}
+	subscriptionType, err := resolve(row.Subscription)
+	if err != nil {
+		return err
+	}
+	result, err := contacts.Search(ContactSearchParams{Query: map[string]string{"field": "email", "operator": "=", "value": row.Email}})
+	if err != nil {
+		return err
+	}
+	if len(result.Data) == 0 {
+		return fmt.Errorf("No Contact With Email %s", row.Email)
+	}
+	for _, contact := range result.Data {
+		row.ContactIDs = append(row.ContactIDs, contact.Id)
+		consented, err := s.ContactOptedIn(contact, subscriptionType)
+		if err != nil {
+			return err
+		}
+		if consented == optedIn {
+			continue
+		}
+		if _, err := s.SetConsent(contact.Id, subscriptionType, optedIn); err != nil {
+			return err
+		}
+		row.Changed = append(row.Changed, contact.Id)
+	}
+	return nil
+}
+
+// subscriptionTypeResolver finds SubscriptionTypes by ID, or by the name of their default translation.
+func subscriptionTypeResolver(types []SubscriptionType) func(string) (SubscriptionType, error) {
+	byID := map[string]SubscriptionType{}
+	byName := map[string][]SubscriptionType{}
+	for _, subscriptionType := range types {
+		byID[subscriptionType.ID] = subscriptionType
+		name := strings.ToLower(subscriptionType.DefaultTranslation.Name)
+		byName[name] = append(byName[name], subscriptionType)
+	}
+	return func(subscription string) (SubscriptionType, error) {
+		if subscriptionType, ok := byID[subscription]; ok {
+			return subscriptionType, nil
+		}
+		switch matches := byName[strings.ToLower(subscription)]; len(matches) {
+		case 0:
+			return SubscriptionType{}, fmt.Errorf("Unknown Subscription Type %q", subscription)
+		case 1:
+			return matches[0], nil
+		default:
+			return SubscriptionType{}, fmt.Errorf("Ambiguous Subscription Type %q", subscription)
+		}
+	}
+}
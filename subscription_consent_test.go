@@ -0,0 +1,116 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func newTestConsentServer(t *testing.T, writes *[]string) *httptest.Server {
+	contacts := map[string]string{
+		"jamie@example.io": `[{"type":"contact","id":"c1","email":"jamie@example.io","role":"lead",
+			"opted_out_subscription_types":{"type":"list","data":[{"type":"subscription","id":"37846","url":"/subscriptions/37846"}]}},
+			{"type":"contact","id":"c2","email":"jamie@example.io","role":"user"}]`,
+		"casey@example.io": `[{"type":"contact","id":"c3","email":"casey@example.io",
+			"opted_in_subscription_types":{"type":"list","data":[{"type":"subscription","id":"37847"}]}}]`,
+		"taylor@example.io": `[{"type":"contact","id":"c4","email":"taylor@example.io"}]`,
+		"alex@example.io": `[{"type":"contact","id":"c5","email":"alex@example.io",
+			"opted_out_subscription_types":{"type":"list","data":[],"has_more":true}}]`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/subscription_types":
+			data, _ := ioutil.ReadFile("fixtures/subscription_types.json")
+			w.Write(data)
+		case r.URL.Path == "/contacts/search":
+			search := ContactSearchParams{}
+			json.NewDecoder(r.Body).Decode(&search)
+			data, ok := contacts[search.Query["value"]]
+			if !ok || search.Query["field"] != "email" {
+				data = "[]"
+			}
+			w.Write([]byte(`{"type":"list","data":` + data + `}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/contacts/c5/subscriptions":
+			data, _ := ioutil.ReadFile("fixtures/subscription_types.json")
+			w.Write(data)
+		case strings.HasSuffix(r.URL.Path, "/subscriptions") || strings.Contains(r.URL.Path, "/subscriptions/"):
+			request := requestContactSubscription{}
+			json.NewDecoder(r.Body).Decode(&request)
+			*writes = append(*writes, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+request.ConsentType))
+			data, _ := ioutil.ReadFile("fixtures/subscription_type.json")
+			w.Write(data)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestApplyConsentCSV(t *testing.T) {
+	writes := []string{}
+	server := newTestConsentServer(t, &writes)
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+
+	file, _ := os.Open("fixtures/consent.csv")
+	defer file.Close()
+	report, err := ic.Subscriptions.ApplyConsentCSV(context.Background(), &ic.Contacts, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedWrites := "POST /contacts/c2/subscriptions opt_out, POST /contacts/c1/subscriptions opt_in, POST /contacts/c2/subscriptions opt_in, DELETE /contacts/c3/subscriptions/37847"
+	if strings.Join(writes, ", ") != expectedWrites {
+		t.Errorf("Requests were %v", writes)
+	}
+	if len(report.Applied) != 4 {
+		t.Fatalf("Applied %v", report.Applied)
+	}
+	if row := report.Applied[0]; row.Line != 2 || strings.Join(row.ContactIDs, ",") != "c1,c2" || strings.Join(row.Changed, ",") != "c2" || row.OptedIn {
+		t.Errorf("First row was %+v", row)
+	}
+	if row := report.Applied[3]; row.Email != "taylor@example.io" || len(row.Changed) != 0 || !row.OptedIn {
+		t.Errorf("Row already consented was %+v", row)
+	}
+	expectedErrors := []string{"No Contact With Email nobody@example.io", `Unknown Subscription Type "Webinars"`, `Invalid Consent State "maybe"`, "Invalid Consent Row: 2 of 3 columns"}
+	if len(report.Failed) != len(expectedErrors) {
+		t.Fatalf("Failed %v", report.Failed)
+	}
+	for i, expected := range expectedErrors {
+		if row := report.Failed[i]; row.Err == nil || row.Err.Error() != expected || row.Line != 6+i {
+			t.Errorf("Failed row was %+v, expected %s", row, expected)
+		}
+	}
+}
+
+func TestApplyConsentCSVHeader(t *testing.T) {
+	ic := NewClient("appID", "apiKey")
+	_, err := ic.Subscriptions.ApplyConsentCSV(context.Background(), &ic.Contacts, strings.NewReader("email,state\njamie@example.io,opted_in\n"))
+	if err == nil || err.Error() != "consent CSV has no subscription column" {
+		t.Errorf("Expected a missing column error, got %v", err)
+	}
+}
+
+func TestApplyConsentCSVTruncatedSubscriptions(t *testing.T) {
+	writes := []string{}
+	server := newTestConsentServer(t, &writes)
+	defer server.Close()
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+
+	csv := "email,subscription,state\nalex@example.io,Newsletters,opted_out\nalex@example.io,Newsletters,opted_in\n"
+	report, err := ic.Subscriptions.ApplyConsentCSV(context.Background(), &ic.Contacts, strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Applied) != 2 || len(report.Applied[0].Changed) != 0 || len(report.Applied[1].Changed) != 1 {
+		t.Errorf("Report was %+v", report)
+	}
+	if strings.Join(writes, ", ") != "DELETE /contacts/c5/subscriptions/37846" {
+		t.Errorf("Requests were %v", writes)
+	}
+}
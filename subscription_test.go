@@ -0,0 +1,89 @@
+package intercom
+
+import "testing"
+
+func TestSubscriptionSetConsent(t *testing.T) {
+	repo := &TestSubscriptionAPI{t: t}
+	subscriptions := SubscriptionService{Repository: repo}
+	optOut := SubscriptionType{ID: "37846", ConsentType: "opt_out"}
+	optIn := SubscriptionType{ID: "37847", ConsentType: "opt_in"}
+	cases := []struct {
+		subscriptionType SubscriptionType
+		optedIn          bool
+		expected         string
+	}{
+		{optOut, false, "attach 37846 opt_out"},
+		{optOut, true, "detach 37846"},
+		{optIn, true, "attach 37847 opt_in"},
+		{optIn, false, "detach 37847"},
+	}
+	for _, c := range cases {
+		subscriptions.SetConsent("27", c.subscriptionType, c.optedIn)
+		if repo.last != c.expected {
+			t.Errorf("Consent %v to %v was %s, expected %s", c.optedIn, c.subscriptionType, repo.last, c.expected)
+		}
+	}
+	subscriptions.OptIn("27", optOut)
+	if repo.last != "detach 37846" {
+		t.Errorf("OptIn was %s", repo.last)
+	}
+
+	invalid := []struct {
+		contactID        string
+		subscriptionType SubscriptionType
+		expected         string
+	}{
+		{"", optIn, "Missing Contact ID"},
+		{"27", SubscriptionType{ConsentType: "opt_in"}, "Missing Subscription Type ID"},
+		{"27", SubscriptionType{ID: "37847"}, `Invalid Subscription Consent Type ""`},
+	}
+	for _, c := range invalid {
+		if _, err := subscriptions.OptOut(c.contactID, c.subscriptionType); err == nil || err.Error() != c.expected {
+			t.Errorf("Expected %s, got %v", c.expected, err)
+		}
+	}
+}
+
+func TestSearchContactOptedIn(t *testing.T) {
+	contact := SearchContact{
+		OptedOutSubscriptionTypes: SubscriptionTypeReferences{Data: []SubscriptionTypeReference{{Type: "subscription", ID: "37846"}}},
+		OptedInSubscriptionTypes:  SubscriptionTypeReferences{Data: []SubscriptionTypeReference{{Type: "subscription", ID: "37848"}}},
+	}
+	cases := []struct {
+		subscriptionType SubscriptionType
+		expected         bool
+	}{
+		{SubscriptionType{ID: "37846", ConsentType: "opt_out"}, false},
+		{SubscriptionType{ID: "37849", ConsentType: "opt_out"}, true},
+		{SubscriptionType{ID: "37847", ConsentType: "opt_in"}, false},
+		{SubscriptionType{ID: "37848", ConsentType: "opt_in"}, true},
+	}
+	for _, c := range cases {
+		if contact.OptedIn(c.subscriptionType) != c.expected {
+			t.Errorf("OptedIn %v was %v", c.subscriptionType, !c.expected)
+		}
+	}
+}
+
+type TestSubscriptionAPI struct {
+	t    *testing.T
+	last string
+}
+
+func (t *TestSubscriptionAPI) listTypes() (SubscriptionTypeList, error) {
+	return SubscriptionTypeList{}, nil
+}
+
+func (t *TestSubscriptionAPI) listContactSubscriptions(contactID string) (SubscriptionTypeList, error) {
+	return SubscriptionTypeList{}, nil
+}
+
+func (t *TestSubscriptionAPI) attach(contactID, subscriptionTypeID, consentType string) (SubscriptionType, error) {
+	t.last = "attach " + subscriptionTypeID + " " + consentType
+	return SubscriptionType{ID: subscriptionTypeID}, nil
+}
+
+func (t *TestSubscriptionAPI) detach(contactID, subscriptionTypeID string) (SubscriptionType, error) {
+	t.last = "detach " + subscriptionTypeID
+	return SubscriptionType{ID: subscriptionTypeID}, nil
+}
@@ -1,6 +1,9 @@
 package intercom
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // TagService handles interactions with the API through a TagRepository.
 type TagService struct {
@@ -8,9 +11,17 @@ type TagService struct {
 }
 
 // Tag represents an Tag in Intercom.
+// AppliedAt and AppliedBy are set on Tags applied to an object.
 type Tag struct {
-	ID   string `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	AppliedAt int64  `json:"applied_at,omitempty"`
+	AppliedBy *Admin `json:"applied_by,omitempty"`
+}
+
+type tagObjectRequest struct {
+	ID      string `json:"id,omitempty" url:"-"`
+	AdminID string `json:"admin_id,omitempty" url:"admin_id,omitempty"`
 }
 
 // TagList, an object holding a list of Tags
@@ -38,6 +49,62 @@ func (t *TagService) Tag(taggingList *TaggingList) (Tag, error) {
 	return t.Repository.tag(taggingList)
 }
 
+// TagContact applies a Tag to a Contact, using their Intercom IDs.
+func (t *TagService) TagContact(contactID, tagID string) (Tag, error) {
+	return t.tagObject("contacts", contactID, tagID, "", false)
+}
+
+// UntagContact removes a Tag from a Contact, using their Intercom IDs.
+func (t *TagService) UntagContact(contactID, tagID string) (Tag, error) {
+	return t.untagObject("contacts", contactID, tagID, "", false)
+}
+
+// TagConversation applies a Tag to a Conversation on behalf of an Admin.
+func (t *TagService) TagConversation(conversationID, tagID, adminID string) (Tag, error) {
+	return t.tagObject("conversations", conversationID, tagID, adminID, true)
+}
+
+// UntagConversation removes a Tag from a Conversation on behalf of an Admin.
+func (t *TagService) UntagConversation(conversationID, tagID, adminID string) (Tag, error) {
+	return t.untagObject("conversations", conversationID, tagID, adminID, true)
+}
+
+// TagTicket applies a Tag to a Ticket on behalf of an Admin.
+func (t *TagService) TagTicket(ticketID, tagID, adminID string) (Tag, error) {
+	return t.tagObject("tickets", ticketID, tagID, adminID, true)
+}
+
+// UntagTicket removes a Tag from a Ticket on behalf of an Admin.
+func (t *TagService) UntagTicket(ticketID, tagID, adminID string) (Tag, error) {
+	return t.untagObject("tickets", ticketID, tagID, adminID, true)
+}
+
+func (t *TagService) tagObject(objectType, objectID, tagID, adminID string, adminRequired bool) (Tag, error) {
+	if err := validateTagObject(objectID, tagID, adminID, adminRequired); err != nil {
+		return Tag{}, err
+	}
+	return t.Repository.tagObject(objectType, objectID, tagObjectRequest{ID: tagID, AdminID: adminID})
+}
+
+func (t *TagService) untagObject(objectType, objectID, tagID, adminID string, adminRequired bool) (Tag, error) {
+	if err := validateTagObject(objectID, tagID, adminID, adminRequired); err != nil {
+		return Tag{}, err
+	}
+	return t.Repository.untagObject(objectType, objectID, tagID, tagObjectRequest{AdminID: adminID})
+}
+
+func validateTagObject(objectID, tagID, adminID string, adminRequired bool) error {
+	switch {
+	case objectID == "":
+		return errors.New("Missing Object Identifier")
+	case tagID == "":
+		return errors.New("Missing Tag Identifier")
+	case adminRequired && adminID == "":
+		return errors.New("Missing Admin Identifier")
+	}
+	return nil
+}
+
 func (t Tag) String() string {
 	return fmt.Sprintf("[intercom] tag { id: %s name: %s }", t.ID, t.Name)
 }
@@ -13,6 +13,8 @@ type TagRepository interface {
 	save(tag *Tag) (Tag, error)
 	delete(id string) error
 	tag(tagList *TaggingList) (Tag, error)
+	tagObject(objectType, objectID string, request tagObjectRequest) (Tag, error)
+	untagObject(objectType, objectID, tagID string, request tagObjectRequest) (Tag, error)
 }
 
 // TagAPI implements TagRepository
@@ -54,3 +56,20 @@ func (api TagAPI) tag(taggingList *TaggingList) (Tag, error) {
 	err = json.Unmarshal(data, &savedTag)
 	return savedTag, err
 }
+
+func (api TagAPI) tagObject(objectType, objectID string, request tagObjectRequest) (Tag, error) {
+	return unmarshalToTag(api.httpClient.Post(fmt.Sprintf("/%s/%s/tags", objectType, objectID), &request))
+}
+
+func (api TagAPI) untagObject(objectType, objectID, tagID string, request tagObjectRequest) (Tag, error) {
+	return unmarshalToTag(api.httpClient.DeleteWithBody(fmt.Sprintf("/%s/%s/tags/%s", objectType, objectID, tagID), &request))
+}
+
+func unmarshalToTag(data []byte, err error) (Tag, error) {
+	tag := Tag{}
+	if err != nil {
+		return tag, err
+	}
+	err = json.Unmarshal(data, &tag)
+	return tag, err
+}
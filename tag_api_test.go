@@ -8,6 +8,7 @@ import (
 type TestTagHTTPClient struct {
 	TestHTTPClient
 	t               *testing.T
+	f               func(body interface{})
 	fixtureFilename string
 	expectedURI     string
 }
@@ -47,6 +48,37 @@ func TestAPITagTagging(t *testing.T) {
 	}
 }
 
+func TestAPITagContact(t *testing.T) {
+	http := TestTagHTTPClient{t: t, fixtureFilename: "fixtures/tag_applied.json", expectedURI: "/contacts/5ba682d2/tags"}
+	http.f = func(body interface{}) {
+		if request := body.(*tagObjectRequest); request.ID != "60218" || request.AdminID != "" {
+			t.Errorf("Tag request was %v", request)
+		}
+	}
+	api := TagAPI{httpClient: &http}
+	tag, err := api.tagObject("contacts", "5ba682d2", tagObjectRequest{ID: "60218"})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if tag.AppliedAt != 1663597223 || tag.AppliedBy == nil || tag.AppliedBy.ID.String() != "25" {
+		t.Errorf("Applied tag was %v", tag)
+	}
+}
+
+func TestAPIUntagConversation(t *testing.T) {
+	http := TestTagHTTPClient{t: t, fixtureFilename: "fixtures/tag_applied.json", expectedURI: "/conversations/147/tags/60218"}
+	http.f = func(params interface{}) {
+		if request := params.(*tagObjectRequest); request.AdminID != "25" {
+			t.Errorf("Untag request was %v", request)
+		}
+	}
+	api := TagAPI{httpClient: &http}
+	tag, err := api.untagObject("conversations", "147", "60218", tagObjectRequest{AdminID: "25"})
+	if err != nil || tag.ID != "60218" {
+		t.Errorf("Untagged %s, error %v", tag.ID, err)
+	}
+}
+
 func (t TestTagHTTPClient) Get(uri string, params interface{}) ([]byte, error) {
 	if uri != t.expectedURI {
 		t.t.Errorf("Wrong endpoint called")
@@ -58,12 +90,25 @@ func (t TestTagHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
 	if uri != t.expectedURI {
 		t.t.Errorf("Wrong endpoint called")
 	}
+	if t.f != nil {
+		t.f(body)
+	}
 	return ioutil.ReadFile(t.fixtureFilename)
 }
 
-func (t TestTagHTTPClient) Delete(uri string, body interface{}) ([]byte, error) {
+func (t TestTagHTTPClient) Delete(uri string, params interface{}) ([]byte, error) {
 	if uri != t.expectedURI {
 		t.t.Errorf("Wrong endpoint called")
 	}
-	return nil, nil
+	if t.f != nil {
+		t.f(params)
+	}
+	if t.fixtureFilename == "" {
+		return nil, nil
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
+}
+
+func (t TestTagHTTPClient) DeleteWithBody(uri string, body interface{}) ([]byte, error) {
+	return t.Delete(uri, body)
 }
@@ -0,0 +1,88 @@
+package intercom
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BulkTagConfig configures TagContacts; its zero value tags 4 Contacts at a time, retrying each up to 3 times.
+type BulkTagConfig struct {
+	Concurrency       int           // Defaults to 4.
+	MaxRetries        int           // Defaults to 3; negative to never retry.
+	RetryBackoff      time.Duration // First retry delay, then doubled. Defaults to 1s.
+	RequestsPerSecond float64       // Unlimited when 0.
+	sleep             func(context.Context, time.Duration) error
+}
+
+// A BulkTagReport lists the Contacts TagContacts tagged, and those it could not.
+type BulkTagReport struct {
+	Tagged  []string         // Contact IDs tagged, in the order given.
+	Failed  map[string]error // Contact IDs which could not be tagged, and why.
+	Retried int              // Retried requests.
+}
+
+// TagContacts applies a Tag to many Contacts concurrently.
+// Rate limited (429) requests pause every worker before being retried, as do server and network errors.
+// Contacts which could not be tagged are listed in the BulkTagReport; an error is only returned if ctx is done first.
+func (t *TagService) TagContacts(ctx context.Context, tagID string, contactIDs []string, config BulkTagConfig) (BulkTagReport, error) {
+	config = config.withDefaults()
+	retrier := newRetrier(ctx, config.MaxRetries, config.RetryBackoff, config.RequestsPerSecond, config.sleep)
+	defer retrier.stop()
+
+	errs := make([]error, len(contactIDs))
+	indexes := make(chan int)
+	var workers sync.WaitGroup
+	for i := 0; i < config.Concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for index := range indexes {
+				errs[index] = retrier.do(func() error {
+					_, err := t.TagContact(contactIDs[index], tagID)
+					return err
+				})
+			}
+		}()
+	}
+	// Contacts not handed to a worker before ctx is done fail with its error.
+feed:
+	for i := range contactIDs {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for ; i < len(contactIDs); i++ {
+				errs[i] = ctx.Err()
+			}
+			break feed
+		}
+	}
+	close(indexes)
+	workers.Wait()
+
+	report := BulkTagReport{Failed: map[string]error{}, Retried: int(retrier.retried.Load())}
+	for i, contactID := range contactIDs {
+		if errs[i] != nil {
+			report.Failed[contactID] = errs[i]
+		} else {
+			report.Tagged = append(report.Tagged, contactID)
+		}
+	}
+	return report, ctx.Err()
+}
+
+func (c BulkTagConfig) withDefaults() BulkTagConfig {
+	if c.Concurrency <= 0 {
+		c.Concurrency = 4
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = 3
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = time.Second
+	}
+	if c.sleep == nil {
+		c.sleep = sleepContext
+	}
+	return c
+}
@@ -0,0 +1,91 @@
+package intercom
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
+
+func TestTagContacts(t *testing.T) {
+	repo := &TestBulkTagAPI{TestTagAPI: TestTagAPI{t: t}, failures: map[string][]error{
+		"c2": {interfaces.HTTPError{StatusCode: 429, Code: "rate_limit_exceeded"}},
+		"c3": {interfaces.HTTPError{StatusCode: 404, Code: "not_found"}},
+	}}
+	tagService := TagService{Repository: repo}
+	sleeps := 0
+	var mu sync.Mutex
+	config := BulkTagConfig{Concurrency: 2, sleep: func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		sleeps++
+		mu.Unlock()
+		return nil
+	}}
+	report, err := tagService.TagContacts(context.Background(), "24", []string{"c1", "c2", "c3", "c4"}, config)
+	if err != nil {
+		t.Fatalf("Bulk tagging failed %s", err)
+	}
+	if len(report.Tagged) != 3 || report.Tagged[0] != "c1" || report.Tagged[1] != "c2" || report.Tagged[2] != "c4" {
+		t.Errorf("Tagged %v, expected c1, c2, c4", report.Tagged)
+	}
+	if len(report.Failed) != 1 || report.Failed["c3"] == nil {
+		t.Errorf("Failed %v, expected c3", report.Failed)
+	}
+	if report.Retried != 1 || sleeps == 0 {
+		t.Errorf("Retried %d with %d sleeps, expected the rate limited request to be retried", report.Retried, sleeps)
+	}
+}
+
+func TestTagContactsCancelled(t *testing.T) {
+	repo := &TestBulkTagAPI{TestTagAPI: TestTagAPI{t: t}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := (&TagService{Repository: repo}).TagContacts(ctx, "24", []string{"c1", "c2"}, BulkTagConfig{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(report.Tagged) != 0 || len(report.Failed) != 2 {
+		t.Errorf("Report was %v", report)
+	}
+}
+
+func TestTagContactsCancelledWhileTagging(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := &TestBulkTagAPI{TestTagAPI: TestTagAPI{t: t}, tagged: cancel}
+	contactIDs := []string{"c1", "c2", "c3", "c4", "c5"}
+	report, err := (&TagService{Repository: repo}).TagContacts(ctx, "24", contactIDs, BulkTagConfig{Concurrency: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if repo.calls != 1 || len(report.Tagged) != 1 || len(report.Failed) != 4 || !errors.Is(report.Failed["c5"], context.Canceled) {
+		t.Errorf("Tagging %d times, report was %v", repo.calls, report)
+	}
+}
+
+type TestBulkTagAPI struct {
+	TestTagAPI
+	mu       sync.Mutex
+	failures map[string][]error
+	tagged   func()
+	calls    int
+}
+
+func (t *TestBulkTagAPI) tagObject(objectType, objectID string, request tagObjectRequest) (Tag, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.calls++
+	if t.tagged != nil {
+		t.tagged()
+	}
+	if failures := t.failures[objectID]; len(failures) > 0 {
+		if failures[0].(interfaces.HTTPError).StatusCode == 429 {
+			t.failures[objectID] = failures[1:]
+		}
+		return Tag{}, failures[0]
+	}
+	return Tag{ID: request.ID}, nil
+}
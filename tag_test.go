@@ -1,6 +1,9 @@
 package intercom
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 
 func TestListTags(t *testing.T) {
 	tagList, _ := (&TagService{Repository: TestTagAPI{t: t}}).List()
@@ -27,6 +30,35 @@ func TestTaggingUsers(t *testing.T) {
 	tagService.Tag(&taggingList)
 }
 
+func TestTagObjects(t *testing.T) {
+	tagService := TagService{Repository: TestTagAPI{t: t}}
+	if tag, _ := tagService.TagContact("c1", "24"); tag.Name != "contacts/c1" {
+		t.Errorf("Contact not tagged, got %s", tag.Name)
+	}
+	if tag, _ := tagService.UntagContact("c1", "24"); tag.Name != "untag contacts/c1" {
+		t.Errorf("Contact not untagged, got %s", tag.Name)
+	}
+	if tag, _ := tagService.TagConversation("147", "24", "25"); tag.Name != "conversations/147" || tag.AppliedBy.ID != "25" {
+		t.Errorf("Conversation not tagged, got %s", tag.Name)
+	}
+	if tag, _ := tagService.UntagTicket("9", "24", "25"); tag.Name != "untag tickets/9" {
+		t.Errorf("Ticket not untagged, got %s", tag.Name)
+	}
+}
+
+func TestTagObjectsValidation(t *testing.T) {
+	tagService := TagService{Repository: TestTagAPI{t: t}}
+	if _, err := tagService.TagConversation("147", "24", ""); err == nil || err.Error() != "Missing Admin Identifier" {
+		t.Errorf("Expected a missing admin error, got %v", err)
+	}
+	if _, err := tagService.TagTicket("9", "", "25"); err == nil {
+		t.Errorf("Expected a missing tag error")
+	}
+	if _, err := tagService.UntagContact("", "24"); err == nil {
+		t.Errorf("Expected a missing contact error")
+	}
+}
+
 type TestTagAPI struct {
 	t *testing.T
 }
@@ -58,3 +90,14 @@ func (t TestTagAPI) tag(taggingList *TaggingList) (Tag, error) {
 	}
 	return Tag{}, nil
 }
+
+func (t TestTagAPI) tagObject(objectType, objectID string, request tagObjectRequest) (Tag, error) {
+	if request.ID != "24" {
+		t.t.Errorf("Tag request expected to have ID 24 but had %s", request.ID)
+	}
+	return Tag{ID: request.ID, Name: objectType + "/" + objectID, AppliedBy: &Admin{ID: json.Number(request.AdminID)}}, nil
+}
+
+func (t TestTagAPI) untagObject(objectType, objectID, tagID string, request tagObjectRequest) (Tag, error) {
+	return Tag{ID: tagID, Name: "untag " + objectType + "/" + objectID}, nil
+}
@@ -0,0 +1,403 @@
+package intercom
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stefanoschrs/go-intercom/internal/safeurl"
+)
+
+// TranscriptFormat determines how a Transcript is rendered
+type TranscriptFormat int
+
+const (
+	TRANSCRIPT_MARKDOWN TranscriptFormat = iota
+	TRANSCRIPT_HTML
+	TRANSCRIPT_TEXT
+)
+
+var transcriptFormats = [...]string{
+	"markdown",
+	"html",
+	"text",
+}
+
+func (format TranscriptFormat) String() string {
+	return transcriptFormats[format]
+}
+
+// TranscriptOptions configure which Conversation Parts a Transcript includes, and how it is rendered.
+// Comments are always included.
+type TranscriptOptions struct {
+	Format              TranscriptFormat
+	IncludeNotes        bool
+	IncludeAssignments  bool
+	IncludeStateChanges bool // Opening, closing, snoozing and other changes of state.
+
+	// ResolveAuthor names authors and assignees the Conversation does not name.
+	// See AdminAuthorResolver. Unresolved authors are named by their type and ID.
+	ResolveAuthor func(authorType, id string) string
+	// Location of the times shown, UTC by default.
+	Location *time.Location
+	// TimeFormat of the times shown, "2006-01-02 15:04 MST" by default.
+	TimeFormat string
+}
+
+// A Transcript is the history of a Conversation, from its source message through its Parts.
+type Transcript struct {
+	ConversationID string
+	Subject        string
+	Entries        []TranscriptEntry
+
+	options TranscriptOptions
+}
+
+// TranscriptEntry kinds
+const (
+	TRANSCRIPT_COMMENT    = "comment"
+	TRANSCRIPT_NOTE       = "note"
+	TRANSCRIPT_ASSIGNMENT = "assignment"
+	TRANSCRIPT_STATE      = "state"
+)
+
+// A TranscriptEntry is the source message or a Part of a Conversation.
+type TranscriptEntry struct {
+	ID          string
+	Kind        string // TRANSCRIPT_COMMENT, TRANSCRIPT_NOTE, TRANSCRIPT_ASSIGNMENT or TRANSCRIPT_STATE
+	PartType    string // part_type from the API, or "source"
+	Author      TranscriptAuthor
+	AssignedTo  *TranscriptAuthor
+	CreatedAt   time.Time
+	Body        string // HTML fragment, as returned by the API
+	Redacted    bool
+	Attachments []TranscriptAttachment
+}
+
+// A TranscriptAuthor is an admin, user, lead, bot or team.
+type TranscriptAuthor struct {
+	Type string
+	ID   string
+	Name string
+}
+
+// A TranscriptAttachment is a file attached to a message.
+type TranscriptAttachment struct {
+	Name string
+	URL  string
+}
+
+// NewTranscript builds the Transcript of a Conversation.
+// Authors missing a name are resolved from elsewhere in the Conversation, then with ResolveAuthor.
+func NewTranscript(conversation Conversation, options TranscriptOptions) Transcript {
+	if options.Location == nil {
+		options.Location = time.UTC
+	}
+	if options.TimeFormat == "" {
+		options.TimeFormat = "2006-01-02 15:04 MST"
+	}
+	transcript := Transcript{ConversationID: conversation.Id, Subject: conversation.Title, options: options}
+	if transcript.Subject == "" {
+		transcript.Subject = htmlToText(conversation.Source.Subject, false)
+	}
+
+	names := map[string]string{}
+	source := conversation.Source
+	names[source.Author.Type+"/"+source.Author.Id] = source.Author.Name
+	for _, part := range conversation.ConversationParts.ConversationParts {
+		if part.Author.Name != "" {
+			names[part.Author.Type+"/"+part.Author.Id] = part.Author.Name
+		}
+	}
+	author := func(authorType, id, name string) TranscriptAuthor {
+		if name == "" {
+			name = names[authorType+"/"+id]
+		}
+		if name == "" && options.ResolveAuthor != nil {
+			name = options.ResolveAuthor(authorType, id)
+		}
+		return TranscriptAuthor{Type: authorType, ID: id, Name: name}
+	}
+
+	if source.Body != "" || len(source.Attachments) > 0 {
+		transcript.Entries = append(transcript.Entries, TranscriptEntry{
+			ID:          source.Id,
+			Kind:        TRANSCRIPT_COMMENT,
+			PartType:    "source",
+			Author:      author(source.Author.Type, source.Author.Id, source.Author.Name),
+			CreatedAt:   time.Unix(int64(conversation.CreatedAt), 0),
+			Body:        source.Body,
+			Redacted:    source.Redacted,
+			Attachments: transcriptAttachments(source.Attachments),
+		})
+	}
+	for _, part := range conversation.ConversationParts.ConversationParts {
+		entry := TranscriptEntry{
+			ID:          part.Id,
+			Kind:        transcriptKind(part.PartType),
+			PartType:    part.PartType,
+			Author:      author(part.Author.Type, part.Author.Id, part.Author.Name),
+			CreatedAt:   time.Unix(int64(part.CreatedAt), 0),
+			Redacted:    part.Redacted,
+			Attachments: transcriptAttachments(part.Attachments),
+		}
+		if part.Body != nil {
+			entry.Body = *part.Body
+		}
+		if part.AssignedTo != nil {
+			assignee := author(part.AssignedTo.Type, part.AssignedTo.Id, "")
+			entry.AssignedTo = &assignee
+		}
+		if !options.includes(entry.Kind) {
+			continue
+		}
+		transcript.Entries = append(transcript.Entries, entry)
+	}
+	return transcript
+}
+
+// WriteTranscript renders the Transcript of a Conversation to w.
+func WriteTranscript(w io.Writer, conversation Conversation, options TranscriptOptions) error {
+	return NewTranscript(conversation, options).Render(w)
+}
+
+// Render writes the Transcript to w, in the format of its TranscriptOptions.
+func (t Transcript) Render(w io.Writer) error {
+	var out string
+	switch t.options.Format {
+	case TRANSCRIPT_MARKDOWN:
+		out = t.markdown()
+	case TRANSCRIPT_HTML:
+		out = t.html()
+	case TRANSCRIPT_TEXT:
+		out = t.text()
+	default:
+		return fmt.Errorf("Unknown Transcript Format %d", t.options.Format)
+	}
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// String renders the Transcript.
+func (t Transcript) String() string {
+	b := &strings.Builder{}
+	t.Render(b)
+	return b.String()
+}
+
+// Describe summarises an assignment or state change entry, e.g. "Jane closed the conversation".
+func (e TranscriptEntry) Describe() string {
+	switch {
+	case e.Kind == TRANSCRIPT_ASSIGNMENT && e.AssignedTo == nil:
+		return fmt.Sprintf("%s unassigned the conversation", e.Author)
+	case e.Kind == TRANSCRIPT_ASSIGNMENT:
+		return fmt.Sprintf("%s assigned the conversation to %s", e.Author, *e.AssignedTo)
+	}
+	switch e.PartType {
+	case "open":
+		return fmt.Sprintf("%s reopened the conversation", e.Author)
+	case "close":
+		return fmt.Sprintf("%s closed the conversation", e.Author)
+	case "snoozed":
+		return fmt.Sprintf("%s snoozed the conversation", e.Author)
+	case "unsnoozed", "timer_unsnooze":
+		return fmt.Sprintf("%s unsnoozed the conversation", e.Author)
+	case "priority":
+		return fmt.Sprintf("%s marked the conversation as priority", e.Author)
+	case "not_priority":
+		return fmt.Sprintf("%s marked the conversation as not priority", e.Author)
+	}
+	return fmt.Sprintf("%s: %s", e.Author, strings.ReplaceAll(e.PartType, "_", " "))
+}
+
+// String names the author, falling back to its type and ID.
+func (a TranscriptAuthor) String() string {
+	switch {
+	case a.Name != "":
+		return a.Name
+	case a.Type == "" && a.ID == "":
+		return "Unknown"
+	case a.ID == "":
+		return strings.ToUpper(a.Type[:1]) + a.Type[1:]
+	case a.Type == "":
+		return a.ID
+	}
+	return strings.ToUpper(a.Type[:1]) + a.Type[1:] + " " + a.ID
+}
+
+// AdminAuthorResolver resolves admin authors and assignees by the name in an AdminList.
+func AdminAuthorResolver(admins AdminList) func(authorType, id string) string {
+	names := map[string]string{}
+	for _, admin := range admins.Admins {
+		names[admin.ID.String()] = admin.Name
+	}
+	return func(authorType, id string) string {
+		if authorType != "admin" {
+			return ""
+		}
+		return names[id]
+	}
+}
+
+func (o TranscriptOptions) includes(kind string) bool {
+	switch kind {
+	case TRANSCRIPT_NOTE:
+		return o.IncludeNotes
+	case TRANSCRIPT_ASSIGNMENT:
+		return o.IncludeAssignments
+	case TRANSCRIPT_STATE:
+		return o.IncludeStateChanges
+	}
+	return true
+}
+
+func transcriptKind(partType string) string {
+	switch {
+	case partType == "comment" || partType == "quick_reply":
+		return TRANSCRIPT_COMMENT
+	case partType == "note" || partType == "note_and_reopen":
+		return TRANSCRIPT_NOTE
+	case strings.Contains(partType, "assign"):
+		return TRANSCRIPT_ASSIGNMENT
+	}
+	return TRANSCRIPT_STATE
+}
+
+func transcriptAttachments(attachments []interface{}) []TranscriptAttachment {
+	result := []TranscriptAttachment{}
+	for _, attachment := range attachments {
+		fields, ok := attachment.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := fields["name"].(string)
+		url, _ := fields["url"].(string)
+		if url != "" {
+			result = append(result, TranscriptAttachment{Name: name, URL: url})
+		}
+	}
+	return result
+}
+
+func (t Transcript) time(e TranscriptEntry) string {
+	return e.CreatedAt.In(t.options.Location).Format(t.options.TimeFormat)
+}
+
+func (t Transcript) markdown() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# Conversation %s\n", t.ConversationID)
+	if t.Subject != "" {
+		fmt.Fprintf(b, "\n## %s\n", escapeMarkdown(t.Subject))
+	}
+	for _, e := range t.Entries {
+		b.WriteString("\n")
+		if e.Kind == TRANSCRIPT_ASSIGNMENT || e.Kind == TRANSCRIPT_STATE {
+			fmt.Fprintf(b, "_%s · %s_\n", escapeMarkdown(e.Describe()), t.time(e))
+			if e.Body == "" {
+				continue
+			}
+			b.WriteString("\n")
+		} else {
+			fmt.Fprintf(b, "**%s** (%s) · %s\n\n", escapeMarkdown(e.Author.String()), escapeMarkdown(t.label(e)), t.time(e))
+		}
+		body := htmlToText(e.Body, true)
+		if e.Redacted {
+			body = "_This message was redacted._"
+		}
+		if e.Kind == TRANSCRIPT_NOTE {
+			body = "> " + strings.ReplaceAll(body, "\n", "\n> ")
+		}
+		if body != "" {
+			b.WriteString(body + "\n")
+		}
+		for _, a := range e.Attachments {
+			if safeurl.Allowed(a.URL, false) {
+				fmt.Fprintf(b, "\n- Attachment: [%s](%s)\n", escapeMarkdown(a.Name), escapeMarkdownURL(a.URL))
+			} else {
+				fmt.Fprintf(b, "\n- Attachment: %s\n", escapeMarkdown(a.Name))
+			}
+		}
+	}
+	return b.String()
+}
+
+func (t Transcript) text() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "Conversation %s\n", t.ConversationID)
+	if t.Subject != "" {
+		fmt.Fprintf(b, "%s\n", t.Subject)
+	}
+	for _, e := range t.Entries {
+		b.WriteString("\n")
+		if e.Kind == TRANSCRIPT_ASSIGNMENT || e.Kind == TRANSCRIPT_STATE {
+			fmt.Fprintf(b, "[%s] %s\n", t.time(e), e.Describe())
+			if e.Body == "" {
+				continue
+			}
+		} else {
+			fmt.Fprintf(b, "[%s] %s (%s):\n", t.time(e), e.Author, t.label(e))
+		}
+		body := htmlToText(e.Body, false)
+		if e.Redacted {
+			body = "This message was redacted."
+		}
+		if body != "" {
+			b.WriteString(body + "\n")
+		}
+		for _, a := range e.Attachments {
+			fmt.Fprintf(b, "Attachment: %s (%s)\n", a.Name, a.URL)
+		}
+	}
+	return b.String()
+}
+
+func (t Transcript) html() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "<article class=\"intercom-transcript\" data-conversation-id=\"%s\">\n", html.EscapeString(t.ConversationID))
+	fmt.Fprintf(b, "<h1>Conversation %s</h1>\n", html.EscapeString(t.ConversationID))
+	if t.Subject != "" {
+		fmt.Fprintf(b, "<h2>%s</h2>\n", html.EscapeString(t.Subject))
+	}
+	for _, e := range t.Entries {
+		timestamp := fmt.Sprintf("<time datetime=\"%s\">%s</time>", e.CreatedAt.UTC().Format(time.RFC3339), html.EscapeString(t.time(e)))
+		fmt.Fprintf(b, "<section class=\"%s\" data-part-id=\"%s\">\n", e.Kind, html.EscapeString(e.ID))
+		if e.Kind == TRANSCRIPT_ASSIGNMENT || e.Kind == TRANSCRIPT_STATE {
+			fmt.Fprintf(b, "<header><em>%s</em> %s</header>\n", html.EscapeString(e.Describe()), timestamp)
+		} else {
+			fmt.Fprintf(b, "<header><strong>%s</strong> (%s) %s</header>\n", html.EscapeString(e.Author.String()), html.EscapeString(t.label(e)), timestamp)
+		}
+		switch {
+		case e.Redacted:
+			b.WriteString("<div class=\"body\"><em>This message was redacted.</em></div>\n")
+		case e.Body != "":
+			fmt.Fprintf(b, "<div class=\"body\">%s</div>\n", sanitizeHTML(e.Body))
+		}
+		if len(e.Attachments) > 0 {
+			b.WriteString("<ul class=\"attachments\">\n")
+			for _, a := range e.Attachments {
+				if safeurl.Allowed(a.URL, false) {
+					fmt.Fprintf(b, "<li><a href=\"%s\" rel=\"nofollow noopener\">%s</a></li>\n", html.EscapeString(a.URL), html.EscapeString(a.Name))
+				} else {
+					fmt.Fprintf(b, "<li>%s</li>\n", html.EscapeString(a.Name))
+				}
+			}
+			b.WriteString("</ul>\n")
+		}
+		b.WriteString("</section>\n")
+	}
+	b.WriteString("</article>\n")
+	return b.String()
+}
+
+func (t Transcript) label(e TranscriptEntry) string {
+	label := e.Author.Type
+	if label == "" {
+		label = "unknown"
+	}
+	if e.Kind == TRANSCRIPT_NOTE {
+		label += ", note"
+	}
+	return label
+}
@@ -0,0 +1,157 @@
+package intercom
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestTranscriptFormats(t *testing.T) {
+	conversation := testTranscriptConversation(t)
+	options := TranscriptOptions{IncludeNotes: true, IncludeAssignments: true, IncludeStateChanges: true}
+	for format, golden := range map[TranscriptFormat]string{
+		TRANSCRIPT_MARKDOWN: "fixtures/conversation_transcript.md",
+		TRANSCRIPT_TEXT:     "fixtures/conversation_transcript.txt",
+		TRANSCRIPT_HTML:     "fixtures/conversation_transcript.html",
+	} {
+		options.Format = format
+		out := &bytes.Buffer{}
+		if err := WriteTranscript(out, conversation, options); err != nil {
+			t.Fatalf("Error rendering %s %s", format, err)
+		}
+		expected, _ := ioutil.ReadFile(golden)
+		if out.String() != string(expected) {
+			t.Errorf("%s transcript did not match %s, was:\n%s", format, golden, out)
+		}
+	}
+}
+
+func TestTranscriptDefaultOptions(t *testing.T) {
+	transcript := NewTranscript(testTranscriptConversation(t), TranscriptOptions{})
+	kinds := []string{}
+	for _, entry := range transcript.Entries {
+		kinds = append(kinds, entry.Kind)
+	}
+	if strings.Join(kinds, ",") != "comment,comment,comment" {
+		t.Errorf("Entries were %v, expected only comments", kinds)
+	}
+	if text := transcript.String(); strings.Contains(text, "Pro plan") || strings.Contains(text, "closed") {
+		t.Errorf("Notes and state changes should be excluded:\n%s", text)
+	}
+}
+
+func TestTranscriptResolvesAuthors(t *testing.T) {
+	conversation := testTranscriptConversation(t)
+	parts := conversation.ConversationParts.ConversationParts
+	for i := range parts {
+		if parts[i].Author.Type == "admin" {
+			parts[i].Author.Name = ""
+		}
+	}
+	conversation.Source.Author.Name = ""
+	resolver := AdminAuthorResolver(AdminList{Admins: []Admin{{ID: "25", Name: "Jane Doe"}}})
+	transcript := NewTranscript(conversation, TranscriptOptions{IncludeAssignments: true, ResolveAuthor: resolver})
+	if author := transcript.Entries[0].Author.String(); author != "Jane Doe" {
+		t.Errorf("Source author was %s, expected Jane Doe", author)
+	}
+	if describe := transcript.Entries[2].Describe(); describe != "Operator assigned the conversation to Jane Doe" {
+		t.Errorf("Assignment was %q", describe)
+	}
+
+	unresolved := NewTranscript(conversation, TranscriptOptions{})
+	if author := unresolved.Entries[0].Author.String(); author != "Admin 25" {
+		t.Errorf("Unresolved author was %s, expected Admin 25", author)
+	}
+}
+
+func TestTranscriptRedacted(t *testing.T) {
+	conversation := testTranscriptConversation(t)
+	conversation.ConversationParts.ConversationParts[0].Redacted = true
+	text := NewTranscript(conversation, TranscriptOptions{Format: TRANSCRIPT_TEXT}).String()
+	if strings.Contains(text, "all great") || !strings.Contains(text, "This message was redacted.") {
+		t.Errorf("Redacted part was rendered:\n%s", text)
+	}
+}
+
+func TestTranscriptEscapesLabels(t *testing.T) {
+	conversation := testTranscriptConversation(t)
+	conversation.ConversationParts.ConversationParts[0].Author.Type = "<b>bot</b>"
+	out := NewTranscript(conversation, TranscriptOptions{Format: TRANSCRIPT_HTML}).String()
+	if strings.Contains(out, "<b>bot</b>") || !strings.Contains(out, "(&lt;b&gt;bot&lt;/b&gt;)") {
+		t.Errorf("Label was not escaped:\n%s", out)
+	}
+}
+
+func TestTranscriptMarkdownAttachments(t *testing.T) {
+	conversation := testTranscriptConversation(t)
+	conversation.ConversationParts.ConversationParts[0].Attachments = []interface{}{
+		map[string]interface{}{"name": "evil.txt", "url": "javascript:alert(1)"},
+		map[string]interface{}{"name": "report (final).pdf", "url": "https://example.io/report (final).pdf"},
+	}
+	out := NewTranscript(conversation, TranscriptOptions{Format: TRANSCRIPT_MARKDOWN}).String()
+	if strings.Contains(out, "javascript:") || !strings.Contains(out, "- Attachment: evil.txt\n") {
+		t.Errorf("Unsafe attachment was linked:\n%s", out)
+	}
+	if !strings.Contains(out, "- Attachment: [report (final).pdf](https://example.io/report%20%28final%29.pdf)\n") {
+		t.Errorf("Attachment URL was not escaped:\n%s", out)
+	}
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	cases := map[string]string{
+		`<p onclick="x()">Hi <script>alert(1)</script><b>there</p>`:                  `<p>Hi <b>there</b></p>`,
+		`<a href="javascript:alert(1)">link</a>`:                                     `<a>link</a>`,
+		`<a href='https://example.com/?a=1&amp;b=2'>link</a>`:                        `<a href="https://example.com/?a=1&amp;b=2" rel="nofollow noopener">link</a>`,
+		`<img src="data:image/png;base64,xx"><img src="https://x.io/a.png" alt="A">`: `<img src="https://x.io/a.png" alt="A">`,
+		`<iframe src="https://x.io"></iframe>1 &lt; 2<!-- comment -->`:               `1 &lt; 2`,
+		`<p title="a>b" onclick=1>hi</p>`:                                            `<p>hi</p>`,
+		`<p title='it"s' class=x>don't</p>`:                                          `<p>don&#39;t</p>`,
+	}
+	for in, expected := range cases {
+		if out := sanitizeHTML(in); out != expected {
+			t.Errorf("sanitizeHTML(%q) was %q, expected %q", in, out, expected)
+		}
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	in := `<p>Steps:</p><ol><li>Open <a href="https://example.com">the app</a></li><li>Click <em>Save</em></li></ol><p>1. not a list</p><pre>x := 1
+y := 2</pre>`
+	markdown := "Steps:\n\n1. Open [the app](https://example.com)\n2. Click _Save_\n\n1\\. not a list\n\n```\nx := 1\ny := 2\n```"
+	if out := htmlToText(in, true); out != markdown {
+		t.Errorf("Markdown was %q, expected %q", out, markdown)
+	}
+	text := "Steps:\n\n1. Open the app (https://example.com)\n2. Click Save\n\n1. not a list\n\nx := 1\ny := 2"
+	if out := htmlToText(in, false); out != text {
+		t.Errorf("Text was %q, expected %q", out, text)
+	}
+}
+
+func TestHTMLToTextUnsafeURLs(t *testing.T) {
+	in := `<p><a href="javascript:alert(1)">click</a> <a href="https://example.com/a_(b) c">wiki</a></p><p><img src="javascript:alert(1)" alt="Logo"><img src="https://x.io/a.png" alt="A"></p>`
+	markdown := "click [wiki](https://example.com/a_%28b%29%20c)\n\nLogo![A](https://x.io/a.png)"
+	if out := htmlToText(in, true); out != markdown {
+		t.Errorf("Markdown was %q, expected %q", out, markdown)
+	}
+	text := "click wiki (https://example.com/a_(b) c)\n\n[Logo][A]"
+	if out := htmlToText(in, false); out != text {
+		t.Errorf("Text was %q, expected %q", out, text)
+	}
+	if out := htmlToText(`<p title="a>b" onclick=1>hi</p>`, false); out != "hi" {
+		t.Errorf("Text was %q, expected %q", out, "hi")
+	}
+}
+
+func testTranscriptConversation(t *testing.T) Conversation {
+	data, err := ioutil.ReadFile("fixtures/conversation.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conversation := Conversation{}
+	if err := json.Unmarshal(data, &conversation); err != nil {
+		t.Fatal(err)
+	}
+	return conversation
+}
@@ -1,6 +1,10 @@
 package intercom
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
 
 // UserService handles interactions with the API through a UserRepository.
 type UserService struct {
@@ -18,32 +22,32 @@ type UserList struct {
 // Not all of the fields are writeable to the API, non-writeable fields are
 // stripped out from the request. Please see the API documentation for details.
 type User struct {
-	ID                     string                 `json:"id,omitempty"`
-	Email                  string                 `json:"email,omitempty"`
-	Phone                  string                 `json:"phone,omitempty"`
-	UserID                 string                 `json:"user_id,omitempty"`
-	Anonymous              *bool                  `json:"anonymous,omitempty"`
-	Name                   string                 `json:"name,omitempty"`
-	Pseudonym              string                 `json:"pseudonym,omitempty"`
-	Avatar                 *UserAvatar            `json:"avatar,omitempty"`
-	LocationData           *LocationData          `json:"location_data,omitempty"`
-	SignedUpAt             int64                  `json:"signed_up_at,omitempty"`
-	RemoteCreatedAt        int64                  `json:"remote_created_at,omitempty"`
-	LastRequestAt          int64                  `json:"last_request_at,omitempty"`
-	CreatedAt              int64                  `json:"created_at,omitempty"`
-	UpdatedAt              int64                  `json:"updated_at,omitempty"`
-	SessionCount           int64                  `json:"session_count,omitempty"`
-	LastSeenIP             string                 `json:"last_seen_ip,omitempty"`
-	SocialProfiles         *SocialProfileList     `json:"social_profiles,omitempty"`
-	UnsubscribedFromEmails *bool                  `json:"unsubscribed_from_emails,omitempty"`
-	UserAgentData          string                 `json:"user_agent_data,omitempty"`
-	Tags                   *TagList               `json:"tags,omitempty"`
-	Segments               *SegmentList           `json:"segments,omitempty"`
-	Companies              *CompanyList           `json:"companies,omitempty"`
-	CustomAttributes       map[string]interface{} `json:"custom_attributes,omitempty"`
-	UpdateLastRequestAt    *bool                  `json:"update_last_request_at,omitempty"`
-	NewSession             *bool                  `json:"new_session,omitempty"`
-	LastSeenUserAgent      string                 `json:"last_seen_user_agent,omitempty"`
+	ID                     string             `json:"id,omitempty"`
+	Email                  string             `json:"email,omitempty"`
+	Phone                  string             `json:"phone,omitempty"`
+	UserID                 string             `json:"user_id,omitempty"`
+	Anonymous              *bool              `json:"anonymous,omitempty"`
+	Name                   string             `json:"name,omitempty"`
+	Pseudonym              string             `json:"pseudonym,omitempty"`
+	Avatar                 *UserAvatar        `json:"avatar,omitempty"`
+	LocationData           *LocationData      `json:"location_data,omitempty"`
+	SignedUpAt             int64              `json:"signed_up_at,omitempty"`
+	RemoteCreatedAt        int64              `json:"remote_created_at,omitempty"`
+	LastRequestAt          int64              `json:"last_request_at,omitempty"`
+	CreatedAt              int64              `json:"created_at,omitempty"`
+	UpdatedAt              int64              `json:"updated_at,omitempty"`
+	SessionCount           int64              `json:"session_count,omitempty"`
+	LastSeenIP             string             `json:"last_seen_ip,omitempty"`
+	SocialProfiles         *SocialProfileList `json:"social_profiles,omitempty"`
+	UnsubscribedFromEmails *bool              `json:"unsubscribed_from_emails,omitempty"`
+	UserAgentData          string             `json:"user_agent_data,omitempty"`
+	Tags                   *TagList           `json:"tags,omitempty"`
+	Segments               *SegmentList       `json:"segments,omitempty"`
+	Companies              *CompanyList       `json:"companies,omitempty"`
+	CustomAttributes       CustomAttributes   `json:"custom_attributes,omitempty"`
+	UpdateLastRequestAt    *bool              `json:"update_last_request_at,omitempty"`
+	NewSession             *bool              `json:"new_session,omitempty"`
+	LastSeenUserAgent      string             `json:"last_seen_user_agent,omitempty"`
 }
 
 // LocationData represents the location for a User.
@@ -85,6 +89,11 @@ type UserAvatar struct {
 	ImageURL string `json:"image_url,omitempty"`
 }
 
+// A UserDeleteRequest is a request to permanently delete a User.
+type UserDeleteRequest struct {
+	ID json.Number `json:"id"`
+}
+
 type userListParams struct {
 	PageParams
 	SegmentID string `url:"segment_id,omitempty"`
@@ -139,10 +148,20 @@ func (u *UserService) Save(user *User) (User, error) {
 	return u.Repository.save(user)
 }
 
+// Delete archives a User by their Intercom ID. See PermanentlyDelete to erase their data.
 func (u *UserService) Delete(id string) (User, error) {
 	return u.Repository.delete(id)
 }
 
+// PermanentlyDelete requests a User's data be erased by their Intercom ID, such as for a GDPR data subject request.
+// Intercom processes the UserDeleteRequest asynchronously; it cannot be undone.
+func (u *UserService) PermanentlyDelete(id string) (UserDeleteRequest, error) {
+	if id == "" {
+		return UserDeleteRequest{}, errors.New("Missing User ID")
+	}
+	return u.Repository.permanentlyDelete(id)
+}
+
 // MessageAddress gets the address for an User in order to message them
 func (u User) MessageAddress() MessageAddress {
 	return MessageAddress{
@@ -15,6 +15,7 @@ type UserRepository interface {
 	scroll(scrollParam string) (UserList, error)
 	save(*User) (User, error)
 	delete(id string) (User, error)
+	permanentlyDelete(id string) (UserDeleteRequest, error)
 }
 
 // UserAPI implements UserRepository
@@ -26,21 +27,21 @@ type requestScroll struct {
 	ScrollParam string `json:"scroll_param,omitempty"`
 }
 type requestUser struct {
-	ID                     string                 `json:"id,omitempty"`
-	Email                  string                 `json:"email,omitempty"`
-	Phone                  string                 `json:"phone,omitempty"`
-	UserID                 string                 `json:"user_id,omitempty"`
-	Name                   string                 `json:"name,omitempty"`
-	SignedUpAt             int64                  `json:"signed_up_at,omitempty"`
-	RemoteCreatedAt        int64                  `json:"remote_created_at,omitempty"`
-	LastRequestAt          int64                  `json:"last_request_at,omitempty"`
-	LastSeenIP             string                 `json:"last_seen_ip,omitempty"`
-	UnsubscribedFromEmails *bool                  `json:"unsubscribed_from_emails,omitempty"`
-	Companies              []UserCompany          `json:"companies,omitempty"`
-	CustomAttributes       map[string]interface{} `json:"custom_attributes,omitempty"`
-	UpdateLastRequestAt    *bool                  `json:"update_last_request_at,omitempty"`
-	NewSession             *bool                  `json:"new_session,omitempty"`
-	LastSeenUserAgent      string                 `json:"last_seen_user_agent,omitempty"`
+	ID                     string           `json:"id,omitempty"`
+	Email                  string           `json:"email,omitempty"`
+	Phone                  string           `json:"phone,omitempty"`
+	UserID                 string           `json:"user_id,omitempty"`
+	Name                   string           `json:"name,omitempty"`
+	SignedUpAt             int64            `json:"signed_up_at,omitempty"`
+	RemoteCreatedAt        int64            `json:"remote_created_at,omitempty"`
+	LastRequestAt          int64            `json:"last_request_at,omitempty"`
+	LastSeenIP             string           `json:"last_seen_ip,omitempty"`
+	UnsubscribedFromEmails *bool            `json:"unsubscribed_from_emails,omitempty"`
+	Companies              []UserCompany    `json:"companies,omitempty"`
+	CustomAttributes       CustomAttributes `json:"custom_attributes,omitempty"`
+	UpdateLastRequestAt    *bool            `json:"update_last_request_at,omitempty"`
+	NewSession             *bool            `json:"new_session,omitempty"`
+	LastSeenUserAgent      string           `json:"last_seen_user_agent,omitempty"`
 }
 
 func (api UserAPI) find(params UserIdentifiers) (User, error) {
@@ -94,6 +95,20 @@ func unmarshalToUser(data []byte, err error) (User, error) {
 	return savedUser, err
 }
 
+type requestUserDelete struct {
+	IntercomUserID string `json:"intercom_user_id"`
+}
+
+func (api UserAPI) permanentlyDelete(id string) (UserDeleteRequest, error) {
+	deleteRequest := UserDeleteRequest{}
+	data, err := api.httpClient.Post("/user_delete_requests", requestUserDelete{IntercomUserID: id})
+	if err != nil {
+		return deleteRequest, err
+	}
+	err = json.Unmarshal(data, &deleteRequest)
+	return deleteRequest, err
+}
+
 func (api UserAPI) delete(id string) (User, error) {
 	user := User{}
 	data, err := api.httpClient.Delete(fmt.Sprintf("/users/%s", id), nil)
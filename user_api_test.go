@@ -98,6 +98,15 @@ func TestUserAPIDelete(t *testing.T) {
 	api.delete("1234")
 }
 
+func TestUserAPIPermanentlyDelete(t *testing.T) {
+	http := TestUserHTTPClient{t: t, fixtureFilename: "fixtures/user_delete_request.json", expectedURI: "/user_delete_requests"}
+	api := UserAPI{httpClient: &http}
+	request, err := api.permanentlyDelete("1234")
+	if err != nil || request.ID.String() != "8542" {
+		t.Errorf("Delete request was %v, error %v", request, err)
+	}
+}
+
 type TestUserHTTPClient struct {
 	TestHTTPClient
 	t               *testing.T
@@ -93,3 +93,7 @@ func (t TestUserAPI) delete(id string) (User, error) {
 	}
 	return User{}, nil
 }
+
+func (t TestUserAPI) permanentlyDelete(id string) (UserDeleteRequest, error) {
+	return UserDeleteRequest{ID: "8542"}, nil
+}
@@ -0,0 +1,97 @@
+package intercom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// VisitorService handles interactions with the API through a VisitorRepository.
+type VisitorService struct {
+	Repository VisitorRepository
+}
+
+// Visitor represents an anonymous visitor using the Messenger, before they sign up as a User or Lead.
+// Not all of the fields are writeable to the API, non-writeable fields are
+// stripped out from the request. Please see the API documentation for details.
+type Visitor struct {
+	Type                   string             `json:"type,omitempty"`
+	ID                     string             `json:"id,omitempty"`
+	UserID                 string             `json:"user_id,omitempty"` // Generated by the Messenger for the visitor.
+	Anonymous              *bool              `json:"anonymous,omitempty"`
+	Email                  string             `json:"email,omitempty"`
+	Phone                  string             `json:"phone,omitempty"`
+	Name                   string             `json:"name,omitempty"`
+	Pseudonym              string             `json:"pseudonym,omitempty"`
+	Avatar                 *UserAvatar        `json:"avatar,omitempty"`
+	AppID                  string             `json:"app_id,omitempty"`
+	LocationData           *LocationData      `json:"location_data,omitempty"`
+	LastRequestAt          int64              `json:"last_request_at,omitempty"`
+	CreatedAt              int64              `json:"created_at,omitempty"`
+	RemoteCreatedAt        int64              `json:"remote_created_at,omitempty"`
+	SignedUpAt             int64              `json:"signed_up_at,omitempty"`
+	UpdatedAt              int64              `json:"updated_at,omitempty"`
+	SessionCount           int64              `json:"session_count,omitempty"`
+	OwnerID                json.Number        `json:"owner_id,omitempty"` // The ID of the Admin owning the Visitor.
+	UnsubscribedFromEmails *bool              `json:"unsubscribed_from_emails,omitempty"`
+	MarkedEmailAsSpam      *bool              `json:"marked_email_as_spam,omitempty"`
+	HasHardBounced         *bool              `json:"has_hard_bounced,omitempty"`
+	SocialProfiles         *SocialProfileList `json:"social_profiles,omitempty"`
+	Tags                   *TagList           `json:"tags,omitempty"`
+	Segments               *SegmentList       `json:"segments,omitempty"`
+	Companies              *CompanyList       `json:"companies,omitempty"`
+	CustomAttributes       CustomAttributes   `json:"custom_attributes,omitempty"`
+	Referrer               string             `json:"referrer,omitempty"`
+	UTMCampaign            string             `json:"utm_campaign,omitempty"`
+	UTMContent             string             `json:"utm_content,omitempty"`
+	UTMMedium              string             `json:"utm_medium,omitempty"`
+	UTMSource              string             `json:"utm_source,omitempty"`
+	UTMTerm                string             `json:"utm_term,omitempty"`
+	DoNotTrack             *bool              `json:"do_not_track,omitempty"`
+}
+
+// FindByUserID looks up a Visitor by the UserID the Messenger generated for them.
+func (v *VisitorService) FindByUserID(userID string) (Visitor, error) {
+	if userID == "" {
+		return Visitor{}, errors.New("Missing Visitor User ID")
+	}
+	return v.Repository.find(userID)
+}
+
+// Update a Visitor, identified by their ID or UserID. Only the Name and CustomAttributes can be changed.
+func (v *VisitorService) Update(visitor *Visitor) (Visitor, error) {
+	if visitor.ID == "" && visitor.UserID == "" {
+		return Visitor{}, errors.New("Missing Visitor ID Or User ID")
+	}
+	return v.Repository.update(visitor)
+}
+
+// ConvertToUser merges a Visitor into a User, identified by their ID, UserID or Email, creating the User if needed.
+func (v *VisitorService) ConvertToUser(visitor *Visitor, user *User) (User, error) {
+	if err := validateVisitorIdentifiers(visitor); err != nil {
+		return User{}, err
+	}
+	if user.ID == "" && user.UserID == "" && user.Email == "" {
+		return User{}, errors.New("Missing User Identifier")
+	}
+	return v.Repository.convertToUser(visitor, user)
+}
+
+// ConvertToLead converts a Visitor into a Lead.
+func (v *VisitorService) ConvertToLead(visitor *Visitor) (Contact, error) {
+	if err := validateVisitorIdentifiers(visitor); err != nil {
+		return Contact{}, err
+	}
+	return v.Repository.convertToLead(visitor)
+}
+
+func validateVisitorIdentifiers(visitor *Visitor) error {
+	if visitor.ID == "" && visitor.UserID == "" && visitor.Email == "" {
+		return errors.New("Missing Visitor Identifier")
+	}
+	return nil
+}
+
+func (v Visitor) String() string {
+	return fmt.Sprintf("[intercom] visitor { id: %s name: %s, user_id: %s, email: %s }", v.ID, v.Name, v.UserID, v.Email)
+}
@@ -0,0 +1,80 @@
+package intercom
+
+import (
+	"encoding/json"
+
+	"github.com/stefanoschrs/go-intercom/interfaces"
+)
+
+// VisitorRepository defines the interface for working with Visitors through the API.
+type VisitorRepository interface {
+	find(userID string) (Visitor, error)
+	update(*Visitor) (Visitor, error)
+	convertToUser(*Visitor, *User) (User, error)
+	convertToLead(*Visitor) (Contact, error)
+}
+
+// VisitorAPI implements VisitorRepository
+type VisitorAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+type visitorFindParams struct {
+	UserID string `url:"user_id"`
+}
+
+type requestVisitor struct {
+	ID               string                 `json:"id,omitempty"`
+	UserID           string                 `json:"user_id,omitempty"`
+	Name             string                 `json:"name,omitempty"`
+	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
+}
+
+type visitorIdentifiers struct {
+	ID     string `json:"id,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+	Email  string `json:"email,omitempty"`
+}
+
+type visitorConvertRequest struct {
+	Type    string              `json:"type"`
+	User    *visitorIdentifiers `json:"user,omitempty"`
+	Visitor visitorIdentifiers  `json:"visitor"`
+}
+
+func (api VisitorAPI) find(userID string) (Visitor, error) {
+	return unmarshalToVisitor(api.httpClient.Get("/visitors", visitorFindParams{UserID: userID}))
+}
+
+func (api VisitorAPI) update(visitor *Visitor) (Visitor, error) {
+	return unmarshalToVisitor(api.httpClient.Put("/visitors", requestVisitor{
+		ID:               visitor.ID,
+		UserID:           visitor.UserID,
+		Name:             visitor.Name,
+		CustomAttributes: visitor.CustomAttributes,
+	}))
+}
+
+func (api VisitorAPI) convertToUser(visitor *Visitor, user *User) (User, error) {
+	return unmarshalToUser(api.httpClient.Post("/visitors/convert", visitorConvertRequest{
+		Type:    "user",
+		User:    &visitorIdentifiers{ID: user.ID, UserID: user.UserID, Email: user.Email},
+		Visitor: visitorIdentifiers{ID: visitor.ID, UserID: visitor.UserID, Email: visitor.Email},
+	}))
+}
+
+func (api VisitorAPI) convertToLead(visitor *Visitor) (Contact, error) {
+	return unmarshalToContact(api.httpClient.Post("/visitors/convert", visitorConvertRequest{
+		Type:    "lead",
+		Visitor: visitorIdentifiers{ID: visitor.ID, UserID: visitor.UserID, Email: visitor.Email},
+	}))
+}
+
+func unmarshalToVisitor(data []byte, err error) (Visitor, error) {
+	savedVisitor := Visitor{}
+	if err != nil {
+		return savedVisitor, err
+	}
+	err = json.Unmarshal(data, &savedVisitor)
+	return savedVisitor, err
+}
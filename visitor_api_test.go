@@ -0,0 +1,59 @@
+package intercom
+
+import "testing"
+
+func TestVisitorAPIFind(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/visitor.json", expectedURI: "/visitors"}
+	api := VisitorAPI{httpClient: &http}
+	visitor, err := api.find("8a88a590-e1c3-41e2-a502-e0649dbf721c")
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if params := http.lastParams.(visitorFindParams); params.UserID != "8a88a590-e1c3-41e2-a502-e0649dbf721c" {
+		t.Errorf("Params were %v", params)
+	}
+	if visitor.ID != "530370b477ad7120001d" || visitor.Pseudonym != "Red Duck from Dublin" || !*visitor.Anonymous || visitor.OwnerID.String() != "991267834" {
+		t.Errorf("Visitor was %v", visitor)
+	}
+	if visitor.LocationData.CityName != "Dublin" || visitor.Tags.Tags[0].Name != "Pricing page" || visitor.CustomAttributes["plan"] != "trial" || visitor.UTMSource != "google" {
+		t.Errorf("Visitor attributes were %+v", visitor)
+	}
+}
+
+func TestVisitorAPIUpdate(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/visitor.json", expectedURI: "/visitors"}
+	http.f = func(body interface{}) {
+		if request := body.(requestVisitor); request.UserID != "8a88a590" || request.Name != "Winston Smith" || request.CustomAttributes["plan"] != "trial" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := VisitorAPI{httpClient: &http}
+	api.update(&Visitor{UserID: "8a88a590", Name: "Winston Smith", Pseudonym: "ignored", CustomAttributes: CustomAttributes{"plan": "trial"}})
+}
+
+func TestVisitorAPIConvertToUser(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/user.json", expectedURI: "/visitors/convert"}
+	http.f = func(body interface{}) {
+		request := body.(visitorConvertRequest)
+		if request.Type != "user" || request.User.UserID != "27" || request.Visitor.UserID != "8a88a590" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := VisitorAPI{httpClient: &http}
+	if _, err := api.convertToUser(&Visitor{UserID: "8a88a590"}, &User{UserID: "27"}); err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+}
+
+func TestVisitorAPIConvertToLead(t *testing.T) {
+	http := TestArticleHTTPClient{t: t, fixtureFilename: "fixtures/contact.json", expectedURI: "/visitors/convert"}
+	http.f = func(body interface{}) {
+		if request := body.(visitorConvertRequest); request.Type != "lead" || request.User != nil || request.Visitor.ID != "530370b477ad7120001d" {
+			t.Errorf("Request was %v", request)
+		}
+	}
+	api := VisitorAPI{httpClient: &http}
+	if contact, err := api.convertToLead(&Visitor{ID: "530370b477ad7120001d"}); err != nil || contact.ID == "" {
+		t.Errorf("Converted to %v, error %v", contact, err)
+	}
+}
@@ -0,0 +1,51 @@
+package intercom
+
+import "testing"
+
+func TestVisitorValidation(t *testing.T) {
+	visitors := VisitorService{Repository: TestVisitorAPI{t: t}}
+	if _, err := visitors.FindByUserID(""); err == nil || err.Error() != "Missing Visitor User ID" {
+		t.Errorf("Expected a missing user ID error, got %v", err)
+	}
+	if _, err := visitors.Update(&Visitor{Email: "winston@example.io", Name: "Winston"}); err == nil || err.Error() != "Missing Visitor ID Or User ID" {
+		t.Errorf("Expected a missing ID error, got %v", err)
+	}
+	if _, err := visitors.ConvertToLead(&Visitor{Name: "Winston"}); err == nil || err.Error() != "Missing Visitor Identifier" {
+		t.Errorf("Expected a missing visitor identifier error, got %v", err)
+	}
+	if _, err := visitors.ConvertToUser(&Visitor{UserID: "8a88a590"}, &User{Name: "Winston"}); err == nil || err.Error() != "Missing User Identifier" {
+		t.Errorf("Expected a missing user identifier error, got %v", err)
+	}
+}
+
+func TestVisitorConvert(t *testing.T) {
+	visitors := VisitorService{Repository: TestVisitorAPI{t: t}}
+	user, err := visitors.ConvertToUser(&Visitor{UserID: "8a88a590"}, &User{Email: "winston@example.io"})
+	if err != nil || user.Email != "winston@example.io" {
+		t.Errorf("Converted to %v, error %v", user, err)
+	}
+	lead, err := visitors.ConvertToLead(&Visitor{Email: "winston@example.io"})
+	if err != nil || lead.Email != "winston@example.io" {
+		t.Errorf("Converted to %v, error %v", lead, err)
+	}
+}
+
+type TestVisitorAPI struct {
+	t *testing.T
+}
+
+func (t TestVisitorAPI) find(userID string) (Visitor, error) {
+	return Visitor{UserID: userID}, nil
+}
+
+func (t TestVisitorAPI) update(visitor *Visitor) (Visitor, error) {
+	return *visitor, nil
+}
+
+func (t TestVisitorAPI) convertToUser(visitor *Visitor, user *User) (User, error) {
+	return User{ID: "5ba682d23d7cf92bef87bfd4", Email: user.Email}, nil
+}
+
+func (t TestVisitorAPI) convertToLead(visitor *Visitor) (Contact, error) {
+	return Contact{ID: "5ba682d23d7cf92bef87bfd5", Email: visitor.Email}, nil
+}